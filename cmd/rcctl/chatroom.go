@@ -0,0 +1,144 @@
+package main
+
+import "strings"
+
+var chatroomCommands = []command{
+	{"create", "<chatroomId> <name>", "创建聊天室", func(c *call) (interface{}, error) {
+		if err := c.parse(2); err != nil {
+			return nil, err
+		}
+		return nil, c.rc.ChatRoomCreateContext(c.ctx, c.Arg(0), c.Arg(1))
+	}},
+	{"destroy", "<chatroomId>", "销毁聊天室", func(c *call) (interface{}, error) {
+		if err := c.parse(1); err != nil {
+			return nil, err
+		}
+		return nil, c.rc.ChatRoomDestroyContext(c.ctx, c.Arg(0))
+	}},
+	{"query", "<chatroomId>...", "查询聊天室信息", func(c *call) (interface{}, error) {
+		if err := c.parse(1); err != nil {
+			return nil, err
+		}
+		return c.rc.ChatRoomQueryContext(c.ctx, c.list(0))
+	}},
+	{"members", "<chatroomId>", "查询聊天室成员", func(c *call) (interface{}, error) {
+		count := c.Int("count", 100, "返回的成员数，最多 500")
+		order := c.Int("order", 1, "1 按加入时间正序，2 按加入时间倒序")
+		if err := c.parse(1); err != nil {
+			return nil, err
+		}
+		return c.rc.ChatRoomGetContext(c.ctx, c.Arg(0), *count, *order)
+	}},
+	{"exist", "<chatroomId> <userId>...", "查询用户是否在聊天室中", func(c *call) (interface{}, error) {
+		if err := c.parse(2); err != nil {
+			return nil, err
+		}
+		return c.rc.ChatRoomIsExistContext(c.ctx, c.Arg(0), c.list(1))
+	}},
+	{"block-add", "<chatroomId> <userId>...", "封禁聊天室成员", func(c *call) (interface{}, error) {
+		minute := c.Uint("minute", 60, "封禁时长（分钟），最长 43200")
+		if err := c.parse(2); err != nil {
+			return nil, err
+		}
+		return nil, c.rc.ChatRoomBlockAddContext(c.ctx, c.Arg(0), c.list(1), *minute)
+	}},
+	{"block-remove", "<chatroomId> <userId>...", "解除聊天室成员封禁", func(c *call) (interface{}, error) {
+		if err := c.parse(2); err != nil {
+			return nil, err
+		}
+		return nil, c.rc.ChatRoomBlockRemoveContext(c.ctx, c.Arg(0), c.list(1))
+	}},
+	{"block-list", "<chatroomId>", "查询被封禁的聊天室成员", func(c *call) (interface{}, error) {
+		if err := c.parse(1); err != nil {
+			return nil, err
+		}
+		return c.rc.ChatRoomBlockGetListContext(c.ctx, c.Arg(0))
+	}},
+	{"gag-add", "<chatroomId> <userId>...", "禁言聊天室成员", func(c *call) (interface{}, error) {
+		minute := c.Uint("minute", 60, "禁言时长（分钟），最长 43200")
+		if err := c.parse(2); err != nil {
+			return nil, err
+		}
+		return nil, c.rc.ChatRoomGagAddContext(c.ctx, c.Arg(0), c.list(1), *minute)
+	}},
+	{"gag-remove", "<chatroomId> <userId>...", "解除聊天室成员禁言", func(c *call) (interface{}, error) {
+		if err := c.parse(2); err != nil {
+			return nil, err
+		}
+		return nil, c.rc.ChatRoomGagRemoveContext(c.ctx, c.Arg(0), c.list(1))
+	}},
+	{"gag-list", "<chatroomId>", "查询被禁言的聊天室成员", func(c *call) (interface{}, error) {
+		if err := c.parse(1); err != nil {
+			return nil, err
+		}
+		return c.rc.ChatRoomGagGetListContext(c.ctx, c.Arg(0))
+	}},
+	{"ban-add", "<userId>...", "在所有聊天室中禁言用户", func(c *call) (interface{}, error) {
+		minute := c.Uint("minute", 60, "禁言时长（分钟），最长 43200")
+		if err := c.parse(1); err != nil {
+			return nil, err
+		}
+		return nil, c.rc.ChatRoomBanAddContext(c.ctx, c.list(0), *minute)
+	}},
+	{"ban-remove", "<userId>...", "解除聊天室全局禁言", func(c *call) (interface{}, error) {
+		if err := c.parse(1); err != nil {
+			return nil, err
+		}
+		return nil, c.rc.ChatRoomBanRemoveContext(c.ctx, c.list(0))
+	}},
+	{"ban-list", "", "查询聊天室全局禁言的用户", func(c *call) (interface{}, error) {
+		if err := c.parse(0); err != nil {
+			return nil, err
+		}
+		return c.rc.ChatRoomBanGetListContext(c.ctx)
+	}},
+	{"stop-distribution", "<chatroomId>", "停止聊天室消息分发", func(c *call) (interface{}, error) {
+		if err := c.parse(1); err != nil {
+			return nil, err
+		}
+		return nil, c.rc.ChatRoomDistributionStopContext(c.ctx, c.Arg(0))
+	}},
+	{"resume-distribution", "<chatroomId>", "恢复聊天室消息分发", func(c *call) (interface{}, error) {
+		if err := c.parse(1); err != nil {
+			return nil, err
+		}
+		return nil, c.rc.ChatRoomDistributionResumeContext(c.ctx, c.Arg(0))
+	}},
+	{"keepalive-add", "<chatroomId>", "添加聊天室保活", func(c *call) (interface{}, error) {
+		if err := c.parse(1); err != nil {
+			return nil, err
+		}
+		return nil, c.rc.ChatRoomKeepAliveAddContext(c.ctx, c.Arg(0))
+	}},
+	{"keepalive-remove", "<chatroomId>", "移除聊天室保活", func(c *call) (interface{}, error) {
+		if err := c.parse(1); err != nil {
+			return nil, err
+		}
+		return nil, c.rc.ChatRoomKeepAliveRemoveContext(c.ctx, c.Arg(0))
+	}},
+	{"keepalive-list", "", "查询保活的聊天室", func(c *call) (interface{}, error) {
+		if err := c.parse(0); err != nil {
+			return nil, err
+		}
+		return c.rc.ChatRoomKeepAliveGetListContext(c.ctx)
+	}},
+	{"entry-set", "<chatroomId> <userId> <key> <value>", "设置聊天室属性", func(c *call) (interface{}, error) {
+		autoDelete := c.Bool("auto-delete", false, "用户退出聊天室后是否删除该属性")
+		if err := c.parse(4); err != nil {
+			return nil, err
+		}
+		return nil, c.rc.ChatRoomEntrySetContext(c.ctx, c.Arg(0), c.Arg(1), c.Arg(2), c.Arg(3), *autoDelete)
+	}},
+	{"entry-remove", "<chatroomId> <userId> <key>", "删除聊天室属性", func(c *call) (interface{}, error) {
+		if err := c.parse(3); err != nil {
+			return nil, err
+		}
+		return nil, c.rc.ChatRoomEntryRemoveContext(c.ctx, c.Arg(0), c.Arg(1), c.Arg(2))
+	}},
+	{"entry-query", "<chatroomId> [key]...", "查询聊天室属性，不指定 key 时查询全部", func(c *call) (interface{}, error) {
+		if err := c.parse(1); err != nil {
+			return nil, err
+		}
+		return c.rc.ChatRoomEntryQueryContext(c.ctx, c.Arg(0), strings.Join(c.list(1), ","))
+	}},
+}
@@ -0,0 +1,21 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/rongcloud/server-sdk-go/v3/sdk"
+)
+
+// loadConfig 使用 sdk.LoadConfig 读取配置文件及环境变量，配置项及环境变量见 sdk.Config
+// file 为空且未设置环境变量 RC_CONFIG 时读取 ~/.rcctl.json，该文件不存在时只使用环境变量
+func loadConfig(file, profile string) (*sdk.Config, error) {
+	if file == "" && os.Getenv("RC_CONFIG") == "" {
+		if home, err := os.UserHomeDir(); err == nil {
+			if _, err := os.Stat(filepath.Join(home, ".rcctl.json")); err == nil {
+				file = filepath.Join(home, ".rcctl.json")
+			}
+		}
+	}
+	return sdk.LoadConfig(file, profile)
+}
@@ -0,0 +1,96 @@
+package main
+
+var groupCommands = []command{
+	{"create", "<groupId> <name> <userId>...", "创建群组", func(c *call) (interface{}, error) {
+		if err := c.parse(3); err != nil {
+			return nil, err
+		}
+		return nil, c.rc.GroupCreateContext(c.ctx, c.Arg(0), c.Arg(1), c.list(2))
+	}},
+	{"update", "<groupId> <name>", "修改群组名称", func(c *call) (interface{}, error) {
+		if err := c.parse(2); err != nil {
+			return nil, err
+		}
+		return nil, c.rc.GroupUpdateContext(c.ctx, c.Arg(0), c.Arg(1))
+	}},
+	{"join", "<groupId> <userId>", "加入群组", func(c *call) (interface{}, error) {
+		name := c.String("name", "", "群组名称")
+		if err := c.parse(2); err != nil {
+			return nil, err
+		}
+		return nil, c.rc.GroupJoinContext(c.ctx, c.Arg(0), *name, c.Arg(1))
+	}},
+	{"quit", "<groupId> <userId>", "退出群组", func(c *call) (interface{}, error) {
+		if err := c.parse(2); err != nil {
+			return nil, err
+		}
+		return nil, c.rc.GroupQuitContext(c.ctx, c.Arg(1), c.Arg(0))
+	}},
+	{"dismiss", "<groupId> <userId>", "解散群组", func(c *call) (interface{}, error) {
+		if err := c.parse(2); err != nil {
+			return nil, err
+		}
+		return nil, c.rc.GroupDismissContext(c.ctx, c.Arg(0), c.Arg(1))
+	}},
+	{"members", "<groupId>", "查询群组成员", func(c *call) (interface{}, error) {
+		if err := c.parse(1); err != nil {
+			return nil, err
+		}
+		return c.rc.GroupGetContext(c.ctx, c.Arg(0))
+	}},
+	{"mute-add", "<groupId> <userId>...", "禁言群成员", func(c *call) (interface{}, error) {
+		minute := c.Int("minute", 60, "禁言时长（分钟），最长 43200")
+		if err := c.parse(2); err != nil {
+			return nil, err
+		}
+		return nil, c.rc.GroupMuteMembersAddContext(c.ctx, c.Arg(0), c.list(1), *minute)
+	}},
+	{"mute-remove", "<groupId> <userId>...", "解除群成员禁言", func(c *call) (interface{}, error) {
+		if err := c.parse(2); err != nil {
+			return nil, err
+		}
+		return nil, c.rc.GroupMuteMembersRemoveContext(c.ctx, c.Arg(0), c.list(1))
+	}},
+	{"mute-list", "<groupId>", "查询被禁言的群成员", func(c *call) (interface{}, error) {
+		if err := c.parse(1); err != nil {
+			return nil, err
+		}
+		return c.rc.GroupMuteMembersGetListContext(c.ctx, c.Arg(0))
+	}},
+	{"mute-all-add", "<groupId>...", "设置群组全体禁言", func(c *call) (interface{}, error) {
+		if err := c.parse(1); err != nil {
+			return nil, err
+		}
+		return nil, c.rc.GroupMuteAllMembersAddContext(c.ctx, c.list(0))
+	}},
+	{"mute-all-remove", "<groupId>...", "解除群组全体禁言", func(c *call) (interface{}, error) {
+		if err := c.parse(1); err != nil {
+			return nil, err
+		}
+		return nil, c.rc.GroupMuteAllMembersRemoveContext(c.ctx, c.list(0))
+	}},
+	{"mute-all-list", "[groupId]...", "查询全体禁言的群组，不指定群组时查询全部", func(c *call) (interface{}, error) {
+		if err := c.parse(0); err != nil {
+			return nil, err
+		}
+		return c.rc.GroupMuteAllMembersGetListContext(c.ctx, c.list(0))
+	}},
+	{"mute-whitelist-add", "<groupId> <userId>...", "添加全体禁言白名单", func(c *call) (interface{}, error) {
+		if err := c.parse(2); err != nil {
+			return nil, err
+		}
+		return nil, c.rc.GroupMuteWhiteListUserAddContext(c.ctx, c.Arg(0), c.list(1))
+	}},
+	{"mute-whitelist-remove", "<groupId> <userId>...", "移除全体禁言白名单", func(c *call) (interface{}, error) {
+		if err := c.parse(2); err != nil {
+			return nil, err
+		}
+		return nil, c.rc.GroupMuteWhiteListUserRemoveContext(c.ctx, c.Arg(0), c.list(1))
+	}},
+	{"mute-whitelist", "<groupId>", "查询全体禁言白名单", func(c *call) (interface{}, error) {
+		if err := c.parse(1); err != nil {
+			return nil, err
+		}
+		return c.rc.GroupMuteWhiteListUserGetListContext(c.ctx, c.Arg(0))
+	}},
+}
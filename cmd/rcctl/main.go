@@ -0,0 +1,244 @@
+// rcctl 融云应用运维命令行工具，用于注册用户、封禁、禁言、发送系统消息、获取历史消息日志等一次性操作
+//
+//	rcctl [-config file] [-o table|json] <domain> <command> [flags] [args]
+//	rcctl user register u01 Alice
+//	rcctl -o json chatroom gag-list c01
+//
+// appKey、appSecret 依次从环境变量 RC_APP_KEY、RC_APP_SECRET 及配置文件读取，
+// 配置文件通过 -config 或环境变量 RC_CONFIG 指定，默认为 ~/.rcctl.json
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/rongcloud/server-sdk-go/v3/sdk"
+)
+
+// errUsage 参数错误，已输出用法
+var errUsage = errors.New("usage error")
+
+// command 子命令
+type command struct {
+	name  string
+	args  string // 位置参数说明，如 <userId> <name>
+	usage string
+	run   func(c *call) (interface{}, error)
+}
+
+// domain 按接口领域划分的命令组
+type domain struct {
+	name     string
+	usage    string
+	commands []command
+}
+
+var domains = []domain{
+	{"user", "用户", userCommands},
+	{"group", "群组", groupCommands},
+	{"chatroom", "聊天室", chatroomCommands},
+	{"message", "消息", messageCommands},
+	{"sensitive", "敏感词", sensitiveCommands},
+	{"push", "推送", pushCommands},
+}
+
+// call 一次命令执行，命令在 run 中定义 flag 后调用 parse
+type call struct {
+	*flag.FlagSet
+	ctx  context.Context
+	rc   *sdk.RongCloud
+	args []string
+}
+
+// parse 解析 flag，并校验至少有 n 个位置参数
+func (c *call) parse(n int) error {
+	if err := c.Parse(c.args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return errUsage
+	}
+	if c.NArg() < n {
+		c.Usage()
+		return errUsage
+	}
+	return nil
+}
+
+// require 校验命令行中显式传入了指定的 flag，需在 parse 之后调用
+func (c *call) require(names ...string) error {
+	set := map[string]bool{}
+	c.Visit(func(f *flag.Flag) {
+		set[f.Name] = true
+	})
+	for _, name := range names {
+		if !set[name] {
+			fmt.Fprintf(c.Output(), "flag -%s is required\n", name)
+			c.Usage()
+			return errUsage
+		}
+	}
+	return nil
+}
+
+// list 第 i 个及之后的位置参数，逗号分隔的参数会被拆分
+func (c *call) list(i int) []string {
+	var list []string
+	for _, arg := range c.Args()[i:] {
+		for _, v := range strings.Split(arg, ",") {
+			if v != "" {
+				list = append(list, v)
+			}
+		}
+	}
+	return list
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("rcctl", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	configFile := fs.String("config", os.Getenv("RC_CONFIG"), "配置文件路径（yaml 或 json），默认为 ~/.rcctl.json")
+	profile := fs.String("profile", os.Getenv("RC_PROFILE"), "使用配置文件中的 profile")
+	output := fs.String("o", "table", "输出格式：table、json")
+	uri := fs.String("uri", "", "API 地址，默认使用融云的主备地址")
+	timeout := fs.Int("timeout", 0, "请求超时时间（秒），默认使用配置文件中的 timeout，都未设置时为 10 秒")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: rcctl [flags] <domain> <command> [flags] [args]")
+		fs.PrintDefaults()
+		fmt.Fprintln(stderr, "\ndomains:")
+		for _, d := range domains {
+			fmt.Fprintf(stderr, "  %-10s %s\n", d.name, d.usage)
+		}
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+	if *output != "table" && *output != "json" {
+		fmt.Fprintf(stderr, "rcctl: invalid output format %q\n", *output)
+		return 2
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return 2
+	}
+
+	d, ok := findDomain(fs.Arg(0))
+	if !ok {
+		fmt.Fprintf(stderr, "rcctl: unknown domain %q\n", fs.Arg(0))
+		fs.Usage()
+		return 2
+	}
+	if fs.NArg() == 1 {
+		domainUsage(stderr, d)
+		return 2
+	}
+	cmd, ok := d.find(fs.Arg(1))
+	if !ok {
+		fmt.Fprintf(stderr, "rcctl: unknown command %q\n", fs.Arg(1))
+		domainUsage(stderr, d)
+		return 2
+	}
+
+	cfg, err := loadConfig(*configFile, *profile)
+	if err != nil {
+		fmt.Fprintln(stderr, "rcctl:", err)
+		return 2
+	}
+	// 命令行参数优先于配置文件及环境变量
+	if *uri != "" {
+		cfg.URI, cfg.Endpoints = *uri, nil
+	}
+	if *timeout != 0 {
+		cfg.Timeout = sdk.Duration(time.Duration(*timeout) * time.Second)
+	}
+	rc, err := sdk.NewFromConfig(cfg)
+	if err != nil {
+		fmt.Fprintln(stderr, "rcctl:", err)
+		return 2
+	}
+	defer rc.Close()
+
+	// Ctrl+C 取消正在执行的请求
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	defer signal.Stop(interrupt)
+	go func() {
+		select {
+		case <-interrupt:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	name := "rcctl " + d.name + " " + cmd.name
+	c := &call{FlagSet: flag.NewFlagSet(name, flag.ContinueOnError), ctx: ctx, rc: rc, args: fs.Args()[2:]}
+	c.SetOutput(stderr)
+	c.Usage = func() {
+		fmt.Fprintf(stderr, "usage: %s [flags] %s\n%s\n", name, cmd.args, cmd.usage)
+		c.PrintDefaults()
+	}
+	result, err := cmd.run(c)
+	if err != nil {
+		if code := exitCode(err); code != 1 {
+			return code
+		}
+		fmt.Fprintln(stderr, "rcctl:", err)
+		return 1
+	}
+	if err := printResult(stdout, *output, result); err != nil {
+		fmt.Fprintln(stderr, "rcctl:", err)
+		return 1
+	}
+	return 0
+}
+
+// exitCode 参数错误返回 2，-h 返回 0，其他错误返回 1
+func exitCode(err error) int {
+	switch {
+	case errors.Is(err, flag.ErrHelp):
+		return 0
+	case errors.Is(err, errUsage):
+		return 2
+	}
+	return 1
+}
+
+func findDomain(name string) (domain, bool) {
+	for _, d := range domains {
+		if d.name == name {
+			return d, true
+		}
+	}
+	return domain{}, false
+}
+
+func (d domain) find(name string) (command, bool) {
+	for _, cmd := range d.commands {
+		if cmd.name == name {
+			return cmd, true
+		}
+	}
+	return command{}, false
+}
+
+func domainUsage(w io.Writer, d domain) {
+	fmt.Fprintf(w, "usage: rcctl %s <command> [flags] [args]\n\ncommands:\n", d.name)
+	for _, cmd := range d.commands {
+		fmt.Fprintf(w, "  %-22s %s\n", cmd.name+" "+cmd.args, cmd.usage)
+	}
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rongcloud/server-sdk-go/v3/sdk"
+	"github.com/rongcloud/server-sdk-go/v3/sdk/sdktest"
+)
+
+// tempDir 创建临时目录，返回的函数用于删除该目录
+func tempDir(t *testing.T) (string, func()) {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "rcctl-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir, func() { _ = os.RemoveAll(dir) }
+}
+
+// rcctl 使用配置文件连接模拟服务执行命令
+func rcctl(t *testing.T, server *sdktest.Server, args ...string) (code int, stdout, stderr string) {
+	t.Helper()
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	file := filepath.Join(dir, "rcctl.json")
+	data, _ := json.Marshal(sdk.Config{AppKey: server.AppKey, AppSecret: server.AppSecret, URI: server.URL})
+	if err := ioutil.WriteFile(file, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	var out, errOut bytes.Buffer
+	code = run(append([]string{"-config", file}, args...), &out, &errOut)
+	return code, out.String(), errOut.String()
+}
+
+func TestRcctl_User(t *testing.T) {
+	server := sdktest.NewServer("appKey", "appSecret")
+	defer server.Close()
+
+	code, stdout, stderr := rcctl(t, server, "user", "register", "-portrait", "a.png", "u01", "Alice")
+	if code != 0 {
+		t.Fatalf("exit %d: %s", code, stderr)
+	}
+	token, _ := server.Token("u01")
+	if !strings.Contains(stdout, "token") || !strings.Contains(stdout, token) {
+		t.Errorf("stdout = %q", stdout)
+	}
+	if r, _ := server.LastRequest("/user/getToken"); r.Form.Get("portraitUri") != "a.png" {
+		t.Errorf("form = %v", r.Form)
+	}
+
+	if code, _, stderr = rcctl(t, server, "user", "update", "-name", "Bob", "u01"); code != 0 {
+		t.Fatalf("update: exit %d: %s", code, stderr)
+	}
+	if r, _ := server.LastRequest("/user/refresh"); r.Form.Get("name") != "Bob" {
+		t.Errorf("update form = %v", r.Form)
+	}
+
+	if code, stdout, _ = rcctl(t, server, "user", "block", "-minute", "10", "u01"); code != 0 || stdout != "OK\n" {
+		t.Errorf("block: exit %d, %q", code, stdout)
+	}
+	code, stdout, _ = rcctl(t, server, "-o", "json", "user", "block-list")
+	var blocked struct {
+		Users []struct {
+			UserID string `json:"userId"`
+		} `json:"users"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &blocked); err != nil || code != 0 || len(blocked.Users) != 1 || blocked.Users[0].UserID != "u01" {
+		t.Errorf("block-list: exit %d, %q", code, stdout)
+	}
+}
+
+func TestRcctl_Table(t *testing.T) {
+	server := sdktest.NewServer("appKey", "appSecret")
+	defer server.Close()
+
+	rcctl(t, server, "chatroom", "gag-add", "c01", "u01,u02", "u03")
+	code, stdout, _ := rcctl(t, server, "chatroom", "gag-list", "c01")
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	if code != 0 || len(lines) != 4 || !strings.Contains(lines[0], "USERID") || !strings.Contains(lines[3], "u03") {
+		t.Errorf("gag-list: exit %d:\n%s", code, stdout)
+	}
+}
+
+func TestRcctl_Message(t *testing.T) {
+	server := sdktest.NewServer("appKey", "appSecret")
+	defer server.Close()
+
+	code, _, stderr := rcctl(t, server, "message", "system", "-text", "hello", "admin", "u01", "u02")
+	if code != 0 {
+		t.Fatalf("exit %d: %s", code, stderr)
+	}
+	messages := server.Messages("/message/system/publish")
+	if len(messages) != 1 || messages[0].Content != `{"content":"hello"}` || len(messages[0].TargetIDs) != 2 {
+		t.Errorf("messages = %+v", messages)
+	}
+
+	if code, _, _ = rcctl(t, server, "message", "private", "-content", "{", "admin", "u01"); code != 1 {
+		t.Errorf("invalid content: exit %d, want 1", code)
+	}
+}
+
+func TestRcctl_Profile(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	server := sdktest.NewServer("appKey", "appSecret")
+	defer server.Close()
+
+	file := filepath.Join(dir, "rcctl.yaml")
+	content := "appKey: appKey\nappSecret: appSecret\nendpoints: [http://127.0.0.1:1]\nprofiles:\n  local:\n    uri: " + server.URL + "\n"
+	if err := ioutil.WriteFile(file, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	var out, errOut bytes.Buffer
+	if code := run([]string{"-config", file, "-profile", "local", "user", "block-list"}, &out, &errOut); code != 0 {
+		t.Errorf("exit %d: %s", code, errOut.String())
+	}
+}
+
+func TestRcctl_Errors(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	server := sdktest.NewServer("appKey", "appSecret")
+	defer server.Close()
+
+	for _, args := range [][]string{
+		{},
+		{"unknown"},
+		{"user"},
+		{"user", "unknown"},
+		{"user", "register", "u01"},
+		{"user", "update", "-portrait", "b.png", "u01"},
+		{"user", "block", "-minute", "x", "u01"},
+		{"-o", "xml", "user", "block-list"},
+	} {
+		if code, _, _ := rcctl(t, server, args...); code != 2 {
+			t.Errorf("%v: exit %d, want 2", args, code)
+		}
+	}
+	if code, _, _ := rcctl(t, server, "user", "register", "-h"); code != 0 {
+		t.Errorf("-h: exit %d, want 0", code)
+	}
+
+	server.FailNext("/user/getToken", http.StatusInternalServerError, 1000)
+	code, _, stderr := rcctl(t, server, "user", "register", "u01", "Alice")
+	if code != 1 || !strings.Contains(stderr, "1000") {
+		t.Errorf("api error: exit %d, %q", code, stderr)
+	}
+
+	var out, errOut bytes.Buffer
+	missing := filepath.Join(dir, "missing.json")
+	if code := run([]string{"-config", missing, "user", "block-list"}, &out, &errOut); code != 2 {
+		t.Errorf("missing config: exit %d, want 2", code)
+	}
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"strconv"
+
+	"github.com/rongcloud/server-sdk-go/v3/sdk"
+)
+
+// msgFlags 发送消息的公共参数
+type msgFlags struct {
+	objectName  *string
+	content     *string
+	text        *string
+	pushContent *string
+	pushData    *string
+}
+
+func newMsgFlags(fs *flag.FlagSet) msgFlags {
+	return msgFlags{
+		objectName:  fs.String("type", "RC:TxtMsg", "消息类型"),
+		content:     fs.String("content", "", "json 格式的消息内容"),
+		text:        fs.String("text", "", "文本消息内容，等同于 -content '{\"content\":\"...\"}'"),
+		pushContent: fs.String("push-content", "", "推送通知内容"),
+		pushData:    fs.String("push-data", "", "推送附加信息"),
+	}
+}
+
+// msg 消息内容，-content 与 -text 二选一
+func (f msgFlags) msg() (sdk.RCMsg, error) {
+	switch {
+	case *f.content != "" && *f.text != "":
+		return nil, fmt.Errorf("-content and -text are mutually exclusive")
+	case *f.text != "":
+		content, err := json.Marshal(map[string]string{"content": *f.text})
+		return sdk.RawMsg(content), err
+	case *f.content != "":
+		if !json.Valid([]byte(*f.content)) {
+			return nil, fmt.Errorf("-content is not valid json")
+		}
+		return sdk.RawMsg(*f.content), nil
+	}
+	return nil, fmt.Errorf("-content or -text is required")
+}
+
+var messageCommands = []command{
+	{"private", "<fromUserId> <toUserId>...", "发送单聊消息", func(c *call) (interface{}, error) {
+		f := newMsgFlags(c.FlagSet)
+		if err := c.parse(2); err != nil {
+			return nil, err
+		}
+		msg, err := f.msg()
+		if err != nil {
+			return nil, err
+		}
+		return nil, c.rc.PrivateSendContext(c.ctx, c.Arg(0), c.list(1), *f.objectName, msg,
+			*f.pushContent, *f.pushData, 0, 0, 1, 0, 0)
+	}},
+	{"system", "<fromUserId> <toUserId>...", "发送系统消息", func(c *call) (interface{}, error) {
+		f := newMsgFlags(c.FlagSet)
+		if err := c.parse(2); err != nil {
+			return nil, err
+		}
+		msg, err := f.msg()
+		if err != nil {
+			return nil, err
+		}
+		return nil, c.rc.SystemSendContext(c.ctx, c.Arg(0), c.list(1), *f.objectName, msg,
+			*f.pushContent, *f.pushData, 0, 1)
+	}},
+	{"group", "<fromUserId> <groupId>...", "发送群组消息", func(c *call) (interface{}, error) {
+		f := newMsgFlags(c.FlagSet)
+		if err := c.parse(2); err != nil {
+			return nil, err
+		}
+		msg, err := f.msg()
+		if err != nil {
+			return nil, err
+		}
+		return nil, c.rc.GroupSendContext(c.ctx, c.Arg(0), c.list(1), nil, *f.objectName, msg,
+			*f.pushContent, *f.pushData, 1, 0)
+	}},
+	{"chatroom", "<fromUserId> <chatroomId>...", "发送聊天室消息", func(c *call) (interface{}, error) {
+		f := newMsgFlags(c.FlagSet)
+		if err := c.parse(2); err != nil {
+			return nil, err
+		}
+		msg, err := f.msg()
+		if err != nil {
+			return nil, err
+		}
+		return nil, c.rc.ChatRoomSendContext(c.ctx, c.Arg(0), c.list(1), *f.objectName, msg)
+	}},
+	{"broadcast", "<fromUserId>", "向应用内所有用户发送广播消息", func(c *call) (interface{}, error) {
+		f := newMsgFlags(c.FlagSet)
+		if err := c.parse(1); err != nil {
+			return nil, err
+		}
+		msg, err := f.msg()
+		if err != nil {
+			return nil, err
+		}
+		return nil, c.rc.SystemBroadcastContext(c.ctx, c.Arg(0), *f.objectName, msg)
+	}},
+	{"recall", "<fromUserId> <targetId> <messageUID> <sentTime>", "撤回消息", func(c *call) (interface{}, error) {
+		conversation := c.String("conversation", "private", "会话类型：private、group、chatroom、system")
+		if err := c.parse(4); err != nil {
+			return nil, err
+		}
+		sentTime, err := strconv.Atoi(c.Arg(3))
+		if err != nil {
+			return nil, fmt.Errorf("invalid sentTime %q", c.Arg(3))
+		}
+		switch *conversation {
+		case "private":
+			return nil, c.rc.PrivateRecallContext(c.ctx, c.Arg(0), c.Arg(1), c.Arg(2), sentTime)
+		case "group":
+			return nil, c.rc.GroupRecallContext(c.ctx, c.Arg(0), c.Arg(1), c.Arg(2), sentTime)
+		case "chatroom":
+			return nil, c.rc.ChatRoomRecallContext(c.ctx, c.Arg(0), c.Arg(1), c.Arg(2), sentTime)
+		case "system":
+			return nil, c.rc.SystemRecallContext(c.ctx, c.Arg(0), c.Arg(1), c.Arg(2), sentTime)
+		}
+		return nil, fmt.Errorf("invalid conversation type %q", *conversation)
+	}},
+	{"history", "<date>", "获取历史消息日志下载地址，date 格式为 2006010215", func(c *call) (interface{}, error) {
+		if err := c.parse(1); err != nil {
+			return nil, err
+		}
+		return c.rc.HistoryGetContext(c.ctx, c.Arg(0))
+	}},
+	{"history-remove", "<date>", "删除历史消息日志", func(c *call) (interface{}, error) {
+		if err := c.parse(1); err != nil {
+			return nil, err
+		}
+		return nil, c.rc.HistoryRemoveContext(c.ctx, c.Arg(0))
+	}},
+}
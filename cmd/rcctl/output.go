@@ -0,0 +1,156 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+)
+
+// printResult 按 format 输出命令结果，结果为 nil 时表示执行成功
+func printResult(w io.Writer, format string, result interface{}) error {
+	if format == "json" {
+		if result == nil {
+			result = map[string]int{"code": 200}
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(result)
+	}
+	if result == nil {
+		_, err := fmt.Fprintln(w, "OK")
+		return err
+	}
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	printTable(tw, reflect.ValueOf(result))
+	return tw.Flush()
+}
+
+// printTable 以表格输出：结构体切片每个元素一行，结构体及 map 每个字段一行，
+// 只有一个切片字段的结构体（如 BlockListResult）直接输出该切片
+func printTable(w io.Writer, v reflect.Value) {
+	v = indirect(v)
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			fmt.Fprintln(w, string(v.Bytes()))
+			return
+		}
+		elem := v.Type().Elem()
+		for elem.Kind() == reflect.Ptr {
+			elem = elem.Elem()
+		}
+		if elem.Kind() != reflect.Struct {
+			for i := 0; i < v.Len(); i++ {
+				fmt.Fprintln(w, cell(v.Index(i)))
+			}
+			return
+		}
+		fields := structFields(elem)
+		var header []string
+		for _, f := range fields {
+			header = append(header, strings.ToUpper(f.name))
+		}
+		fmt.Fprintln(w, strings.Join(header, "\t"))
+		for i := 0; i < v.Len(); i++ {
+			row := indirect(v.Index(i))
+			var cells []string
+			for _, f := range fields {
+				cells = append(cells, cell(row.FieldByIndex(f.index)))
+			}
+			fmt.Fprintln(w, strings.Join(cells, "\t"))
+		}
+	case reflect.Struct:
+		fields := structFields(v.Type())
+		if len(fields) == 1 && indirect(v.FieldByIndex(fields[0].index)).Kind() == reflect.Slice {
+			printTable(w, v.FieldByIndex(fields[0].index))
+			return
+		}
+		for _, f := range fields {
+			fmt.Fprintf(w, "%s\t%s\n", f.name, cell(v.FieldByIndex(f.index)))
+		}
+	case reflect.Map:
+		keys := v.MapKeys()
+		names := make([]string, len(keys))
+		for i, k := range keys {
+			names[i] = fmt.Sprint(k.Interface())
+		}
+		sortTogether(names, keys)
+		for i, k := range keys {
+			fmt.Fprintf(w, "%s\t%s\n", names[i], cell(v.MapIndex(k)))
+		}
+	case reflect.Invalid:
+		fmt.Fprintln(w, "OK")
+	default:
+		fmt.Fprintln(w, cell(v))
+	}
+}
+
+type field struct {
+	name  string
+	index []int
+}
+
+// structFields 结构体中可导出的字段，名称取 json tag，忽略匿名嵌入的 CodeResult
+func structFields(t reflect.Type) []field {
+	var fields []field
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous || f.PkgPath != "" {
+			continue
+		}
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields = append(fields, field{name: name, index: f.Index})
+	}
+	return fields
+}
+
+// cell 单元格内容，切片以逗号分隔，结构体及 map 输出为 json
+func cell(v reflect.Value) string {
+	v = indirect(v)
+	switch v.Kind() {
+	case reflect.Invalid:
+		return ""
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.String {
+			list := make([]string, v.Len())
+			for i := range list {
+				list[i] = v.Index(i).String()
+			}
+			return strings.Join(list, ",")
+		}
+		fallthrough
+	case reflect.Struct, reflect.Map:
+		data, _ := json.Marshal(v.Interface())
+		return string(data)
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+func indirect(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+// sortTogether 按 names 排序 keys
+func sortTogether(names []string, keys []reflect.Value) {
+	for i := 1; i < len(names); i++ {
+		for j := i; j > 0 && names[j] < names[j-1]; j-- {
+			names[j], names[j-1] = names[j-1], names[j]
+			keys[j], keys[j-1] = keys[j-1], keys[j]
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/rongcloud/server-sdk-go/v3/sdk"
+)
+
+var pushCommands = []command{
+	{"send", "<file>", "发送推送，file 为 json 格式的 sdk.Push，- 表示从标准输入读取", func(c *call) (interface{}, error) {
+		broadcast := c.Bool("broadcast", false, "file 为 sdk.Broadcast，发送广播消息")
+		if err := c.parse(1); err != nil {
+			return nil, err
+		}
+		var data []byte
+		var err error
+		if c.Arg(0) == "-" {
+			data, err = ioutil.ReadAll(os.Stdin)
+		} else {
+			data, err = ioutil.ReadFile(c.Arg(0))
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var sender sdk.Sender
+		if *broadcast {
+			var b sdk.Broadcast
+			err = json.Unmarshal(data, &b)
+			sender = b
+		} else {
+			var p sdk.Push
+			err = json.Unmarshal(data, &p)
+			sender = p
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid push file: %w", err)
+		}
+		return c.rc.PushSendContext(c.ctx, sender)
+	}},
+}
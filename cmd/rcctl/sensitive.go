@@ -0,0 +1,27 @@
+package main
+
+var sensitiveCommands = []command{
+	{"add", "<word> [replaceWord]", "添加敏感词，不指定替换词时屏蔽含有该词的消息", func(c *call) (interface{}, error) {
+		if err := c.parse(1); err != nil {
+			return nil, err
+		}
+		// 屏蔽敏感词时不会发送 replace，但 SensitiveAdd 要求 replace 不为空
+		word, replace, sensitiveType := c.Arg(0), c.Arg(0), 1
+		if c.NArg() > 1 {
+			replace, sensitiveType = c.Arg(1), 0
+		}
+		return nil, c.rc.SensitiveAddContext(c.ctx, word, replace, sensitiveType)
+	}},
+	{"remove", "<word>...", "删除敏感词", func(c *call) (interface{}, error) {
+		if err := c.parse(1); err != nil {
+			return nil, err
+		}
+		return nil, c.rc.SensitiveRemoveContext(c.ctx, c.list(0))
+	}},
+	{"list", "", "查询敏感词", func(c *call) (interface{}, error) {
+		if err := c.parse(0); err != nil {
+			return nil, err
+		}
+		return c.rc.SensitiveGetListContext(c.ctx)
+	}},
+}
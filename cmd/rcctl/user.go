@@ -0,0 +1,104 @@
+package main
+
+import (
+	"github.com/rongcloud/server-sdk-go/v3/sdk"
+)
+
+var userCommands = []command{
+	{"register", "<userId> <name>", "注册用户并获取 token", func(c *call) (interface{}, error) {
+		portrait := c.String("portrait", "", "头像地址")
+		if err := c.parse(2); err != nil {
+			return nil, err
+		}
+		return c.rc.UserRegisterContext(c.ctx, c.Arg(0), c.Arg(1), *portrait)
+	}},
+	{"update", "<userId>", "修改用户名称、头像", func(c *call) (interface{}, error) {
+		// UserUpdate 总会发送 name 参数，未传入 -name 时会清空用户名称，因此必须传入
+		name := c.String("name", "", "用户名称（必填）")
+		portrait := c.String("portrait", "", "头像地址")
+		if err := c.parse(1); err != nil {
+			return nil, err
+		}
+		if err := c.require("name"); err != nil {
+			return nil, err
+		}
+		return nil, c.rc.UserUpdateContext(c.ctx, c.Arg(0), *name, *portrait)
+	}},
+	{"online", "<userId>", "查询用户在线状态", func(c *call) (interface{}, error) {
+		if err := c.parse(1); err != nil {
+			return nil, err
+		}
+		status, err := c.rc.OnlineStatusCheckContext(c.ctx, c.Arg(0))
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"userId": c.Arg(0), "online": status == 1}, nil
+	}},
+	{"block", "<userId>", "封禁用户", func(c *call) (interface{}, error) {
+		minute := c.Uint64("minute", 60, "封禁时长（分钟），最长 43200")
+		if err := c.parse(1); err != nil {
+			return nil, err
+		}
+		return nil, c.rc.BlockAddContext(c.ctx, c.Arg(0), *minute)
+	}},
+	{"unblock", "<userId>", "解除封禁", func(c *call) (interface{}, error) {
+		if err := c.parse(1); err != nil {
+			return nil, err
+		}
+		return nil, c.rc.BlockRemoveContext(c.ctx, c.Arg(0))
+	}},
+	{"block-list", "", "查询被封禁的用户", func(c *call) (interface{}, error) {
+		if err := c.parse(0); err != nil {
+			return nil, err
+		}
+		return c.rc.BlockGetListContext(c.ctx)
+	}},
+	{"blacklist-add", "<userId> <blackUserId>...", "添加用户到黑名单", func(c *call) (interface{}, error) {
+		if err := c.parse(2); err != nil {
+			return nil, err
+		}
+		return nil, c.rc.BlacklistAddContext(c.ctx, c.Arg(0), c.list(1))
+	}},
+	{"blacklist-remove", "<userId> <blackUserId>...", "从黑名单中移除用户", func(c *call) (interface{}, error) {
+		if err := c.parse(2); err != nil {
+			return nil, err
+		}
+		return nil, c.rc.BlacklistRemoveContext(c.ctx, c.Arg(0), c.list(1))
+	}},
+	{"blacklist", "<userId>", "查询用户的黑名单", func(c *call) (interface{}, error) {
+		if err := c.parse(1); err != nil {
+			return nil, err
+		}
+		return c.rc.BlacklistGetContext(c.ctx, c.Arg(0))
+	}},
+	{"whitelist-add", "<userId> <whiteUserId>...", "添加用户到白名单", func(c *call) (interface{}, error) {
+		if err := c.parse(2); err != nil {
+			return nil, err
+		}
+		return nil, c.rc.AddWhiteListContext(c.ctx, c.Arg(0), c.list(1))
+	}},
+	{"whitelist-remove", "<userId> <whiteUserId>...", "从白名单中移除用户", func(c *call) (interface{}, error) {
+		if err := c.parse(2); err != nil {
+			return nil, err
+		}
+		return nil, c.rc.RemoveWhiteListContext(c.ctx, c.Arg(0), c.list(1))
+	}},
+	{"whitelist", "<userId>", "查询用户的白名单", func(c *call) (interface{}, error) {
+		if err := c.parse(1); err != nil {
+			return nil, err
+		}
+		return c.rc.QueryWhiteListContext(c.ctx, c.Arg(0))
+	}},
+	{"tag-set", "<userId> <tag>...", "设置用户标签", func(c *call) (interface{}, error) {
+		if err := c.parse(2); err != nil {
+			return nil, err
+		}
+		return nil, c.rc.TagSetContext(c.ctx, sdk.Tag{UserID: c.Arg(0), Tags: c.list(1)})
+	}},
+	{"tags", "<userId>...", "查询用户标签", func(c *call) (interface{}, error) {
+		if err := c.parse(1); err != nil {
+			return nil, err
+		}
+		return c.rc.TagGetContext(c.ctx, c.list(0))
+	}},
+}
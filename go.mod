@@ -1,5 +1,5 @@
 module github.com/rongcloud/server-sdk-go/v3
 
-require github.com/astaxie/beego v1.12.0
-
 go 1.13
+
+require gopkg.in/yaml.v2 v2.2.1
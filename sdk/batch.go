@@ -0,0 +1,225 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+const (
+	// PRIVATE_SEND_BATCH_SIZE PrivateSend 每次最多发送的用户数
+	PRIVATE_SEND_BATCH_SIZE = 1000
+	// SYSTEM_SEND_BATCH_SIZE SystemSend 每次最多发送的用户数
+	SYSTEM_SEND_BATCH_SIZE = 100
+	// GROUP_SEND_BATCH_SIZE GroupSend 每次最多发送的群组数
+	GROUP_SEND_BATCH_SIZE = 3
+	// TAG_GET_BATCH_SIZE TagGet 每次最多查询的用户数
+	TAG_GET_BATCH_SIZE = 50
+	// CHATROOM_ENTRY_QUERY_BATCH_SIZE ChatRoomEntryQuery 每次最多查询的 key 数
+	CHATROOM_ENTRY_QUERY_BATCH_SIZE = 100
+	// DEFAULT_BATCH_PARALLELISM 分批调用时默认的并发请求数
+	DEFAULT_BATCH_PARALLELISM = 4
+)
+
+// BatchChunk 分批调用中一批的执行结果
+type BatchChunk struct {
+	Index int      // 批次序号，从 0 开始
+	IDs   []string // 本批次包含的用户、群组或 key
+	Err   error    // 本批次的错误，为 nil 时表示成功
+}
+
+// BatchResult 分批调用的执行结果，各批次按序号排列
+type BatchResult struct {
+	Chunks []BatchChunk
+}
+
+// Succeeded 调用成功的用户、群组或 key
+func (r BatchResult) Succeeded() []string {
+	var ids []string
+	for _, c := range r.Chunks {
+		if c.Err == nil {
+			ids = append(ids, c.IDs...)
+		}
+	}
+	return ids
+}
+
+// Failed 调用失败的用户、群组或 key
+func (r BatchResult) Failed() []string {
+	var ids []string
+	for _, c := range r.Chunks {
+		if c.Err != nil {
+			ids = append(ids, c.IDs...)
+		}
+	}
+	return ids
+}
+
+// Err 全部成功时返回 nil，否则返回包含第一个失败批次错误的 error，可通过 errors.Is、errors.As 判断错误类型
+func (r BatchResult) Err() error {
+	failed := 0
+	var first error
+	for _, c := range r.Chunks {
+		if c.Err != nil {
+			if first == nil {
+				first = c.Err
+			}
+			failed++
+		}
+	}
+	if first == nil {
+		return nil
+	}
+	return fmt.Errorf("rongcloud: %d of %d batches failed: %w", failed, len(r.Chunks), first)
+}
+
+// chunk 将 ids 按 size 拆分，ids 为空时返回一个空批次，由接口本身校验参数
+func chunk(ids []string, size int) [][]string {
+	if len(ids) == 0 {
+		return [][]string{nil}
+	}
+	var chunks [][]string
+	for len(ids) > size {
+		chunks = append(chunks, ids[:size:size])
+		ids = ids[size:]
+	}
+	return append(chunks, ids)
+}
+
+// batch 将 ids 按 size 拆分后并发调用 fn，并发数由 WithBatchParallelism 设置
+// 每批次的请求仍经过限流，超出限额时等待或失败；ctx 结束后未开始的批次返回 ctx.Err()
+func (rc *RongCloud) batch(ctx context.Context, ids []string, size int, fn func(ctx context.Context, index int, ids []string) error) BatchResult {
+	chunks := chunk(ids, size)
+	result := BatchResult{Chunks: make([]BatchChunk, len(chunks))}
+	parallelism := rc.batchParallelism
+	if parallelism <= 0 {
+		parallelism = 1
+	}
+
+	sem := make(chan struct{}, parallelism)
+	var wg sync.WaitGroup
+	for i, ids := range chunks {
+		result.Chunks[i] = BatchChunk{Index: i, IDs: ids}
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			result.Chunks[i].Err = ctx.Err()
+			continue
+		}
+		wg.Add(1)
+		go func(c *BatchChunk) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			c.Err = fn(ctx, c.Index, c.IDs)
+		}(&result.Chunks[i])
+	}
+	wg.Wait()
+	return result
+}
+
+// PrivateSendBatch 发送单聊消息，targetID 超过 1000 个时自动分批并发发送，参数同 PrivateSend
+func (rc *RongCloud) PrivateSendBatch(senderID string, targetID []string, objectName string, msg rcMsg,
+	pushContent, pushData string, count, verifyBlacklist, isPersisted, isIncludeSender, contentAvailable int,
+	options ...MsgOption) BatchResult {
+	return rc.PrivateSendBatchContext(context.Background(), senderID, targetID, objectName, msg,
+		pushContent, pushData, count, verifyBlacklist, isPersisted, isIncludeSender,
+		contentAvailable, options...)
+}
+
+// PrivateSendBatchContext 同 PrivateSendBatch，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) PrivateSendBatchContext(ctx context.Context, senderID string, targetID []string, objectName string, msg rcMsg,
+	pushContent, pushData string, count, verifyBlacklist, isPersisted, isIncludeSender, contentAvailable int,
+	options ...MsgOption) BatchResult {
+	return rc.batch(ctx, targetID, PRIVATE_SEND_BATCH_SIZE, func(ctx context.Context, _ int, ids []string) error {
+		return rc.PrivateSendContext(ctx, senderID, ids, objectName, msg,
+			pushContent, pushData, count, verifyBlacklist, isPersisted, isIncludeSender,
+			contentAvailable, options...)
+	})
+}
+
+// SystemSendBatch 发送系统消息，targetID 超过 100 个时自动分批并发发送，参数同 SystemSend
+func (rc *RongCloud) SystemSendBatch(senderID string, targetID []string, objectName string, msg rcMsg,
+	pushContent, pushData string, count, isPersisted int,
+	options ...MsgOption) BatchResult {
+	return rc.SystemSendBatchContext(context.Background(), senderID, targetID, objectName, msg,
+		pushContent, pushData, count, isPersisted, options...)
+}
+
+// SystemSendBatchContext 同 SystemSendBatch，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) SystemSendBatchContext(ctx context.Context, senderID string, targetID []string, objectName string, msg rcMsg,
+	pushContent, pushData string, count, isPersisted int,
+	options ...MsgOption) BatchResult {
+	return rc.batch(ctx, targetID, SYSTEM_SEND_BATCH_SIZE, func(ctx context.Context, _ int, ids []string) error {
+		return rc.SystemSendContext(ctx, senderID, ids, objectName, msg,
+			pushContent, pushData, count, isPersisted, options...)
+	})
+}
+
+// GroupSendBatch 发送群组消息，targetID 超过 3 个时自动分批并发发送，userID 为定向用户，每批次相同，参数同 GroupSend
+func (rc *RongCloud) GroupSendBatch(senderID string, targetID, userID []string, objectName string, msg rcMsg,
+	pushContent string, pushData string, isPersisted, isIncludeSender int,
+	options ...MsgOption) BatchResult {
+	return rc.GroupSendBatchContext(context.Background(), senderID, targetID, userID, objectName, msg,
+		pushContent, pushData, isPersisted, isIncludeSender, options...)
+}
+
+// GroupSendBatchContext 同 GroupSendBatch，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) GroupSendBatchContext(ctx context.Context, senderID string, targetID, userID []string, objectName string, msg rcMsg,
+	pushContent string, pushData string, isPersisted, isIncludeSender int,
+	options ...MsgOption) BatchResult {
+	return rc.batch(ctx, targetID, GROUP_SEND_BATCH_SIZE, func(ctx context.Context, _ int, ids []string) error {
+		return rc.GroupSendContext(ctx, senderID, ids, userID, objectName, msg,
+			pushContent, pushData, isPersisted, isIncludeSender, options...)
+	})
+}
+
+// TagGetBatch 查询用户标签，userIds 超过 50 个时自动分批并发查询，返回成功批次合并后的标签
+func (rc *RongCloud) TagGetBatch(userIds []string) (TagResult, BatchResult) {
+	return rc.TagGetBatchContext(context.Background(), userIds)
+}
+
+// TagGetBatchContext 同 TagGetBatch，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) TagGetBatchContext(ctx context.Context, userIds []string) (TagResult, BatchResult) {
+	var lock sync.Mutex
+	tags := TagResult{CodeResult: &CodeResult{Code: 200}, Result: map[string][]string{}}
+	result := rc.batch(ctx, userIds, TAG_GET_BATCH_SIZE, func(ctx context.Context, _ int, ids []string) error {
+		tag, err := rc.TagGetContext(ctx, ids)
+		if err != nil {
+			return err
+		}
+		lock.Lock()
+		defer lock.Unlock()
+		for k, v := range tag.Result {
+			tags.Result[k] = v
+		}
+		return nil
+	})
+	return tags, result
+}
+
+// ChatRoomEntryQueryBatch 获取聊天室属性，keys 超过 100 个时自动分批并发查询，返回成功批次的属性，按批次顺序排列
+// keys 为空时获取全部属性
+func (rc *RongCloud) ChatRoomEntryQueryBatch(chatRoomID string, keys []string) ([]ChatRoomAttr, BatchResult) {
+	return rc.ChatRoomEntryQueryBatchContext(context.Background(), chatRoomID, keys)
+}
+
+// ChatRoomEntryQueryBatchContext 同 ChatRoomEntryQueryBatch，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) ChatRoomEntryQueryBatchContext(ctx context.Context, chatRoomID string, keys []string) ([]ChatRoomAttr, BatchResult) {
+	var lock sync.Mutex
+	chunks := map[int][]ChatRoomAttr{}
+	result := rc.batch(ctx, keys, CHATROOM_ENTRY_QUERY_BATCH_SIZE, func(ctx context.Context, index int, ids []string) error {
+		attrs, err := rc.ChatRoomEntryQueryContext(ctx, chatRoomID, strings.Join(ids, ","))
+		lock.Lock()
+		chunks[index] = attrs
+		lock.Unlock()
+		return err
+	})
+	var attrs []ChatRoomAttr
+	for i := range result.Chunks {
+		attrs = append(attrs, chunks[i]...)
+	}
+	return attrs, result
+}
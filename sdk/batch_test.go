@@ -0,0 +1,134 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/rongcloud/server-sdk-go/v3/sdk/sdktest"
+)
+
+// ids 生成 n 个 ID
+func ids(prefix string, n int) []string {
+	list := make([]string, n)
+	for i := range list {
+		list[i] = fmt.Sprintf("%s%04d", prefix, i)
+	}
+	return list
+}
+
+func TestChunk(t *testing.T) {
+	for _, tc := range []struct {
+		n, size int
+		want    []int
+	}{
+		{0, 3, []int{0}},
+		{3, 3, []int{3}},
+		{7, 3, []int{3, 3, 1}},
+	} {
+		chunks := chunk(ids("u", tc.n), tc.size)
+		var got []int
+		for _, c := range chunks {
+			got = append(got, len(c))
+		}
+		if fmt.Sprint(got) != fmt.Sprint(tc.want) {
+			t.Errorf("chunk(%d, %d) = %v, want %v", tc.n, tc.size, got, tc.want)
+		}
+	}
+}
+
+func TestRongCloud_SendBatch(t *testing.T) {
+	server := sdktest.NewServer("appKey", "appSecret")
+	defer server.Close()
+	rc := New(server.AppKey, server.AppSecret, WithRongCloudURI(server.URL))
+	msg := &TXTMsg{Content: "hello"}
+
+	result := rc.PrivateSendBatch("admin", ids("u", 2500), "RC:TxtMsg", msg, "", "", 0, 0, 1, 0, 0)
+	if err := result.Err(); err != nil || len(result.Chunks) != 3 || len(result.Succeeded()) != 2500 {
+		t.Errorf("PrivateSendBatch: %d chunks, %v", len(result.Chunks), err)
+	}
+	result = rc.SystemSendBatch("admin", ids("u", 250), "RC:TxtMsg", msg, "", "", 0, 1)
+	if err := result.Err(); err != nil || len(server.Messages("/message/system/publish")) != 3 {
+		t.Errorf("SystemSendBatch: %v", err)
+	}
+	result = rc.GroupSendBatch("admin", ids("g", 7), nil, "RC:TxtMsg", msg, "", "", 1, 0)
+	if err := result.Err(); err != nil || len(server.Messages("/message/group/publish")) != 3 {
+		t.Errorf("GroupSendBatch: %v", err)
+	}
+
+	// 参数错误由接口本身返回
+	result = rc.PrivateSendBatch("admin", nil, "RC:TxtMsg", msg, "", "", 0, 0, 1, 0, 0)
+	if err := result.Err(); !errors.Is(err, ErrValidation) {
+		t.Errorf("empty targetID: %v", err)
+	}
+}
+
+func TestRongCloud_SendBatchPartialFailure(t *testing.T) {
+	server := sdktest.NewServer("appKey", "appSecret")
+	defer server.Close()
+	rc := New(server.AppKey, server.AppSecret, WithRongCloudURI(server.URL), WithBatchParallelism(1))
+
+	server.FailNext("/message/system/publish", http.StatusInternalServerError, 1000)
+	targets := ids("u", 250)
+	result := rc.SystemSendBatch("admin", targets, "RC:TxtMsg", &TXTMsg{Content: "hello"}, "", "", 0, 1)
+
+	if result.Chunks[0].Err == nil || result.Chunks[1].Err != nil || result.Chunks[2].Err != nil {
+		t.Fatalf("chunks = %+v", result.Chunks)
+	}
+	failed := result.Failed()
+	if len(failed) != 100 || failed[0] != targets[0] || len(result.Succeeded()) != 150 {
+		t.Errorf("failed = %d, succeeded = %d", len(failed), len(result.Succeeded()))
+	}
+	var rcErr *Error
+	if err := result.Err(); !errors.As(err, &rcErr) || rcErr.Code != 1000 {
+		t.Errorf("Err = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	result = rc.SystemSendBatchContext(ctx, "admin", targets, "RC:TxtMsg", &TXTMsg{Content: "hello"}, "", "", 0, 1)
+	if len(result.Succeeded()) != 0 || !errors.Is(result.Err(), context.Canceled) {
+		t.Errorf("canceled: %v", result.Err())
+	}
+}
+
+func TestRongCloud_QueryBatch(t *testing.T) {
+	server := sdktest.NewServer("appKey", "appSecret")
+	defer server.Close()
+	rc := New(server.AppKey, server.AppSecret, WithRongCloudURI(server.URL))
+
+	users := ids("u", 120)
+	for _, id := range users[:60] {
+		if err := rc.TagSet(Tag{UserID: id, Tags: []string{"vip"}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tags, result := rc.TagGetBatch(users)
+	if err := result.Err(); err != nil || len(result.Chunks) != 3 || len(tags.Result) != 120 ||
+		len(tags.Result["u0059"]) != 1 || len(tags.Result["u0060"]) != 0 {
+		t.Errorf("TagGetBatch: %d tags, %v", len(tags.Result), err)
+	}
+
+	if err := rc.ChatRoomCreate("c01", "c01"); err != nil {
+		t.Fatal(err)
+	}
+	server.JoinChatRoom("c01", "u01")
+	// 聊天室最多 100 个属性，查询的 key 可以不存在
+	keys := ids("k", 150)
+	for _, key := range keys[:100] {
+		if err := rc.ChatRoomEntrySet("c01", "u01", key, "v", false); err != nil {
+			t.Fatal(err)
+		}
+	}
+	attrs, result := rc.ChatRoomEntryQueryBatch("c01", keys)
+	if err := result.Err(); err != nil || len(result.Chunks) != 2 || len(attrs) != 100 ||
+		attrs[0].Key != keys[0] || attrs[99].Key != keys[99] {
+		t.Errorf("ChatRoomEntryQueryBatch: %d attrs, %v", len(attrs), err)
+	}
+	if _, err := rc.ChatRoomEntryQuery("c01", strings.Join(keys, ",")); err == nil {
+		t.Error("expected error for more than 100 keys")
+	}
+}
@@ -0,0 +1,220 @@
+// Package callback 融云服务端回调（全量消息路由、用户在线状态、聊天室状态等）的接收与签名校验
+package callback
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rongcloud/server-sdk-go/v3/sdk"
+)
+
+// DEFAULT_TIMESTAMP_WINDOW 回调时间戳与本地时间允许的默认偏差，5 分钟
+const DEFAULT_TIMESTAMP_WINDOW = 5 * time.Minute
+
+// DEFAULT_MAX_BODY_SIZE 回调 body 默认最大长度，4MB
+const DEFAULT_MAX_BODY_SIZE = 4 << 20
+
+var (
+	// ErrSignature 签名校验失败
+	ErrSignature = errors.New("callback: invalid signature")
+	// ErrTimestamp 时间戳缺失或超出允许的时间窗口，可能为重放请求
+	ErrTimestamp = errors.New("callback: timestamp out of window")
+	// ErrAppKey appKey 与本应用不一致
+	ErrAppKey = errors.New("callback: appKey mismatch")
+)
+
+// Verifier 回调签名校验，*sdk.RongCloud 已实现该接口
+type Verifier interface {
+	VerifySignature(nonce, timestamp, signature string) bool
+}
+
+// Payload 校验通过后解码的回调内容
+type Payload struct {
+	AppKey    string
+	Nonce     string
+	Timestamp time.Time
+	Query     url.Values      // URL 参数，包括签名参数
+	Form      url.Values      // application/x-www-form-urlencoded 编码的回调内容，如消息路由、在线状态
+	JSON      json.RawMessage // application/json 编码的回调内容，如聊天室状态
+	Request   *http.Request
+}
+
+// HandlerFunc 处理校验通过的回调，返回 error 时响应 500（ErrBusy 响应 503），融云会按回调规则重试
+type HandlerFunc func(ctx context.Context, payload *Payload) error
+
+// Handler 校验融云回调签名及时间戳后，将解码的内容交给 HandlerFunc 处理，实现 http.Handler
+type Handler struct {
+	verifier    Verifier
+	handle      HandlerFunc
+	appKey      string
+	window      time.Duration
+	maxBodySize int64
+	// deliveryTimeout NewMessageChannel 等待 channel 可写的最长时间
+	deliveryTimeout time.Duration
+	// msgDecoder NewMessageHandler、NewMessageChannel 解码消息内容的方式
+	msgDecoder sdk.MsgDecoder
+	now        func() time.Time
+	errorLog   func(r *http.Request, err error)
+}
+
+// Option Handler 可选配置
+type Option func(*Handler)
+
+// WithAppKey 校验回调中的 appKey 与给定的 appKey 一致，回调未携带 appKey 时不校验
+func WithAppKey(appKey string) Option {
+	return func(h *Handler) {
+		h.appKey = appKey
+	}
+}
+
+// WithTimestampWindow 设置回调时间戳与本地时间允许的最大偏差，超出时视为重放请求并拒绝。默认 5 分钟，<=0 时不校验
+func WithTimestampWindow(window time.Duration) Option {
+	return func(h *Handler) {
+		h.window = window
+	}
+}
+
+// WithMaxBodySize 设置回调 body 最大长度，默认 4MB
+func WithMaxBodySize(size int64) Option {
+	return func(h *Handler) {
+		h.maxBodySize = size
+	}
+}
+
+// WithErrorLog 设置校验失败、解码失败或 HandlerFunc 返回错误时的回调，可用于记录日志
+func WithErrorLog(fn func(r *http.Request, err error)) Option {
+	return func(h *Handler) {
+		h.errorLog = fn
+	}
+}
+
+// NewHandler 创建回调 Handler
+/*
+*@param  verifier:签名校验，一般传入 *sdk.RongCloud。
+*@param  handle:校验通过后的处理函数。
+*
+*@return *Handler
+ */
+func NewHandler(verifier Verifier, handle HandlerFunc, options ...Option) *Handler {
+	h := &Handler{
+		verifier:    verifier,
+		handle:      handle,
+		window:      DEFAULT_TIMESTAMP_WINDOW,
+		maxBodySize: DEFAULT_MAX_BODY_SIZE,
+		now:         time.Now,
+	}
+	for _, option := range options {
+		option(h)
+	}
+	return h
+}
+
+// ServeHTTP 实现 http.Handler
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	payload, err := h.Decode(r)
+	if err != nil {
+		h.logError(r, err)
+		status := http.StatusBadRequest
+		if errors.Is(err, ErrSignature) || errors.Is(err, ErrTimestamp) || errors.Is(err, ErrAppKey) {
+			status = http.StatusUnauthorized
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+	if err := h.handle(r.Context(), payload); err != nil {
+		h.logError(r, err)
+		http.Error(w, err.Error(), statusOf(err))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// Decode 校验签名、时间戳并解码回调内容，可用于在其他 web 框架中接入
+func (h *Handler) Decode(r *http.Request) (*Payload, error) {
+	query := r.URL.Query()
+	payload := &Payload{
+		AppKey:  query.Get("appKey"),
+		Nonce:   query.Get("nonce"),
+		Query:   query,
+		Request: r,
+	}
+	// 消息路由等回调使用 signTimestamp，部分回调使用 timestamp
+	timestamp := query.Get("signTimestamp")
+	if timestamp == "" {
+		timestamp = query.Get("timestamp")
+	}
+	signature := query.Get("signature")
+
+	if h.appKey != "" && payload.AppKey != "" && payload.AppKey != h.appKey {
+		return nil, ErrAppKey
+	}
+	if payload.Nonce == "" || timestamp == "" || signature == "" {
+		return nil, ErrSignature
+	}
+	t, err := parseTimestamp(timestamp)
+	if err != nil {
+		return nil, ErrTimestamp
+	}
+	payload.Timestamp = t
+	if h.window > 0 {
+		skew := h.now().Sub(t)
+		if skew < 0 {
+			skew = -skew
+		}
+		if skew > h.window {
+			return nil, ErrTimestamp
+		}
+	}
+	if !h.verifier.VerifySignature(payload.Nonce, timestamp, signature) {
+		return nil, ErrSignature
+	}
+
+	if r.Body == nil {
+		return payload, nil
+	}
+	body, err := ioutil.ReadAll(http.MaxBytesReader(nil, r.Body, h.maxBodySize))
+	if err != nil {
+		return nil, err
+	}
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch {
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		if len(body) > 0 && !json.Valid(body) {
+			return nil, errors.New("callback: invalid json body")
+		}
+		payload.JSON = body
+	default:
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			return nil, err
+		}
+		payload.Form = form
+	}
+	return payload, nil
+}
+
+func (h *Handler) logError(r *http.Request, err error) {
+	if h.errorLog != nil {
+		h.errorLog(r, err)
+	}
+}
+
+// parseTimestamp 解析回调时间戳，兼容秒及毫秒
+func parseTimestamp(timestamp string) (time.Time, error) {
+	n, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if len(timestamp) >= 13 {
+		return time.Unix(0, n*int64(time.Millisecond)), nil
+	}
+	return time.Unix(n, 0), nil
+}
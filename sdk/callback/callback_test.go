@@ -0,0 +1,135 @@
+package callback
+
+import (
+	"context"
+	"crypto/sha1"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rongcloud/server-sdk-go/v3/sdk"
+)
+
+const (
+	testAppKey    = "appKey"
+	testAppSecret = "appSecret"
+)
+
+func signedURL(appSecret, nonce, timestamp string) string {
+	signature := fmt.Sprintf("%x", sha1.Sum([]byte(appSecret+nonce+timestamp)))
+	query := url.Values{}
+	query.Set("appKey", testAppKey)
+	query.Set("nonce", nonce)
+	query.Set("signTimestamp", timestamp)
+	query.Set("signature", signature)
+	return "/callback?" + query.Encode()
+}
+
+func nowMillis() string {
+	return strconv.FormatInt(time.Now().UnixNano()/int64(time.Millisecond), 10)
+}
+
+func TestHandler_Form(t *testing.T) {
+	var got *Payload
+	h := NewHandler(sdk.New(testAppKey, testAppSecret), func(ctx context.Context, payload *Payload) error {
+		got = payload
+		return nil
+	}, WithAppKey(testAppKey))
+
+	body := strings.NewReader("fromUserId=u1&toUserId=u2&objectName=RC%3ATxtMsg")
+	r := httptest.NewRequest(http.MethodPost, signedURL(testAppSecret, "123", nowMillis()), body)
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", w.Code, w.Body.String())
+	}
+	if got == nil || got.Form.Get("fromUserId") != "u1" || got.Form.Get("objectName") != "RC:TxtMsg" {
+		t.Fatalf("payload = %+v", got)
+	}
+}
+
+func TestHandler_JSON(t *testing.T) {
+	var got *Payload
+	h := NewHandler(sdk.New(testAppKey, testAppSecret), func(ctx context.Context, payload *Payload) error {
+		got = payload
+		return nil
+	})
+
+	body := strings.NewReader(`[{"chatRoomId":"c1","type":0}]`)
+	r := httptest.NewRequest(http.MethodPost, signedURL(testAppSecret, "123", nowMillis()), body)
+	r.Header.Set("Content-Type", "application/json; charset=utf-8")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", w.Code, w.Body.String())
+	}
+	if got == nil || string(got.JSON) != `[{"chatRoomId":"c1","type":0}]` {
+		t.Fatalf("payload = %+v", got)
+	}
+}
+
+func TestHandler_Reject(t *testing.T) {
+	old := strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), 10)
+	tests := []struct {
+		name string
+		url  string
+	}{
+		{"bad signature", signedURL("otherSecret", "123", nowMillis())},
+		{"missing signature", "/callback?nonce=123&timestamp=" + nowMillis()},
+		{"replay", signedURL(testAppSecret, "123", old)},
+		{"bad timestamp", signedURL(testAppSecret, "123", "abc")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			h := NewHandler(sdk.New(testAppKey, testAppSecret), func(ctx context.Context, payload *Payload) error {
+				called = true
+				return nil
+			})
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, tt.url, nil))
+			if w.Code != http.StatusUnauthorized {
+				t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+			}
+			if called {
+				t.Error("handler called for rejected request")
+			}
+		})
+	}
+}
+
+func TestHandler_AppKeyMismatch(t *testing.T) {
+	h := NewHandler(sdk.New(testAppKey, testAppSecret), func(ctx context.Context, payload *Payload) error {
+		return nil
+	}, WithAppKey("otherAppKey"))
+	r := httptest.NewRequest(http.MethodPost, signedURL(testAppSecret, "123", nowMillis()), nil)
+	_, err := h.Decode(r)
+	if err != ErrAppKey {
+		t.Fatalf("err = %v, want %v", err, ErrAppKey)
+	}
+}
+
+func TestHandler_HandlerError(t *testing.T) {
+	var logged error
+	h := NewHandler(sdk.New(testAppKey, testAppSecret), func(ctx context.Context, payload *Payload) error {
+		return fmt.Errorf("db down")
+	}, WithErrorLog(func(r *http.Request, err error) {
+		logged = err
+	}))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, signedURL(testAppSecret, "123", nowMillis()), nil))
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusInternalServerError)
+	}
+	if logged == nil {
+		t.Error("error not logged")
+	}
+}
@@ -0,0 +1,136 @@
+package callback
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/rongcloud/server-sdk-go/v3/sdk"
+)
+
+var (
+	// ErrBusy 消息接收方繁忙（如 channel 已满且等待超时），响应 503，融云会重新推送
+	ErrBusy = errors.New("callback: receiver busy")
+	// ErrPayload 回调内容缺少必要字段或格式错误，响应 400
+	ErrPayload = errors.New("callback: invalid payload")
+)
+
+// RoutedMessage 全量消息路由推送的消息
+type RoutedMessage struct {
+	FromUserID   string
+	ToUserID     string
+	ObjectName   string
+	Content      json.RawMessage // 原始消息内容
+	ChannelType  string          // 会话类型：PERSON 单聊、PERSONS 讨论组、GROUP 群聊、TEMPGROUP 聊天室、CUSTOMERSERVICE 客服、NOTIFY 系统通知、MC 公众服务、MP 公众服务
+	MsgTimestamp time.Time
+	MsgUID       string
+	// SensitiveType 消息中敏感词标识，0 不含敏感词，1 含屏蔽敏感词，2 含替换敏感词
+	SensitiveType int
+	Source        string   // 消息来源，如 iOS、Android、Websocket、MiniProgram、PC、Server
+	GroupUserIDs  []string // 群定向消息的接收成员
+	// Message 按 objectName 解码后的消息，如 *sdk.TXTMsg、*sdk.ImgMsg；未知的消息类型或解码失败时为 json.RawMessage
+	Message interface{}
+	// DecodeErr 已知消息类型解码失败的原因，此时 Message 为原始 json
+	DecodeErr error
+}
+
+// DecodeMessage 从回调内容中解码全量消息路由推送的消息
+func DecodeMessage(payload *Payload) (*RoutedMessage, error) {
+	return DecodeMessageWith(payload, sdk.MsgDecoder{})
+}
+
+// DecodeMessageWith 同 DecodeMessage，使用 decoder 解码消息内容，如 sdk.MsgDecoder{Strict: true}
+func DecodeMessageWith(payload *Payload, decoder sdk.MsgDecoder) (*RoutedMessage, error) {
+	form := payload.Form
+	if form.Get("objectName") == "" {
+		return nil, fmt.Errorf("%w: 'objectName' is required", ErrPayload)
+	}
+	msg := &RoutedMessage{
+		FromUserID:   form.Get("fromUserId"),
+		ToUserID:     form.Get("toUserId"),
+		ObjectName:   form.Get("objectName"),
+		Content:      json.RawMessage(form.Get("content")),
+		ChannelType:  form.Get("channelType"),
+		MsgUID:       form.Get("msgUID"),
+		Source:       form.Get("source"),
+		GroupUserIDs: form["groupUserIds"],
+	}
+	if v := form.Get("msgTimestamp"); v != "" {
+		ms, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid msgTimestamp %q", ErrPayload, v)
+		}
+		msg.MsgTimestamp = time.Unix(0, ms*int64(time.Millisecond))
+	}
+	if v := form.Get("sensitiveType"); v != "" {
+		msg.SensitiveType, _ = strconv.Atoi(v)
+	}
+	msg.Message, msg.DecodeErr = decoder.DecodeContent(msg.ObjectName, msg.Content)
+	return msg, nil
+}
+
+// MessageHandlerFunc 处理全量消息路由推送的消息
+type MessageHandlerFunc func(ctx context.Context, msg *RoutedMessage) error
+
+// NewMessageHandler 创建全量消息路由回调 Handler，校验通过的消息交给 handle 处理
+func NewMessageHandler(verifier Verifier, handle MessageHandlerFunc, options ...Option) *Handler {
+	var h *Handler
+	h = NewHandler(verifier, func(ctx context.Context, payload *Payload) error {
+		msg, err := DecodeMessageWith(payload, h.msgDecoder)
+		if err != nil {
+			return err
+		}
+		return handle(ctx, msg)
+	}, options...)
+	return h
+}
+
+// NewMessageChannel 创建全量消息路由回调 Handler，校验通过的消息写入 ch
+// ch 已满时阻塞等待，直至请求结束或超过 WithDeliveryTimeout 设置的时间，此时响应 503，由融云重新推送
+func NewMessageChannel(verifier Verifier, ch chan<- *RoutedMessage, options ...Option) *Handler {
+	var h *Handler
+	h = NewMessageHandler(verifier, func(ctx context.Context, msg *RoutedMessage) error {
+		if h.deliveryTimeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, h.deliveryTimeout)
+			defer cancel()
+		}
+		select {
+		case ch <- msg:
+			return nil
+		case <-ctx.Done():
+			return ErrBusy
+		}
+	}, options...)
+	return h
+}
+
+// WithDeliveryTimeout 设置 NewMessageChannel 等待 channel 可写的最长时间，默认等待至请求结束
+func WithDeliveryTimeout(d time.Duration) Option {
+	return func(h *Handler) {
+		h.deliveryTimeout = d
+	}
+}
+
+// WithMsgDecoder 设置 NewMessageHandler、NewMessageChannel 解码消息内容的方式，
+// 如 sdk.MsgDecoder{Strict: true} 时内容包含未定义的字段记录到 RoutedMessage.DecodeErr
+func WithMsgDecoder(decoder sdk.MsgDecoder) Option {
+	return func(h *Handler) {
+		h.msgDecoder = decoder
+	}
+}
+
+// statusOf 处理函数返回错误时的 http 状态码
+func statusOf(err error) int {
+	switch {
+	case errors.Is(err, ErrBusy):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, ErrPayload):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
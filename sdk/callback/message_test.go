@@ -0,0 +1,147 @@
+package callback
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rongcloud/server-sdk-go/v3/sdk"
+)
+
+func routedRequest(form url.Values) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, signedURL(testAppSecret, "123", nowMillis()), strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return r
+}
+
+func routedForm(objectName, content string) url.Values {
+	form := url.Values{}
+	form.Set("fromUserId", "u1")
+	form.Set("toUserId", "g1")
+	form.Set("objectName", objectName)
+	form.Set("content", content)
+	form.Set("channelType", "GROUP")
+	form.Set("msgTimestamp", "1600000000123")
+	form.Set("msgUID", "BD3E-GNA4-0GB9-DL1F")
+	form.Set("sensitiveType", "0")
+	form.Set("source", "Android")
+	form.Add("groupUserIds", "u2")
+	form.Add("groupUserIds", "u3")
+	return form
+}
+
+func TestNewMessageHandler(t *testing.T) {
+	tests := []struct {
+		objectName string
+		content    string
+		check      func(t *testing.T, msg *RoutedMessage)
+	}{
+		{"RC:TxtMsg", `{"content":"hello","extra":"e"}`, func(t *testing.T, msg *RoutedMessage) {
+			txt, ok := msg.Message.(*sdk.TXTMsg)
+			if !ok || txt.Content != "hello" || txt.Extra != "e" {
+				t.Errorf("Message = %#v", msg.Message)
+			}
+		}},
+		{"RC:LBSMsg", `{"content":"base64","latitude":39.9,"longitude":116.3,"poi":"Beijing"}`, func(t *testing.T, msg *RoutedMessage) {
+			lbs, ok := msg.Message.(*sdk.LBSMsg)
+			if !ok || lbs.POI != "Beijing" || lbs.Latitude != 39.9 {
+				t.Errorf("Message = %#v", msg.Message)
+			}
+		}},
+		{"RC:chrmKVNotiMsg", `{"type":1,"key":"k","value":"v","extra":"x"}`, func(t *testing.T, msg *RoutedMessage) {
+			kv, ok := msg.Message.(*sdk.ChatRoomKVNotiMessage)
+			if !ok || kv.Key != "k" || kv.Value != "v" || kv.Extra != "x" {
+				t.Errorf("Message = %#v", msg.Message)
+			}
+		}},
+		{"App:Custom", `{"foo":"bar"}`, func(t *testing.T, msg *RoutedMessage) {
+			raw, ok := msg.Message.(json.RawMessage)
+			if !ok || string(raw) != `{"foo":"bar"}` || msg.DecodeErr != nil {
+				t.Errorf("Message = %#v, DecodeErr = %v", msg.Message, msg.DecodeErr)
+			}
+		}},
+		{"RC:TxtMsg", `not json`, func(t *testing.T, msg *RoutedMessage) {
+			if _, ok := msg.Message.(json.RawMessage); !ok || msg.DecodeErr == nil {
+				t.Errorf("Message = %#v, DecodeErr = %v", msg.Message, msg.DecodeErr)
+			}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.objectName, func(t *testing.T) {
+			var got *RoutedMessage
+			h := NewMessageHandler(sdk.New(testAppKey, testAppSecret), func(ctx context.Context, msg *RoutedMessage) error {
+				got = msg
+				return nil
+			})
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, routedRequest(routedForm(tt.objectName, tt.content)))
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %d, body = %s", w.Code, w.Body.String())
+			}
+			if got.FromUserID != "u1" || got.ToUserID != "g1" || got.ChannelType != "GROUP" ||
+				got.MsgUID != "BD3E-GNA4-0GB9-DL1F" || got.Source != "Android" || len(got.GroupUserIDs) != 2 {
+				t.Errorf("msg = %+v", got)
+			}
+			if !got.MsgTimestamp.Equal(time.Unix(1600000000, 123*int64(time.Millisecond))) {
+				t.Errorf("MsgTimestamp = %v", got.MsgTimestamp)
+			}
+			tt.check(t, got)
+		})
+	}
+}
+
+func TestNewMessageHandler_InvalidPayload(t *testing.T) {
+	h := NewMessageHandler(sdk.New(testAppKey, testAppSecret), func(ctx context.Context, msg *RoutedMessage) error {
+		return nil
+	})
+	form := routedForm("", "{}")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, routedRequest(form))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+}
+
+func TestNewMessageChannel(t *testing.T) {
+	ch := make(chan *RoutedMessage, 1)
+	h := NewMessageChannel(sdk.New(testAppKey, testAppSecret), ch, WithDeliveryTimeout(10*time.Millisecond))
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, routedRequest(routedForm("RC:TxtMsg", `{"content":"1"}`)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", w.Code, w.Body.String())
+	}
+
+	// channel 已满，等待超时后响应 503
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, routedRequest(routedForm("RC:TxtMsg", `{"content":"2"}`)))
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusServiceUnavailable)
+	}
+
+	msg := <-ch
+	if msg.Message.(*sdk.TXTMsg).Content != "1" {
+		t.Errorf("Message = %#v", msg.Message)
+	}
+}
+
+func TestNewMessageHandler_StrictDecoder(t *testing.T) {
+	var got *RoutedMessage
+	h := NewMessageHandler(sdk.New(testAppKey, testAppSecret), func(ctx context.Context, msg *RoutedMessage) error {
+		got = msg
+		return nil
+	}, WithMsgDecoder(sdk.MsgDecoder{Strict: true}))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, routedRequest(routedForm("RC:TxtMsg", `{"content":"hello","unknown":1}`)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", w.Code, w.Body.String())
+	}
+	if _, ok := got.Message.(json.RawMessage); !ok || got.DecodeErr == nil || !strings.Contains(got.DecodeErr.Error(), "unknown") {
+		t.Errorf("Message = %#v, DecodeErr = %v", got.Message, got.DecodeErr)
+	}
+}
@@ -0,0 +1,245 @@
+package callback
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rongcloud/server-sdk-go/v3/sdk"
+)
+
+const (
+	// STATUS_ONLINE 在线状态回调：上线
+	STATUS_ONLINE = 0
+	// STATUS_OFFLINE 在线状态回调：离线
+	STATUS_OFFLINE = 1
+	// STATUS_LOGOUT 在线状态回调：登出
+	STATUS_LOGOUT = 2
+)
+
+// DEFAULT_PRESENCE_TTL 在线状态缓存默认有效期，10 分钟
+const DEFAULT_PRESENCE_TTL = 10 * time.Minute
+
+// OnlineStatus 用户在线状态回调中的一条状态变化
+type OnlineStatus struct {
+	UserID   string
+	Status   int    // 0 上线，1 离线，2 登出
+	OS       string // 设备平台，如 iOS、Android、Websocket、PC、MiniProgram
+	Time     time.Time
+	ClientIP string
+}
+
+// Online 是否为上线状态
+func (s OnlineStatus) Online() bool {
+	return s.Status == STATUS_ONLINE
+}
+
+// flexInt 兼容数字及字符串形式的整数
+type flexInt int64
+
+func (n *flexInt) UnmarshalJSON(b []byte) error {
+	v := strings.Trim(string(b), `"`)
+	if v == "" || v == "null" {
+		return nil
+	}
+	i, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return err
+	}
+	*n = flexInt(i)
+	return nil
+}
+
+// DecodeOnlineStatus 从回调内容中解码用户在线状态，一次回调可能包含多个用户的状态
+func DecodeOnlineStatus(payload *Payload) ([]OnlineStatus, error) {
+	if len(payload.JSON) == 0 {
+		return nil, fmt.Errorf("%w: online status body is required", ErrPayload)
+	}
+	var raw []struct {
+		UserID   string  `json:"userid"`
+		Status   flexInt `json:"status"`
+		OS       string  `json:"os"`
+		Time     flexInt `json:"time"`
+		ClientIP string  `json:"clientIp"`
+	}
+	if err := json.Unmarshal(payload.JSON, &raw); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrPayload, err)
+	}
+	statuses := make([]OnlineStatus, 0, len(raw))
+	for _, r := range raw {
+		if r.UserID == "" {
+			continue
+		}
+		statuses = append(statuses, OnlineStatus{
+			UserID:   r.UserID,
+			Status:   int(r.Status),
+			OS:       r.OS,
+			Time:     time.Unix(0, int64(r.Time)*int64(time.Millisecond)),
+			ClientIP: r.ClientIP,
+		})
+	}
+	return statuses, nil
+}
+
+// OnlineStatusHandlerFunc 处理用户在线状态回调
+type OnlineStatusHandlerFunc func(ctx context.Context, statuses []OnlineStatus) error
+
+// NewOnlineStatusHandler 创建用户在线状态回调 Handler，可传入 Presence.HandleOnlineStatus 维护在线状态缓存
+func NewOnlineStatusHandler(verifier Verifier, handle OnlineStatusHandlerFunc, options ...Option) *Handler {
+	return NewHandler(verifier, func(ctx context.Context, payload *Payload) error {
+		statuses, err := DecodeOnlineStatus(payload)
+		if err != nil {
+			return err
+		}
+		return handle(ctx, statuses)
+	}, options...)
+}
+
+// PresenceChange 用户在线状态变化通知
+type PresenceChange struct {
+	UserID string
+	Online bool         // 变化后是否在线（任一设备在线即为在线）
+	Status OnlineStatus // 引起变化的状态回调
+}
+
+// presenceEntry 用户各设备平台的最新状态
+type presenceEntry struct {
+	devices map[string]OnlineStatus
+	expires time.Time
+}
+
+func (e *presenceEntry) online() bool {
+	for _, s := range e.devices {
+		if s.Online() {
+			return true
+		}
+	}
+	return false
+}
+
+// Presence 根据在线状态回调维护的用户在线状态缓存，实现 sdk.PresenceCache，可通过 sdk.WithPresenceCache 供 OnlineStatusCheck 使用
+// 用户在任一设备平台在线即视为在线，超过 TTL 未收到该用户的状态回调时缓存失效
+type Presence struct {
+	mu        sync.RWMutex
+	ttl       time.Duration
+	users     map[string]*presenceEntry
+	listeners []func(PresenceChange)
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+var _ sdk.PresenceCache = (*Presence)(nil)
+
+// NewPresence 创建在线状态缓存，ttl <= 0 时使用默认有效期 10 分钟
+func NewPresence(ttl time.Duration) *Presence {
+	if ttl <= 0 {
+		ttl = DEFAULT_PRESENCE_TTL
+	}
+	return &Presence{
+		ttl:   ttl,
+		users: map[string]*presenceEntry{},
+		now:   time.Now,
+	}
+}
+
+// OnChange 注册在线状态变化通知，用户由离线变为在线或由在线变为离线（含缓存中首次出现）时调用
+// fn 在 Update 所在的 goroutine 中同步执行，耗时操作请自行异步处理
+func (p *Presence) OnChange(fn func(PresenceChange)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.listeners = append(p.listeners, fn)
+}
+
+// HandleOnlineStatus 更新在线状态缓存，可作为 NewOnlineStatusHandler 的处理函数
+func (p *Presence) HandleOnlineStatus(ctx context.Context, statuses []OnlineStatus) error {
+	p.Update(statuses...)
+	return nil
+}
+
+// Update 更新在线状态缓存，同一用户同一设备平台早于缓存中状态的回调会被忽略
+func (p *Presence) Update(statuses ...OnlineStatus) {
+	p.mu.Lock()
+	now := p.now()
+	p.sweep(now)
+	var changes []PresenceChange
+	for _, s := range statuses {
+		e, ok := p.users[s.UserID]
+		if !ok || now.After(e.expires) {
+			e = &presenceEntry{devices: map[string]OnlineStatus{}}
+			p.users[s.UserID] = e
+			ok = false
+		}
+		if prev, exists := e.devices[s.OS]; exists && s.Time.Before(prev.Time) {
+			continue
+		}
+		wasOnline := e.online()
+		e.devices[s.OS] = s
+		e.expires = now.Add(p.ttl)
+		if online := e.online(); !ok || online != wasOnline {
+			changes = append(changes, PresenceChange{UserID: s.UserID, Online: online, Status: s})
+		}
+	}
+	listeners := p.listeners
+	p.mu.Unlock()
+
+	for _, c := range changes {
+		for _, fn := range listeners {
+			fn(c)
+		}
+	}
+}
+
+// Get 获取缓存中的用户在线状态，ok 为 false 表示未命中或已失效
+func (p *Presence) Get(userID string) (online bool, ok bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	e, ok := p.users[userID]
+	if !ok || p.now().After(e.expires) {
+		return false, false
+	}
+	return e.online(), true
+}
+
+// Devices 获取缓存中用户各设备平台的最新状态
+func (p *Presence) Devices(userID string) []OnlineStatus {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	e, ok := p.users[userID]
+	if !ok || p.now().After(e.expires) {
+		return nil
+	}
+	devices := make([]OnlineStatus, 0, len(e.devices))
+	for _, s := range e.devices {
+		devices = append(devices, s)
+	}
+	return devices
+}
+
+// OnlineStatus 实现 sdk.PresenceCache，返回 1 在线，0 不在线，与 OnlineStatusCheck 一致
+func (p *Presence) OnlineStatus(userID string) (int, bool) {
+	online, ok := p.Get(userID)
+	if !ok {
+		return 0, false
+	}
+	if online {
+		return 1, true
+	}
+	return 0, true
+}
+
+// sweep 清理已失效的缓存，最多每个 TTL 周期执行一次，调用方需持有写锁
+func (p *Presence) sweep(now time.Time) {
+	if now.Sub(p.lastSweep) < p.ttl {
+		return
+	}
+	p.lastSweep = now
+	for id, e := range p.users {
+		if now.After(e.expires) {
+			delete(p.users, id)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package callback
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rongcloud/server-sdk-go/v3/sdk"
+)
+
+func TestNewOnlineStatusHandler(t *testing.T) {
+	var got []OnlineStatus
+	h := NewOnlineStatusHandler(sdk.New(testAppKey, testAppSecret), func(ctx context.Context, statuses []OnlineStatus) error {
+		got = statuses
+		return nil
+	})
+	body := `[{"userid":"u1","status":"0","os":"iOS","time":1600000000123,"clientIp":"1.2.3.4"},` +
+		`{"userid":"u2","status":1,"os":"Android","time":"1600000000456"}]`
+	r := httptest.NewRequest(http.MethodPost, signedURL(testAppSecret, "123", nowMillis()), strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", w.Code, w.Body.String())
+	}
+	if len(got) != 2 {
+		t.Fatalf("statuses = %+v", got)
+	}
+	if got[0].UserID != "u1" || !got[0].Online() || got[0].OS != "iOS" || got[0].ClientIP != "1.2.3.4" ||
+		!got[0].Time.Equal(time.Unix(1600000000, 123*int64(time.Millisecond))) {
+		t.Errorf("statuses[0] = %+v", got[0])
+	}
+	if got[1].UserID != "u2" || got[1].Status != STATUS_OFFLINE {
+		t.Errorf("statuses[1] = %+v", got[1])
+	}
+}
+
+func TestPresence(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	p := NewPresence(time.Minute)
+	p.now = func() time.Time { return now }
+
+	var changes []PresenceChange
+	p.OnChange(func(c PresenceChange) {
+		changes = append(changes, c)
+	})
+
+	p.Update(OnlineStatus{UserID: "u1", Status: STATUS_ONLINE, OS: "iOS", Time: now})
+	p.Update(OnlineStatus{UserID: "u1", Status: STATUS_ONLINE, OS: "PC", Time: now})
+	// iOS 离线，PC 仍在线
+	p.Update(OnlineStatus{UserID: "u1", Status: STATUS_OFFLINE, OS: "iOS", Time: now.Add(time.Second)})
+	if status, ok := p.OnlineStatus("u1"); !ok || status != 1 {
+		t.Fatalf("OnlineStatus = %d, %v", status, ok)
+	}
+	// 早于缓存的回调被忽略
+	p.Update(OnlineStatus{UserID: "u1", Status: STATUS_LOGOUT, OS: "PC", Time: now.Add(-time.Second)})
+	if online, _ := p.Get("u1"); !online {
+		t.Fatal("stale status applied")
+	}
+	p.Update(OnlineStatus{UserID: "u1", Status: STATUS_LOGOUT, OS: "PC", Time: now.Add(2 * time.Second)})
+	if status, ok := p.OnlineStatus("u1"); !ok || status != 0 {
+		t.Fatalf("OnlineStatus = %d, %v", status, ok)
+	}
+	if len(p.Devices("u1")) != 2 {
+		t.Errorf("Devices = %+v", p.Devices("u1"))
+	}
+
+	if len(changes) != 2 || !changes[0].Online || changes[1].Online || changes[1].Status.OS != "PC" {
+		t.Errorf("changes = %+v", changes)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if _, ok := p.OnlineStatus("u1"); ok {
+		t.Error("expired status returned")
+	}
+}
+
+func TestPresence_OnlineStatusCheck(t *testing.T) {
+	p := NewPresence(time.Minute)
+	p.Update(OnlineStatus{UserID: "u1", Status: STATUS_ONLINE, OS: "iOS", Time: time.Now()})
+
+	called := false
+	rc := sdk.New(testAppKey, testAppSecret, sdk.WithPresenceCache(p), sdk.WithHTTPExecutor(executorFunc(func(req *http.Request) (*http.Response, error) {
+		called = true
+		return nil, context.Canceled
+	})))
+	status, err := rc.OnlineStatusCheck("u1")
+	if err != nil || status != 1 {
+		t.Fatalf("OnlineStatusCheck = %d, %v", status, err)
+	}
+	if called {
+		t.Error("request sent on cache hit")
+	}
+
+	if _, err := rc.OnlineStatusCheck("u2"); err == nil || !called {
+		t.Errorf("cache miss: err = %v, called = %v", err, called)
+	}
+}
+
+type executorFunc func(req *http.Request) (*http.Response, error)
+
+func (f executorFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
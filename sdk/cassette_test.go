@@ -0,0 +1,57 @@
+package sdk
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/rongcloud/server-sdk-go/v3/sdk/sdktest"
+)
+
+// 依赖真实应用的测试（使用 NewRongCloud 创建的全局对象）支持录制回放：
+//
+//	RC_CASSETTE=record APP_KEY=xxx APP_SECRET=xxx go test ./sdk/  // 请求沙箱应用并录制到 testdata/cassette.json
+//	RC_CASSETTE=replay go test ./sdk/                              // 从 testdata/cassette.json 回放，不访问网络
+//
+// 可通过 RC_CASSETTE_FILE 指定其他文件，通过 RC_CASSETTE_URI 指定录制时请求的 API 地址（如 sdktest.Server）
+// 仓库中的 testdata/cassette.json 由 sdktest.Server 录制，回放只验证 SDK 与模拟服务的交互
+func TestMain(m *testing.M) {
+	os.Exit(runWithCassette(m))
+}
+
+func runWithCassette(m *testing.M) int {
+	var mode sdktest.Mode
+	switch os.Getenv("RC_CASSETTE") {
+	case "":
+		return m.Run()
+	case "record":
+		mode = sdktest.MODE_RECORD
+	case "replay":
+		mode = sdktest.MODE_REPLAY
+	default:
+		fmt.Fprintln(os.Stderr, "RC_CASSETTE must be record or replay")
+		return 2
+	}
+	file := os.Getenv("RC_CASSETTE_FILE")
+	if file == "" {
+		file = "testdata/cassette.json"
+	}
+
+	recorder, err := sdktest.NewRecorder(file, mode, sdktest.WithRedact(os.Getenv("APP_SECRET")))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	options := []RongCloudOption{WithHTTPExecutor(recorder)}
+	if uri := os.Getenv("RC_CASSETTE_URI"); uri != "" {
+		options = append(options, WithRongCloudURI(uri))
+	}
+	// 先创建全局对象，测试中的 NewRongCloud 都会返回该对象
+	NewRongCloud(os.Getenv("APP_KEY"), os.Getenv("APP_SECRET"), options...)
+	code := m.Run()
+	if err := recorder.Close(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	return code
+}
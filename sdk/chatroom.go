@@ -3,12 +3,9 @@
 package sdk
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
 	"strconv"
-	"time"
-
-	"github.com/astaxie/beego/httplib"
 )
 
 // ChatRoomInfo 聊天室信息
@@ -69,6 +66,11 @@ type ChatRoomAttrResult struct {
  *@return error
  */
 func (rc *RongCloud) ChatRoomCreate(id, name string) error {
+	return rc.ChatRoomCreateContext(context.Background(), id, name)
+}
+
+// ChatRoomCreateContext 同 ChatRoomCreate，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) ChatRoomCreateContext(ctx context.Context, id, name string) error {
 	if id == "" {
 		return RCErrorNew(1002, "Paramer 'id' is required")
 	}
@@ -76,13 +78,11 @@ func (rc *RongCloud) ChatRoomCreate(id, name string) error {
 		return RCErrorNew(1002, "Paramer 'name' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/create." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/chatroom/create")
 
 	req.Param("chatroom["+id+"]", name)
 
-	_, err := rc.do(req)
+	_, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 	}
@@ -97,17 +97,20 @@ func (rc *RongCloud) ChatRoomCreate(id, name string) error {
  *@return error
  */
 func (rc *RongCloud) ChatRoomDestroy(id string) error {
+	return rc.ChatRoomDestroyContext(context.Background(), id)
+}
+
+// ChatRoomDestroyContext 同 ChatRoomDestroy，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) ChatRoomDestroyContext(ctx context.Context, id string) error {
 	if id == "" {
 		return RCErrorNew(1002, "Paramer 'id' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/destroy." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/chatroom/destroy")
 
 	req.Param("chatroomId", id)
 
-	_, err := rc.do(req)
+	_, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 	}
@@ -123,6 +126,11 @@ func (rc *RongCloud) ChatRoomDestroy(id string) error {
  *@return ChatRoomResult error
  */
 func (rc *RongCloud) ChatRoomGet(id string, count, order int) (ChatRoomResult, error) {
+	return rc.ChatRoomGetContext(context.Background(), id, count, order)
+}
+
+// ChatRoomGetContext 同 ChatRoomGet，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) ChatRoomGetContext(ctx context.Context, id string, count, order int) (ChatRoomResult, error) {
 	if id == "" {
 		return ChatRoomResult{}, RCErrorNew(1002, "Paramer 'id' is required")
 	}
@@ -135,14 +143,12 @@ func (rc *RongCloud) ChatRoomGet(id string, count, order int) (ChatRoomResult, e
 		return ChatRoomResult{}, RCErrorNew(1002, "Paramer 'order' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/user/query." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/chatroom/user/query")
 	req.Param("chatroomId", id)
 	req.Param("count", strconv.Itoa(count))
 	req.Param("order", strconv.Itoa(order))
 
-	resp, err := rc.do(req)
+	resp, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 		return ChatRoomResult{}, err
@@ -162,6 +168,11 @@ func (rc *RongCloud) ChatRoomGet(id string, count, order int) (ChatRoomResult, e
  *@return ChatRoomResult error
  */
 func (rc *RongCloud) ChatRoomIsExist(id string, members []string) ([]ChatRoomUser, error) {
+	return rc.ChatRoomIsExistContext(context.Background(), id, members)
+}
+
+// ChatRoomIsExistContext 同 ChatRoomIsExist，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) ChatRoomIsExistContext(ctx context.Context, id string, members []string) ([]ChatRoomUser, error) {
 	if id == "" {
 		return []ChatRoomUser{}, RCErrorNew(1002, "Paramer 'id' is required")
 	}
@@ -170,15 +181,13 @@ func (rc *RongCloud) ChatRoomIsExist(id string, members []string) ([]ChatRoomUse
 		return []ChatRoomUser{}, RCErrorNew(1002, "Paramer 'count' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/users/exist." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/chatroom/users/exist")
 	req.Param("chatroomId", id)
 	for _, v := range members {
 		req.Param("userId", v)
 	}
 
-	resp, err := rc.do(req)
+	resp, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 		return []ChatRoomUser{}, err
@@ -200,6 +209,11 @@ func (rc *RongCloud) ChatRoomIsExist(id string, members []string) ([]ChatRoomUse
  *@return error
  */
 func (rc *RongCloud) ChatRoomBlockAdd(id string, members []string, minute uint) error {
+	return rc.ChatRoomBlockAddContext(context.Background(), id, members, minute)
+}
+
+// ChatRoomBlockAddContext 同 ChatRoomBlockAdd，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) ChatRoomBlockAddContext(ctx context.Context, id string, members []string, minute uint) error {
 	if id == "" {
 		return RCErrorNew(1002, "Paramer 'id' is required")
 	}
@@ -212,16 +226,14 @@ func (rc *RongCloud) ChatRoomBlockAdd(id string, members []string, minute uint)
 		return RCErrorNew(1002, "Paramer 'minute' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/user/block/add." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/chatroom/user/block/add")
 	req.Param("chatroomId", id)
 	for _, v := range members {
 		req.Param("userId", v)
 	}
 	req.Param("minute", strconv.Itoa(int(minute)))
 
-	_, err := rc.do(req)
+	_, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 	}
@@ -237,6 +249,11 @@ func (rc *RongCloud) ChatRoomBlockAdd(id string, members []string, minute uint)
  *@return error
  */
 func (rc *RongCloud) ChatRoomBlockRemove(id string, members []string) error {
+	return rc.ChatRoomBlockRemoveContext(context.Background(), id, members)
+}
+
+// ChatRoomBlockRemoveContext 同 ChatRoomBlockRemove，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) ChatRoomBlockRemoveContext(ctx context.Context, id string, members []string) error {
 	if id == "" {
 		return RCErrorNew(1002, "Paramer 'id' is required")
 	}
@@ -245,15 +262,13 @@ func (rc *RongCloud) ChatRoomBlockRemove(id string, members []string) error {
 		return RCErrorNew(1002, "Paramer 'members' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/user/block/rollback." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/chatroom/user/block/rollback")
 	for _, v := range members {
 		req.Param("userId", v)
 	}
 	req.Param("chatroomId", id)
 
-	_, err := rc.do(req)
+	_, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 	}
@@ -267,17 +282,20 @@ func (rc *RongCloud) ChatRoomBlockRemove(id string, members []string) error {
  *@return ChatRoomResult error
  */
 func (rc *RongCloud) ChatRoomBlockGetList(id string) (ChatRoomResult, error) {
+	return rc.ChatRoomBlockGetListContext(context.Background(), id)
+}
+
+// ChatRoomBlockGetListContext 同 ChatRoomBlockGetList，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) ChatRoomBlockGetListContext(ctx context.Context, id string) (ChatRoomResult, error) {
 	var dat ChatRoomResult
 	if id == "" {
 		return dat, RCErrorNew(1002, "Paramer 'id' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/user/block/list." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/chatroom/user/block/list")
 	req.Param("chatroomId", id)
 
-	resp, err := rc.do(req)
+	resp, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 		return dat, err
@@ -296,6 +314,11 @@ func (rc *RongCloud) ChatRoomBlockGetList(id string) (ChatRoomResult, error) {
  *@return error
  */
 func (rc *RongCloud) ChatRoomBanAdd(members []string, minute uint) error {
+	return rc.ChatRoomBanAddContext(context.Background(), members, minute)
+}
+
+// ChatRoomBanAddContext 同 ChatRoomBanAdd，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) ChatRoomBanAddContext(ctx context.Context, members []string, minute uint) error {
 
 	if len(members) == 0 {
 		return RCErrorNew(1002, "Paramer 'members' is required")
@@ -304,15 +327,13 @@ func (rc *RongCloud) ChatRoomBanAdd(members []string, minute uint) error {
 		return RCErrorNew(1002, "Paramer 'minute' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/user/ban/add." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/chatroom/user/ban/add")
 	for _, v := range members {
 		req.Param("userId", v)
 	}
 	req.Param("minute", strconv.Itoa(int(minute)))
 
-	_, err := rc.do(req)
+	_, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 	}
@@ -326,19 +347,22 @@ func (rc *RongCloud) ChatRoomBanAdd(members []string, minute uint) error {
  *@return error
  */
 func (rc *RongCloud) ChatRoomBanRemove(members []string) error {
+	return rc.ChatRoomBanRemoveContext(context.Background(), members)
+}
+
+// ChatRoomBanRemoveContext 同 ChatRoomBanRemove，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) ChatRoomBanRemoveContext(ctx context.Context, members []string) error {
 
 	if len(members) == 0 {
 		return RCErrorNew(1002, "Paramer 'members' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/user/ban/remove." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/chatroom/user/ban/remove")
 	for _, v := range members {
 		req.Param("userId", v)
 	}
 
-	_, err := rc.do(req)
+	_, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 	}
@@ -350,12 +374,15 @@ func (rc *RongCloud) ChatRoomBanRemove(members []string) error {
  *@return []ChatRoomUser error
  */
 func (rc *RongCloud) ChatRoomBanGetList() ([]ChatRoomUser, error) {
+	return rc.ChatRoomBanGetListContext(context.Background())
+}
+
+// ChatRoomBanGetListContext 同 ChatRoomBanGetList，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) ChatRoomBanGetListContext(ctx context.Context) ([]ChatRoomUser, error) {
 	var dat ChatRoomResult
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/user/ban/query." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/chatroom/user/ban/query")
 
-	resp, err := rc.do(req)
+	resp, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 		return []ChatRoomUser{}, err
@@ -376,6 +403,11 @@ func (rc *RongCloud) ChatRoomBanGetList() ([]ChatRoomUser, error) {
  *@return error
  */
 func (rc *RongCloud) ChatRoomGagAdd(id string, members []string, minute uint) error {
+	return rc.ChatRoomGagAddContext(context.Background(), id, members, minute)
+}
+
+// ChatRoomGagAddContext 同 ChatRoomGagAdd，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) ChatRoomGagAddContext(ctx context.Context, id string, members []string, minute uint) error {
 	if id == "" {
 		return RCErrorNew(1002, "Paramer 'id' is required")
 	}
@@ -388,16 +420,14 @@ func (rc *RongCloud) ChatRoomGagAdd(id string, members []string, minute uint) er
 		return RCErrorNew(1002, "Paramer 'minute' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/user/gag/add." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/chatroom/user/gag/add")
 	for _, v := range members {
 		req.Param("userId", v)
 	}
 	req.Param("chatroomId", id)
 	req.Param("minute", strconv.Itoa(int(minute)))
 
-	_, err := rc.do(req)
+	_, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 	}
@@ -412,6 +442,11 @@ func (rc *RongCloud) ChatRoomGagAdd(id string, members []string, minute uint) er
  *@return error
  */
 func (rc *RongCloud) ChatRoomGagRemove(id string, members []string) error {
+	return rc.ChatRoomGagRemoveContext(context.Background(), id, members)
+}
+
+// ChatRoomGagRemoveContext 同 ChatRoomGagRemove，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) ChatRoomGagRemoveContext(ctx context.Context, id string, members []string) error {
 	if id == "" {
 		return RCErrorNew(1002, "Paramer 'id' is required")
 	}
@@ -420,15 +455,13 @@ func (rc *RongCloud) ChatRoomGagRemove(id string, members []string) error {
 		return RCErrorNew(1002, "Paramer 'members' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/user/gag/rollback." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/chatroom/user/gag/rollback")
 	for _, v := range members {
 		req.Param("userId", v)
 	}
 	req.Param("chatroomId", id)
 
-	_, err := rc.do(req)
+	_, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 	}
@@ -442,16 +475,19 @@ func (rc *RongCloud) ChatRoomGagRemove(id string, members []string) error {
  *@return []ChatRoomUser error
  */
 func (rc *RongCloud) ChatRoomGagGetList(id string) ([]ChatRoomUser, error) {
+	return rc.ChatRoomGagGetListContext(context.Background(), id)
+}
+
+// ChatRoomGagGetListContext 同 ChatRoomGagGetList，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) ChatRoomGagGetListContext(ctx context.Context, id string) ([]ChatRoomUser, error) {
 	var dat ChatRoomResult
 	if id == "" {
 		return []ChatRoomUser{}, RCErrorNew(1002, "Paramer 'chatroomId' is required")
 	}
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/user/gag/list." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/chatroom/user/gag/list")
 	req.Param("chatroomId", id)
 
-	resp, err := rc.do(req)
+	resp, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 		return []ChatRoomUser{}, err
@@ -468,18 +504,21 @@ func (rc *RongCloud) ChatRoomGagGetList(id string) ([]ChatRoomUser, error) {
  *@return err
  */
 func (rc *RongCloud) ChatRoomDemotionAdd(objectNames []string) error {
+	return rc.ChatRoomDemotionAddContext(context.Background(), objectNames)
+}
+
+// ChatRoomDemotionAddContext 同 ChatRoomDemotionAdd，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) ChatRoomDemotionAddContext(ctx context.Context, objectNames []string) error {
 	if len(objectNames) == 0 {
 		return RCErrorNew(1002, "Paramer 'objectName' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/message/priority/add." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/chatroom/message/priority/add")
 	for _, v := range objectNames {
 		req.Param("objectName", v)
 	}
 
-	_, err := rc.do(req)
+	_, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 	}
@@ -492,18 +531,21 @@ func (rc *RongCloud) ChatRoomDemotionAdd(objectNames []string) error {
  *@return err
  */
 func (rc *RongCloud) ChatRoomDemotionRemove(objectNames []string) error {
+	return rc.ChatRoomDemotionRemoveContext(context.Background(), objectNames)
+}
+
+// ChatRoomDemotionRemoveContext 同 ChatRoomDemotionRemove，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) ChatRoomDemotionRemoveContext(ctx context.Context, objectNames []string) error {
 	if len(objectNames) == 0 {
 		return RCErrorNew(1002, "Paramer 'objectName' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/message/priority/remove." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/chatroom/message/priority/remove")
 	for _, v := range objectNames {
 		req.Param("objectName", v)
 	}
 
-	_, err := rc.do(req)
+	_, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 	}
@@ -515,13 +557,16 @@ func (rc *RongCloud) ChatRoomDemotionRemove(objectNames []string) error {
  *@return []string error
  */
 func (rc *RongCloud) ChatRoomDemotionGetList() ([]string, error) {
+	return rc.ChatRoomDemotionGetListContext(context.Background())
+}
+
+// ChatRoomDemotionGetListContext 同 ChatRoomDemotionGetList，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) ChatRoomDemotionGetListContext(ctx context.Context) ([]string, error) {
 	var dat ChatRoomResult
 
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/message/priority/query." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/chatroom/message/priority/query")
 
-	resp, err := rc.do(req)
+	resp, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 		return []string{}, err
@@ -538,16 +583,19 @@ func (rc *RongCloud) ChatRoomDemotionGetList() ([]string, error) {
  *@return error
  */
 func (rc *RongCloud) ChatRoomDistributionStop(id string) error {
+	return rc.ChatRoomDistributionStopContext(context.Background(), id)
+}
+
+// ChatRoomDistributionStopContext 同 ChatRoomDistributionStop，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) ChatRoomDistributionStopContext(ctx context.Context, id string) error {
 	if id == "" {
 		return RCErrorNew(1002, "Paramer 'chatroomId' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/message/stopDistribution." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/chatroom/message/stopDistribution")
 	req.Param("chatroomId", id)
 
-	_, err := rc.do(req)
+	_, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 	}
@@ -560,15 +608,18 @@ func (rc *RongCloud) ChatRoomDistributionStop(id string) error {
  *@return error
  */
 func (rc *RongCloud) ChatRoomDistributionResume(id string) error {
+	return rc.ChatRoomDistributionResumeContext(context.Background(), id)
+}
+
+// ChatRoomDistributionResumeContext 同 ChatRoomDistributionResume，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) ChatRoomDistributionResumeContext(ctx context.Context, id string) error {
 	if id == "" {
 		return RCErrorNew(1002, "Paramer 'chatroomId' is required")
 	}
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/message/resumeDistribution." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/chatroom/message/resumeDistribution")
 	req.Param("chatroomId", id)
 
-	_, err := rc.do(req)
+	_, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 	}
@@ -581,15 +632,18 @@ func (rc *RongCloud) ChatRoomDistributionResume(id string) error {
  *@return error
  */
 func (rc *RongCloud) ChatRoomKeepAliveAdd(id string) error {
+	return rc.ChatRoomKeepAliveAddContext(context.Background(), id)
+}
+
+// ChatRoomKeepAliveAddContext 同 ChatRoomKeepAliveAdd，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) ChatRoomKeepAliveAddContext(ctx context.Context, id string) error {
 	if id == "" {
 		return RCErrorNew(1002, "Paramer 'chatroomId' is required")
 	}
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/keepalive/add." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/chatroom/keepalive/add")
 	req.Param("chatroomId", id)
 
-	_, err := rc.do(req)
+	_, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 	}
@@ -602,15 +656,18 @@ func (rc *RongCloud) ChatRoomKeepAliveAdd(id string) error {
  *@return error
  */
 func (rc *RongCloud) ChatRoomKeepAliveRemove(id string) error {
+	return rc.ChatRoomKeepAliveRemoveContext(context.Background(), id)
+}
+
+// ChatRoomKeepAliveRemoveContext 同 ChatRoomKeepAliveRemove，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) ChatRoomKeepAliveRemoveContext(ctx context.Context, id string) error {
 	if id == "" {
 		return RCErrorNew(1002, "Paramer 'chatroomId' is required")
 	}
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/keepalive/remove." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/chatroom/keepalive/remove")
 	req.Param("chatroomId", id)
 
-	_, err := rc.do(req)
+	_, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 	}
@@ -623,16 +680,19 @@ func (rc *RongCloud) ChatRoomKeepAliveRemove(id string) error {
  *@return []string error
  */
 func (rc *RongCloud) ChatRoomKeepAliveGetList() ([]string, error) {
+	return rc.ChatRoomKeepAliveGetListContext(context.Background())
+}
+
+// ChatRoomKeepAliveGetListContext 同 ChatRoomKeepAliveGetList，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) ChatRoomKeepAliveGetListContext(ctx context.Context) ([]string, error) {
 	var dat ChatRoomResult
 	// if id == "" {
 	// 	return []string{}, RCErrorNew(1002, "Paramer 'chatroomId' is required")
 	// }
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/keepalive/query." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/chatroom/keepalive/query")
 	// req.Param("chatroomId", id)
 
-	resp, err := rc.do(req)
+	resp, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 		return []string{}, err
@@ -649,19 +709,22 @@ func (rc *RongCloud) ChatRoomKeepAliveGetList() ([]string, error) {
  *@return error
  */
 func (rc *RongCloud) ChatRoomWhitelistAdd(objectNames []string) error {
+	return rc.ChatRoomWhitelistAddContext(context.Background(), objectNames)
+}
+
+// ChatRoomWhitelistAddContext 同 ChatRoomWhitelistAdd，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) ChatRoomWhitelistAddContext(ctx context.Context, objectNames []string) error {
 
 	if len(objectNames) == 0 {
 		return RCErrorNew(1002, "Paramer 'objectNames' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/whitelist/add." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/chatroom/whitelist/add")
 	for _, v := range objectNames {
 		req.Param("objectnames", v)
 	}
 
-	_, err := rc.do(req)
+	_, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 	}
@@ -674,20 +737,23 @@ func (rc *RongCloud) ChatRoomWhitelistAdd(objectNames []string) error {
  *@return error
  */
 func (rc *RongCloud) ChatRoomWhitelistRemove(objectNames []string) error {
+	return rc.ChatRoomWhitelistRemoveContext(context.Background(), objectNames)
+}
+
+// ChatRoomWhitelistRemoveContext 同 ChatRoomWhitelistRemove，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) ChatRoomWhitelistRemoveContext(ctx context.Context, objectNames []string) error {
 
 	if len(objectNames) == 0 {
 		return RCErrorNew(1002, "Paramer 'objectNames' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/whitelist/delete." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/chatroom/whitelist/delete")
 
 	for _, v := range objectNames {
 		req.Param("objectnames", v)
 	}
 
-	_, err := rc.do(req)
+	_, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 	}
@@ -699,13 +765,16 @@ func (rc *RongCloud) ChatRoomWhitelistRemove(objectNames []string) error {
  *@return []string error
  */
 func (rc *RongCloud) ChatRoomWhitelistGetList() ([]string, error) {
+	return rc.ChatRoomWhitelistGetListContext(context.Background())
+}
+
+// ChatRoomWhitelistGetListContext 同 ChatRoomWhitelistGetList，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) ChatRoomWhitelistGetListContext(ctx context.Context) ([]string, error) {
 	var dat ChatRoomResult
 
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/whitelist/query." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/chatroom/whitelist/query")
 
-	resp, err := rc.do(req)
+	resp, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 		return []string{}, err
@@ -724,6 +793,11 @@ func (rc *RongCloud) ChatRoomWhitelistGetList() ([]string, error) {
  *@return error
  */
 func (rc *RongCloud) ChatRoomUserWhitelistAdd(id string, members []string) error {
+	return rc.ChatRoomUserWhitelistAddContext(context.Background(), id, members)
+}
+
+// ChatRoomUserWhitelistAddContext 同 ChatRoomUserWhitelistAdd，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) ChatRoomUserWhitelistAddContext(ctx context.Context, id string, members []string) error {
 	if id == "" {
 		return RCErrorNew(1002, "Paramer 'id' is required")
 	}
@@ -732,15 +806,13 @@ func (rc *RongCloud) ChatRoomUserWhitelistAdd(id string, members []string) error
 		return RCErrorNew(1002, "Paramer 'members' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/user/whitelist/add." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/chatroom/user/whitelist/add")
 	req.Param("chatroomId", id)
 	for _, v := range members {
 		req.Param("userId", v)
 	}
 
-	_, err := rc.do(req)
+	_, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 	}
@@ -754,6 +826,11 @@ func (rc *RongCloud) ChatRoomUserWhitelistAdd(id string, members []string) error
  *@return error
  */
 func (rc *RongCloud) ChatRoomUserWhitelistRemove(id string, members []string) error {
+	return rc.ChatRoomUserWhitelistRemoveContext(context.Background(), id, members)
+}
+
+// ChatRoomUserWhitelistRemoveContext 同 ChatRoomUserWhitelistRemove，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) ChatRoomUserWhitelistRemoveContext(ctx context.Context, id string, members []string) error {
 	if id == "" {
 		return RCErrorNew(1002, "Paramer 'id' is required")
 	}
@@ -762,15 +839,13 @@ func (rc *RongCloud) ChatRoomUserWhitelistRemove(id string, members []string) er
 		return RCErrorNew(1002, "Paramer 'members' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/user/whitelist/remove." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/chatroom/user/whitelist/remove")
 	req.Param("chatroomId", id)
 	for _, v := range members {
 		req.Param("userId", v)
 	}
 
-	_, err := rc.do(req)
+	_, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 	}
@@ -783,23 +858,19 @@ func (rc *RongCloud) ChatRoomUserWhitelistRemove(id string, members []string) er
  *@return []string error
  */
 func (rc *RongCloud) ChatRoomUserWhitelistGetList(id string) ([]string, error) {
+	return rc.ChatRoomUserWhitelistGetListContext(context.Background(), id)
+}
+
+// ChatRoomUserWhitelistGetListContext 同 ChatRoomUserWhitelistGetList，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) ChatRoomUserWhitelistGetListContext(ctx context.Context, id string) ([]string, error) {
 	var dat map[string]interface{}
 	if id == "" {
 		return []string{}, RCErrorNew(1002, "Paramer 'id' is required")
 	}
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/user/whitelist/query." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/chatroom/user/whitelist/query")
 	req.Param("chatroomId", id)
 
-	response, err := req.Response()
-	if err != nil {
-		return []string{}, err
-	}
-
-	rc.checkStatusCode(response)
-
-	rep, err := req.Bytes()
+	rep, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 		return []string{}, err
@@ -834,6 +905,11 @@ func (rc *RongCloud) ChatRoomUserWhitelistGetList(id string) ([]string, error) {
  *@return error
  */
 func (rc *RongCloud) ChatRoomMuteMembersAdd(id string, members []string, minute uint) error {
+	return rc.ChatRoomMuteMembersAddContext(context.Background(), id, members, minute)
+}
+
+// ChatRoomMuteMembersAddContext 同 ChatRoomMuteMembersAdd，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) ChatRoomMuteMembersAddContext(ctx context.Context, id string, members []string, minute uint) error {
 	if id == "" {
 		return RCErrorNew(1002, "Paramer 'id' is required")
 	}
@@ -846,9 +922,7 @@ func (rc *RongCloud) ChatRoomMuteMembersAdd(id string, members []string, minute
 		return RCErrorNew(1002, "Paramer 'minute' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/user/gag/add." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/chatroom/user/gag/add")
 	for _, v := range members {
 		req.Param("userId", v)
 	}
@@ -856,7 +930,7 @@ func (rc *RongCloud) ChatRoomMuteMembersAdd(id string, members []string, minute
 	req.Param("chatroomId", id)
 	req.Param("minute", strconv.Itoa(int(minute)))
 
-	_, err := rc.do(req)
+	_, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 	}
@@ -870,23 +944,19 @@ func (rc *RongCloud) ChatRoomMuteMembersAdd(id string, members []string, minute
  *@return []ChatRoomUser error
  */
 func (rc *RongCloud) ChatRoomMuteMembersGetList(id string) ([]ChatRoomUser, error) {
+	return rc.ChatRoomMuteMembersGetListContext(context.Background(), id)
+}
+
+// ChatRoomMuteMembersGetListContext 同 ChatRoomMuteMembersGetList，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) ChatRoomMuteMembersGetListContext(ctx context.Context, id string) ([]ChatRoomUser, error) {
 	var dat ChatRoomResult
 	if id == "" {
 		return []ChatRoomUser{}, RCErrorNew(1002, "Paramer 'chatroomId' is required")
 	}
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/user/gag/list." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/chatroom/user/gag/list")
 	req.Param("chatroomId", id)
 
-	response, err := req.Response()
-	if err != nil {
-		return []ChatRoomUser{}, err
-	}
-
-	rc.checkStatusCode(response)
-
-	rep, err := req.Bytes()
+	rep, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 		return []ChatRoomUser{}, err
@@ -912,6 +982,11 @@ func (rc *RongCloud) ChatRoomMuteMembersGetList(id string) ([]ChatRoomUser, erro
  *@return error
  */
 func (rc *RongCloud) ChatRoomMuteMembersRemove(id string, members []string) error {
+	return rc.ChatRoomMuteMembersRemoveContext(context.Background(), id, members)
+}
+
+// ChatRoomMuteMembersRemoveContext 同 ChatRoomMuteMembersRemove，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) ChatRoomMuteMembersRemoveContext(ctx context.Context, id string, members []string) error {
 	if id == "" {
 		return RCErrorNew(1002, "Paramer 'id' is required")
 	}
@@ -920,15 +995,13 @@ func (rc *RongCloud) ChatRoomMuteMembersRemove(id string, members []string) erro
 		return RCErrorNew(1002, "Paramer 'members' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/user/gag/rollback." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/chatroom/user/gag/rollback")
 	for _, v := range members {
 		req.Param("userId", v)
 	}
 	req.Param("chatroomId", id)
 
-	_, err := rc.do(req)
+	_, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 	}
@@ -946,6 +1019,11 @@ func (rc *RongCloud) ChatRoomMuteMembersRemove(id string, members []string) erro
  * @retrun error
  */
 func (rc *RongCloud) ChatRoomEntrySet(chatRoomID, userID, key, value string, autoDelete bool) error {
+	return rc.ChatRoomEntrySetContext(context.Background(), chatRoomID, userID, key, value, autoDelete)
+}
+
+// ChatRoomEntrySetContext 同 ChatRoomEntrySet，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) ChatRoomEntrySetContext(ctx context.Context, chatRoomID, userID, key, value string, autoDelete bool) error {
 	if chatRoomID == "" {
 		return RCErrorNew(1002, "Paramer 'chatRoomID' is required")
 	}
@@ -962,9 +1040,7 @@ func (rc *RongCloud) ChatRoomEntrySet(chatRoomID, userID, key, value string, aut
 		return RCErrorNew(1002, "Paramer 'value' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/entry/set." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/chatroom/entry/set")
 
 	req.Param("chatroomId", chatRoomID)
 	req.Param("userId", userID)
@@ -972,7 +1048,7 @@ func (rc *RongCloud) ChatRoomEntrySet(chatRoomID, userID, key, value string, aut
 	req.Param("value", value)
 	req.Param("autoDelete", strconv.FormatBool(autoDelete))
 
-	_, err := rc.do(req)
+	_, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 	}
@@ -989,6 +1065,11 @@ func (rc *RongCloud) ChatRoomEntrySet(chatRoomID, userID, key, value string, aut
  * @return error
  */
 func (rc *RongCloud) ChatRoomEntryRemove(chatRoomID, userID, key string) error {
+	return rc.ChatRoomEntryRemoveContext(context.Background(), chatRoomID, userID, key)
+}
+
+// ChatRoomEntryRemoveContext 同 ChatRoomEntryRemove，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) ChatRoomEntryRemoveContext(ctx context.Context, chatRoomID, userID, key string) error {
 	if chatRoomID == "" {
 		return RCErrorNew(1002, "Paramer 'chatRoomID' is required")
 	}
@@ -1001,15 +1082,13 @@ func (rc *RongCloud) ChatRoomEntryRemove(chatRoomID, userID, key string) error {
 		return RCErrorNew(1002, "Paramer 'key' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/entry/remove." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/chatroom/entry/remove")
 
 	req.Param("chatroomId", chatRoomID)
 	req.Param("userId", userID)
 	req.Param("key", key)
 
-	_, err := rc.do(req)
+	_, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 	}
@@ -1026,18 +1105,21 @@ func (rc *RongCloud) ChatRoomEntryRemove(chatRoomID, userID, key string) error {
  * @return error 			错误
  */
 func (rc *RongCloud) ChatRoomEntryQuery(chatRoomID, keys string) ([]ChatRoomAttr, error) {
+	return rc.ChatRoomEntryQueryContext(context.Background(), chatRoomID, keys)
+}
+
+// ChatRoomEntryQueryContext 同 ChatRoomEntryQuery，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) ChatRoomEntryQueryContext(ctx context.Context, chatRoomID, keys string) ([]ChatRoomAttr, error) {
 	if chatRoomID == "" {
 		return nil, RCErrorNew(1002, "Paramer 'chatRoomID' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/chatroom/entry/query." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/chatroom/entry/query")
 
 	req.Param("chatroomId", chatRoomID)
 	req.Param("keys", keys)
 
-	resp, err := rc.do(req)
+	resp, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 		return nil, err
@@ -1060,20 +1142,22 @@ func (rc *RongCloud) ChatRoomEntryQuery(chatRoomID, keys string) ([]ChatRoomAttr
  *
  */
 func (rc *RongCloud) ChatRoomQuery(chatRoomID []string) ([]ChatRoom, error) {
+	return rc.ChatRoomQueryContext(context.Background(), chatRoomID)
+}
+
+// ChatRoomQueryContext 同 ChatRoomQuery，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) ChatRoomQueryContext(ctx context.Context, chatRoomID []string) ([]ChatRoom, error) {
 	if len(chatRoomID) <= 0 {
 		return nil, RCErrorNew(1002, "Paramer 'chatRoomID' is required")
 	}
 
-	url := fmt.Sprintf(`%s/chatroom/query.%s`, rc.rongCloudURI, ReqType)
-	req := httplib.Post(url)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/chatroom/query")
 
 	for _, v := range chatRoomID {
 		req.Param("chatroomId", v)
 	}
 
-	resp, err := rc.do(req)
+	resp, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 		return nil, err
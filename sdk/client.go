@@ -0,0 +1,250 @@
+package sdk
+
+import (
+	"context"
+	"time"
+)
+
+// Client 融云 Server API 的全部接口，*RongCloud 实现了该接口
+// 业务代码依赖 Client 或按领域拆分的接口（如 UserClient）而不是 *RongCloud 时，测试中可替换为 sdkmock 包中的实现
+type Client interface {
+	UserClient
+	GroupClient
+	ChatRoomClient
+	MessageClient
+	ConversationClient
+	SensitiveClient
+	PushClient
+}
+
+// UserClient 用户接口，包括注册、封禁、黑白名单、在线状态及标签
+type UserClient interface {
+	AddWhiteList(userId string, whiteList []string) error
+	AddWhiteListContext(ctx context.Context, userId string, whiteList []string) error
+	RemoveWhiteList(userId string, whiteList []string) error
+	RemoveWhiteListContext(ctx context.Context, userId string, whiteList []string) error
+	QueryWhiteList(userId string) (WhiteList, error)
+	QueryWhiteListContext(ctx context.Context, userId string) (WhiteList, error)
+	UserRegister(userID, name, portraitURI string) (User, error)
+	UserRegisterContext(ctx context.Context, userID, name, portraitURI string) (User, error)
+	UserUpdate(userID, name, portraitURI string) error
+	UserUpdateContext(ctx context.Context, userID, name, portraitURI string) error
+	BlockAdd(id string, minute uint64) error
+	BlockAddContext(ctx context.Context, id string, minute uint64) error
+	BlockRemove(id string) error
+	BlockRemoveContext(ctx context.Context, id string) error
+	BlockGetList() (BlockListResult, error)
+	BlockGetListContext(ctx context.Context) (BlockListResult, error)
+	BlacklistAdd(id string, blacklist []string) error
+	BlacklistAddContext(ctx context.Context, id string, blacklist []string) error
+	BlacklistRemove(id string, blacklist []string) error
+	BlacklistRemoveContext(ctx context.Context, id string, blacklist []string) error
+	BlacklistGet(id string) (BlacklistResult, error)
+	BlacklistGetContext(ctx context.Context, id string) (BlacklistResult, error)
+	OnlineStatusCheck(userID string) (int, error)
+	OnlineStatusCheckContext(ctx context.Context, userID string) (int, error)
+	TagSet(tag Tag) error
+	TagSetContext(ctx context.Context, tag Tag) error
+	TagBatchSet(tagBatch TagBatch) error
+	TagBatchSetContext(ctx context.Context, tagBatch TagBatch) error
+	TagGet(userIds []string) (TagResult, error)
+	TagGetContext(ctx context.Context, userIds []string) (TagResult, error)
+	TagGetBatch(userIds []string) (TagResult, BatchResult)
+	TagGetBatchContext(ctx context.Context, userIds []string) (TagResult, BatchResult)
+}
+
+// GroupClient 群组接口，包括群组管理及禁言
+type GroupClient interface {
+	GroupCreate(id, name string, members []string) error
+	GroupCreateContext(ctx context.Context, id, name string, members []string) error
+	GroupSync(id string, groups []Group) error
+	GroupSyncContext(ctx context.Context, id string, groups []Group) error
+	GroupUpdate(id, name string) error
+	GroupUpdateContext(ctx context.Context, id, name string) error
+	GroupJoin(id, name, member string) error
+	GroupJoinContext(ctx context.Context, id, name, member string) error
+	GroupGet(id string) (Group, error)
+	GroupGetContext(ctx context.Context, id string) (Group, error)
+	GroupQuit(member, id string) error
+	GroupQuitContext(ctx context.Context, member, id string) error
+	GroupDismiss(id, member string) error
+	GroupDismissContext(ctx context.Context, id, member string) error
+	GroupGagAdd(id string, members []string, minute int) error
+	GroupGagAddContext(ctx context.Context, id string, members []string, minute int) error
+	GroupMuteMembersAdd(id string, members []string, minute int) error
+	GroupMuteMembersAddContext(ctx context.Context, id string, members []string, minute int) error
+	GroupGagList(id string) (Group, error)
+	GroupGagListContext(ctx context.Context, id string) (Group, error)
+	GroupMuteMembersGetList(id string) (Group, error)
+	GroupMuteMembersGetListContext(ctx context.Context, id string) (Group, error)
+	GroupGagRemove(id string, members []string) error
+	GroupGagRemoveContext(ctx context.Context, id string, members []string) error
+	GroupMuteMembersRemove(id string, members []string) error
+	GroupMuteMembersRemoveContext(ctx context.Context, id string, members []string) error
+	GroupMuteAllMembersAdd(members []string) error
+	GroupMuteAllMembersAddContext(ctx context.Context, members []string) error
+	GroupMuteAllMembersRemove(members []string) error
+	GroupMuteAllMembersRemoveContext(ctx context.Context, members []string) error
+	GroupMuteAllMembersGetList(members []string) (GroupInfo, error)
+	GroupMuteAllMembersGetListContext(ctx context.Context, members []string) (GroupInfo, error)
+	GroupMuteWhiteListUserAdd(id string, members []string) error
+	GroupMuteWhiteListUserAddContext(ctx context.Context, id string, members []string) error
+	GroupMuteWhiteListUserRemove(id string, members []string) error
+	GroupMuteWhiteListUserRemoveContext(ctx context.Context, id string, members []string) error
+	GroupMuteWhiteListUserGetList(id string) ([]string, error)
+	GroupMuteWhiteListUserGetListContext(ctx context.Context, id string) ([]string, error)
+}
+
+// ChatRoomClient 聊天室接口，包括聊天室管理、成员封禁禁言、消息分发及属性
+type ChatRoomClient interface {
+	ChatRoomCreate(id, name string) error
+	ChatRoomCreateContext(ctx context.Context, id, name string) error
+	ChatRoomDestroy(id string) error
+	ChatRoomDestroyContext(ctx context.Context, id string) error
+	ChatRoomGet(id string, count, order int) (ChatRoomResult, error)
+	ChatRoomGetContext(ctx context.Context, id string, count, order int) (ChatRoomResult, error)
+	ChatRoomIsExist(id string, members []string) ([]ChatRoomUser, error)
+	ChatRoomIsExistContext(ctx context.Context, id string, members []string) ([]ChatRoomUser, error)
+	ChatRoomBlockAdd(id string, members []string, minute uint) error
+	ChatRoomBlockAddContext(ctx context.Context, id string, members []string, minute uint) error
+	ChatRoomBlockRemove(id string, members []string) error
+	ChatRoomBlockRemoveContext(ctx context.Context, id string, members []string) error
+	ChatRoomBlockGetList(id string) (ChatRoomResult, error)
+	ChatRoomBlockGetListContext(ctx context.Context, id string) (ChatRoomResult, error)
+	ChatRoomBanAdd(members []string, minute uint) error
+	ChatRoomBanAddContext(ctx context.Context, members []string, minute uint) error
+	ChatRoomBanRemove(members []string) error
+	ChatRoomBanRemoveContext(ctx context.Context, members []string) error
+	ChatRoomBanGetList() ([]ChatRoomUser, error)
+	ChatRoomBanGetListContext(ctx context.Context) ([]ChatRoomUser, error)
+	ChatRoomGagAdd(id string, members []string, minute uint) error
+	ChatRoomGagAddContext(ctx context.Context, id string, members []string, minute uint) error
+	ChatRoomGagRemove(id string, members []string) error
+	ChatRoomGagRemoveContext(ctx context.Context, id string, members []string) error
+	ChatRoomGagGetList(id string) ([]ChatRoomUser, error)
+	ChatRoomGagGetListContext(ctx context.Context, id string) ([]ChatRoomUser, error)
+	ChatRoomDemotionAdd(objectNames []string) error
+	ChatRoomDemotionAddContext(ctx context.Context, objectNames []string) error
+	ChatRoomDemotionRemove(objectNames []string) error
+	ChatRoomDemotionRemoveContext(ctx context.Context, objectNames []string) error
+	ChatRoomDemotionGetList() ([]string, error)
+	ChatRoomDemotionGetListContext(ctx context.Context) ([]string, error)
+	ChatRoomDistributionStop(id string) error
+	ChatRoomDistributionStopContext(ctx context.Context, id string) error
+	ChatRoomDistributionResume(id string) error
+	ChatRoomDistributionResumeContext(ctx context.Context, id string) error
+	ChatRoomKeepAliveAdd(id string) error
+	ChatRoomKeepAliveAddContext(ctx context.Context, id string) error
+	ChatRoomKeepAliveRemove(id string) error
+	ChatRoomKeepAliveRemoveContext(ctx context.Context, id string) error
+	ChatRoomKeepAliveGetList() ([]string, error)
+	ChatRoomKeepAliveGetListContext(ctx context.Context) ([]string, error)
+	ChatRoomWhitelistAdd(objectNames []string) error
+	ChatRoomWhitelistAddContext(ctx context.Context, objectNames []string) error
+	ChatRoomWhitelistRemove(objectNames []string) error
+	ChatRoomWhitelistRemoveContext(ctx context.Context, objectNames []string) error
+	ChatRoomWhitelistGetList() ([]string, error)
+	ChatRoomWhitelistGetListContext(ctx context.Context) ([]string, error)
+	ChatRoomUserWhitelistAdd(id string, members []string) error
+	ChatRoomUserWhitelistAddContext(ctx context.Context, id string, members []string) error
+	ChatRoomUserWhitelistRemove(id string, members []string) error
+	ChatRoomUserWhitelistRemoveContext(ctx context.Context, id string, members []string) error
+	ChatRoomUserWhitelistGetList(id string) ([]string, error)
+	ChatRoomUserWhitelistGetListContext(ctx context.Context, id string) ([]string, error)
+	ChatRoomMuteMembersAdd(id string, members []string, minute uint) error
+	ChatRoomMuteMembersAddContext(ctx context.Context, id string, members []string, minute uint) error
+	ChatRoomMuteMembersGetList(id string) ([]ChatRoomUser, error)
+	ChatRoomMuteMembersGetListContext(ctx context.Context, id string) ([]ChatRoomUser, error)
+	ChatRoomMuteMembersRemove(id string, members []string) error
+	ChatRoomMuteMembersRemoveContext(ctx context.Context, id string, members []string) error
+	ChatRoomEntrySet(chatRoomID, userID, key, value string, autoDelete bool) error
+	ChatRoomEntrySetContext(ctx context.Context, chatRoomID, userID, key, value string, autoDelete bool) error
+	ChatRoomEntryRemove(chatRoomID, userID, key string) error
+	ChatRoomEntryRemoveContext(ctx context.Context, chatRoomID, userID, key string) error
+	ChatRoomEntryQuery(chatRoomID, keys string) ([]ChatRoomAttr, error)
+	ChatRoomEntryQueryContext(ctx context.Context, chatRoomID, keys string) ([]ChatRoomAttr, error)
+	ChatRoomEntryQueryBatch(chatRoomID string, keys []string) ([]ChatRoomAttr, BatchResult)
+	ChatRoomEntryQueryBatchContext(ctx context.Context, chatRoomID string, keys []string) ([]ChatRoomAttr, BatchResult)
+	ChatRoomQuery(chatRoomID []string) ([]ChatRoom, error)
+	ChatRoomQueryContext(ctx context.Context, chatRoomID []string) ([]ChatRoom, error)
+}
+
+// MessageClient 消息接口，包括发送、撤回及历史消息
+type MessageClient interface {
+	MessageBroadcastRecall(userId string, objectName string, content BroadcastRecallContent) error
+	MessageBroadcastRecallContext(ctx context.Context, userId string, objectName string, content BroadcastRecallContent) error
+	ChatRoomRecall(userId string, targetId string, messageId string, sentTime int, options ...MsgOption) error
+	ChatRoomRecallContext(ctx context.Context, userId string, targetId string, messageId string, sentTime int, options ...MsgOption) error
+	SystemRecall(userId string, targetId string, messageId string, sentTime int, options ...MsgOption) error
+	SystemRecallContext(ctx context.Context, userId string, targetId string, messageId string, sentTime int, options ...MsgOption) error
+	PrivateSend(senderID string, targetID []string, objectName string, msg RCMsg, pushContent, pushData string, count, verifyBlacklist, isPersisted, isIncludeSender, contentAvailable int, options ...MsgOption) error
+	PrivateSendContext(ctx context.Context, senderID string, targetID []string, objectName string, msg RCMsg, pushContent, pushData string, count, verifyBlacklist, isPersisted, isIncludeSender, contentAvailable int, options ...MsgOption) error
+	PrivateSendBatch(senderID string, targetID []string, objectName string, msg RCMsg, pushContent, pushData string, count, verifyBlacklist, isPersisted, isIncludeSender, contentAvailable int, options ...MsgOption) BatchResult
+	PrivateSendBatchContext(ctx context.Context, senderID string, targetID []string, objectName string, msg RCMsg, pushContent, pushData string, count, verifyBlacklist, isPersisted, isIncludeSender, contentAvailable int, options ...MsgOption) BatchResult
+	PrivateStatusSend(senderID string, targetID []string, objectName string, msg RCMsg, verifyBlacklist int, isIncludeSender int, options ...MsgOption) error
+	PrivateStatusSendContext(ctx context.Context, senderID string, targetID []string, objectName string, msg RCMsg, verifyBlacklist int, isIncludeSender int, options ...MsgOption) error
+	PrivateRecall(senderID, targetID, uID string, sentTime int, options ...MsgOption) error
+	PrivateRecallContext(ctx context.Context, senderID, targetID, uID string, sentTime int, options ...MsgOption) error
+	PrivateSendTemplate(senderID, objectName string, template TXTMsg, content []TemplateMsgContent, options ...MsgOption) error
+	PrivateSendTemplateContext(ctx context.Context, senderID, objectName string, template TXTMsg, content []TemplateMsgContent, options ...MsgOption) error
+	GroupSend(senderID string, targetID, userID []string, objectName string, msg RCMsg, pushContent string, pushData string, isPersisted, isIncludeSender int, options ...MsgOption) error
+	GroupSendContext(ctx context.Context, senderID string, targetID, userID []string, objectName string, msg RCMsg, pushContent string, pushData string, isPersisted, isIncludeSender int, options ...MsgOption) error
+	GroupSendBatch(senderID string, targetID, userID []string, objectName string, msg RCMsg, pushContent string, pushData string, isPersisted, isIncludeSender int, options ...MsgOption) BatchResult
+	GroupSendBatchContext(ctx context.Context, senderID string, targetID, userID []string, objectName string, msg RCMsg, pushContent string, pushData string, isPersisted, isIncludeSender int, options ...MsgOption) BatchResult
+	GroupStatusSend(senderID string, toGroupIds []string, objectName string, msg RCMsg, verifyBlacklist int, isIncludeSender int, options ...MsgOption) error
+	GroupStatusSendContext(ctx context.Context, senderID string, toGroupIds []string, objectName string, msg RCMsg, verifyBlacklist int, isIncludeSender int, options ...MsgOption) error
+	GroupRecall(senderID, targetID, uID string, sentTime int, options ...MsgOption) error
+	GroupRecallContext(ctx context.Context, senderID, targetID, uID string, sentTime int, options ...MsgOption) error
+	GroupSendMention(senderID string, targetID []string, objectName string, msg MentionMsgContent, pushContent, pushData string, isPersisted, isIncludeSender, isMentioned, contentAvailable int, options ...MsgOption) error
+	GroupSendMentionContext(ctx context.Context, senderID string, targetID []string, objectName string, msg MentionMsgContent, pushContent, pushData string, isPersisted, isIncludeSender, isMentioned, contentAvailable int, options ...MsgOption) error
+	ChatRoomSend(senderID string, targetID []string, objectName string, msg RCMsg) error
+	ChatRoomSendContext(ctx context.Context, senderID string, targetID []string, objectName string, msg RCMsg) error
+	ChatRoomBroadcast(senderID, objectName string, msg RCMsg) error
+	ChatRoomBroadcastContext(ctx context.Context, senderID, objectName string, msg RCMsg) error
+	OnlineBroadcast(fromUserId string, objectName string, content string) ([]byte, error)
+	OnlineBroadcastContext(ctx context.Context, fromUserId string, objectName string, content string) ([]byte, error)
+	SystemSend(senderID string, targetID []string, objectName string, msg RCMsg, pushContent, pushData string, count, isPersisted int, options ...MsgOption) error
+	SystemSendContext(ctx context.Context, senderID string, targetID []string, objectName string, msg RCMsg, pushContent, pushData string, count, isPersisted int, options ...MsgOption) error
+	SystemSendBatch(senderID string, targetID []string, objectName string, msg RCMsg, pushContent, pushData string, count, isPersisted int, options ...MsgOption) BatchResult
+	SystemSendBatchContext(ctx context.Context, senderID string, targetID []string, objectName string, msg RCMsg, pushContent, pushData string, count, isPersisted int, options ...MsgOption) BatchResult
+	SystemBroadcast(senderID, objectName string, msg RCMsg, options ...MsgOption) error
+	SystemBroadcastContext(ctx context.Context, senderID, objectName string, msg RCMsg, options ...MsgOption) error
+	SystemSendTemplate(senderID, objectName string, template TXTMsg, content []TemplateMsgContent, options ...MsgOption) error
+	SystemSendTemplateContext(ctx context.Context, senderID, objectName string, template TXTMsg, content []TemplateMsgContent, options ...MsgOption) error
+	HistoryGet(date string) (History, error)
+	HistoryGetContext(ctx context.Context, date string) (History, error)
+	HistoryRemove(date string) error
+	HistoryRemoveContext(ctx context.Context, date string) error
+	HistoryDownload(date string, options ...HistoryOption) (*HistoryReader, error)
+	HistoryDownloadContext(ctx context.Context, date string, options ...HistoryOption) (*HistoryReader, error)
+	HistoryWalk(start, end time.Time, fn HistoryFunc, options ...HistoryOption) error
+	HistoryWalkContext(ctx context.Context, start, end time.Time, fn HistoryFunc, options ...HistoryOption) error
+}
+
+// ConversationClient 会话接口，包括会话免打扰
+type ConversationClient interface {
+	ConversationMute(conversationType ConversationType, userID, targetID string, options ...MsgOption) error
+	ConversationMuteContext(ctx context.Context, conversationType ConversationType, userID, targetID string, options ...MsgOption) error
+	ConversationUnmute(conversationType ConversationType, userID, targetID string, options ...MsgOption) error
+	ConversationUnmuteContext(ctx context.Context, conversationType ConversationType, userID, targetID string, options ...MsgOption) error
+	ConversationGet(conversationType ConversationType, userID, targetID string, options ...MsgOption) (int, error)
+	ConversationGetContext(ctx context.Context, conversationType ConversationType, userID, targetID string, options ...MsgOption) (int, error)
+}
+
+// SensitiveClient 敏感词接口
+type SensitiveClient interface {
+	SensitiveAdd(keyword, replace string, sensitiveType int) error
+	SensitiveAddContext(ctx context.Context, keyword, replace string, sensitiveType int) error
+	SensitiveGetList() (ListWordFilterResult, error)
+	SensitiveGetListContext(ctx context.Context) (ListWordFilterResult, error)
+	SensitiveRemove(keywords []string) error
+	SensitiveRemoveContext(ctx context.Context, keywords []string) error
+}
+
+// PushClient 推送接口
+type PushClient interface {
+	PushSend(sender Sender) (PushResult, error)
+	PushSendContext(ctx context.Context, sender Sender) (PushResult, error)
+}
+
+var _ Client = (*RongCloud)(nil)
@@ -0,0 +1,457 @@
+package sdk
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Config 客户端配置，可通过 LoadConfig 从 yaml、json 文件及环境变量加载
+/*
+	appKey: xxx
+	appSecret: xxx
+	timeout: 10s
+	retry:
+	  maxAttempts: 3
+	profiles:
+	  dev:
+	    uri: http://api-cn.ronghub.com
+	  prod:
+	    endpoints: [http://api-cn.ronghub.com, http://api2-cn.ronghub.com]
+	    rateLimit:
+	      failFast: true
+*/
+type Config struct {
+	AppKey              string           `yaml:"appKey" json:"appKey"`
+	AppSecret           string           `yaml:"appSecret" json:"appSecret"`
+	URI                 string           `yaml:"uri" json:"uri"`                                 // API 地址，见 WithRongCloudURI
+	SMSURI              string           `yaml:"smsUri" json:"smsUri"`                           // SMS 地址，见 WithRongCloudSMSURI
+	Endpoints           []string         `yaml:"endpoints" json:"endpoints"`                     // 多个 API 地址，见 WithEndpoints，不能与 uri 同时设置，profile 及环境变量中设置其中之一时覆盖另一项
+	Timeout             Duration         `yaml:"timeout" json:"timeout"`                         // 请求超时时间，最小单位为秒
+	KeepAlive           Duration         `yaml:"keepAlive" json:"keepAlive"`                     // 连接保活时间，最小单位为秒
+	MaxIdleConnsPerHost int              `yaml:"maxIdleConnsPerHost" json:"maxIdleConnsPerHost"` // 每个域名的最大空闲连接数
+	Retry               *RetryConfig     `yaml:"retry" json:"retry"`
+	RateLimit           *RateLimitConfig `yaml:"rateLimit" json:"rateLimit"`
+	Failover            *FailoverConfig  `yaml:"failover" json:"failover"`
+}
+
+// RetryConfig 失败重试配置，见 RetryPolicy
+type RetryConfig struct {
+	MaxAttempts    int      `yaml:"maxAttempts" json:"maxAttempts"`
+	BaseDelay      Duration `yaml:"baseDelay" json:"baseDelay"`
+	MaxDelay       Duration `yaml:"maxDelay" json:"maxDelay"`
+	Jitter         float64  `yaml:"jitter" json:"jitter"`
+	RetryableCodes []int    `yaml:"retryableCodes" json:"retryableCodes"`
+}
+
+// RateLimitConfig 客户端限流配置，设置后在默认限额基础上启用限流，见 RateLimitPolicy
+type RateLimitConfig struct {
+	// Limits 覆盖默认限额，key 为接口路径，value 为空列表时该接口不限流
+	Limits   map[string][]RateLimitItem `yaml:"limits" json:"limits"`
+	FailFast bool                       `yaml:"failFast" json:"failFast"`
+}
+
+// RateLimitItem 时间窗口内的调用次数限制，见 RateLimit
+type RateLimitItem struct {
+	Limit  int      `yaml:"limit" json:"limit"`
+	Window Duration `yaml:"window" json:"window"`
+}
+
+// FailoverConfig 多 API 地址故障切换配置，见 FailoverPolicy
+type FailoverConfig struct {
+	MaxConsecutiveFailures int      `yaml:"maxConsecutiveFailures" json:"maxConsecutiveFailures"`
+	MaxErrorRate           float64  `yaml:"maxErrorRate" json:"maxErrorRate"`
+	MinRequests            int      `yaml:"minRequests" json:"minRequests"`
+	Window                 Duration `yaml:"window" json:"window"`
+	CoolDown               Duration `yaml:"coolDown" json:"coolDown"`
+	ProbeInterval          Duration `yaml:"probeInterval" json:"probeInterval"`
+}
+
+// Duration 配置中的时长，支持 "10s"、"1m30s" 格式的字符串及以秒为单位的整数
+type Duration time.Duration
+
+// parseDuration 解析字符串或整数（秒）格式的时长
+func parseDuration(s string) (Duration, error) {
+	if n, err := strconv.Atoi(s); err == nil {
+		return Duration(time.Duration(n) * time.Second), nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	return Duration(d), nil
+}
+
+// UnmarshalYAML 实现 yaml.Unmarshaler
+func (d *Duration) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+	v, err := parseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// UnmarshalJSON 实现 json.Unmarshaler
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = unquoted
+	}
+	v, err := parseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// MarshalJSON 实现 json.Marshaler
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// MarshalYAML 实现 yaml.Marshaler
+func (d Duration) MarshalYAML() (interface{}, error) {
+	return time.Duration(d).String(), nil
+}
+
+// ConfigError 配置不合法，包含所有不合法的配置项，可通过 errors.Is(err, ErrValidation) 判断
+type ConfigError struct {
+	Source   string   // 配置来源，如文件路径
+	Problems []string // 不合法的配置项及原因
+}
+
+func (e *ConfigError) Error() string {
+	source := ""
+	if e.Source != "" {
+		source = " " + e.Source
+	}
+	return fmt.Sprintf("rongcloud: invalid config%s: %s", source, strings.Join(e.Problems, "; "))
+}
+
+// Is 判断错误类别
+func (e *ConfigError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// configFile 配置文件内容，profiles 中的配置覆盖顶层配置
+type configFile struct {
+	Config   `yaml:",inline"`
+	Profiles map[string]interface{} `yaml:"profiles"`
+}
+
+// jsonConfigFile json 格式的配置文件
+type jsonConfigFile struct {
+	Config
+	Profiles map[string]json.RawMessage `json:"profiles"`
+}
+
+// LoadConfig 加载配置并校验
+// file 为 yaml（.yaml、.yml）或 json（.json）格式的配置文件，为空时读取环境变量 RC_CONFIG，都为空时只使用环境变量；
+// profile 为使用的配置名称，为空时读取环境变量 RC_PROFILE，都为空时只使用顶层配置。
+// 环境变量优先于配置文件：RC_APP_KEY、RC_APP_SECRET、RC_API_URI、RC_SMS_URI、RC_ENDPOINTS（逗号分隔）、
+// RC_TIMEOUT、RC_KEEPALIVE、RC_MAX_IDLE_CONNS_PER_HOST、RC_RETRY_MAX_ATTEMPTS
+func LoadConfig(file, profile string) (*Config, error) {
+	if file == "" {
+		file = os.Getenv("RC_CONFIG")
+	}
+	if profile == "" {
+		profile = os.Getenv("RC_PROFILE")
+	}
+
+	cfg := &Config{}
+	if file != "" {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		if err := decodeConfig(file, data, profile, cfg); err != nil {
+			return nil, err
+		}
+	} else if profile != "" {
+		return nil, &ConfigError{Problems: []string{fmt.Sprintf("profile %q requires a config file", profile)}}
+	}
+
+	var problems []string
+	cfg.loadEnv(&problems)
+	problems = append(problems, cfg.validate()...)
+	if len(problems) > 0 {
+		return nil, &ConfigError{Source: file, Problems: problems}
+	}
+	return cfg, nil
+}
+
+// decodeConfig 按文件后缀解析配置，不允许未知的配置项，并合并 profile 的配置
+func decodeConfig(file string, data []byte, profile string, cfg *Config) error {
+	var profiles []string
+	var merge func(name string) error
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".yaml", ".yml":
+		var f configFile
+		if err := yaml.UnmarshalStrict(data, &f); err != nil {
+			return fmt.Errorf("rongcloud: parse config %s: %w", file, err)
+		}
+		*cfg = f.Config
+		for name := range f.Profiles {
+			profiles = append(profiles, name)
+		}
+		merge = func(name string) error {
+			raw, err := yaml.Marshal(f.Profiles[name])
+			if err != nil {
+				return err
+			}
+			return yaml.UnmarshalStrict(raw, cfg)
+		}
+	case ".json":
+		var f jsonConfigFile
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&f); err != nil {
+			return fmt.Errorf("rongcloud: parse config %s: %w", file, err)
+		}
+		*cfg = f.Config
+		for name := range f.Profiles {
+			profiles = append(profiles, name)
+		}
+		merge = func(name string) error {
+			dec := json.NewDecoder(bytes.NewReader(f.Profiles[name]))
+			dec.DisallowUnknownFields()
+			return dec.Decode(cfg)
+		}
+	default:
+		return fmt.Errorf("rongcloud: unsupported config format %s, use .yaml, .yml or .json", file)
+	}
+
+	if profile == "" {
+		return nil
+	}
+	sort.Strings(profiles)
+	found := false
+	for _, name := range profiles {
+		found = found || name == profile
+	}
+	if !found {
+		return &ConfigError{Source: file, Problems: []string{
+			fmt.Sprintf("profile %q not found, available profiles: %s", profile, strings.Join(profiles, ", ")),
+		}}
+	}
+	// uri 与 endpoints 互斥，profile 设置其中之一时覆盖顶层配置中的另一项
+	uri, endpoints := cfg.URI, cfg.Endpoints
+	cfg.URI, cfg.Endpoints = "", nil
+	if err := merge(profile); err != nil {
+		return fmt.Errorf("rongcloud: parse config %s profile %s: %w", file, profile, err)
+	}
+	if cfg.URI == "" && len(cfg.Endpoints) == 0 {
+		cfg.URI, cfg.Endpoints = uri, endpoints
+	}
+	return nil
+}
+
+// loadEnv 使用环境变量覆盖配置，格式错误的环境变量记录到 problems
+func (c *Config) loadEnv(problems *[]string) {
+	strs := map[string]*string{
+		"RC_APP_KEY":    &c.AppKey,
+		"RC_APP_SECRET": &c.AppSecret,
+		"RC_API_URI":    &c.URI,
+		"RC_SMS_URI":    &c.SMSURI,
+	}
+	for name, p := range strs {
+		if v := os.Getenv(name); v != "" {
+			*p = v
+		}
+	}
+	// 与 profile 相同，只设置 RC_API_URI、RC_ENDPOINTS 之一时覆盖配置文件中的另一项
+	uri, endpoints := os.Getenv("RC_API_URI"), os.Getenv("RC_ENDPOINTS")
+	if endpoints != "" {
+		c.Endpoints = strings.Split(endpoints, ",")
+		if uri == "" {
+			c.URI = ""
+		}
+	} else if uri != "" {
+		c.Endpoints = nil
+	}
+	durations := map[string]*Duration{
+		"RC_TIMEOUT":   &c.Timeout,
+		"RC_KEEPALIVE": &c.KeepAlive,
+	}
+	for name, p := range durations {
+		if v := os.Getenv(name); v != "" {
+			d, err := parseDuration(v)
+			if err != nil {
+				*problems = append(*problems, fmt.Sprintf("%s: %v", name, err))
+				continue
+			}
+			*p = d
+		}
+	}
+	if v := os.Getenv("RC_MAX_IDLE_CONNS_PER_HOST"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			*problems = append(*problems, fmt.Sprintf("RC_MAX_IDLE_CONNS_PER_HOST: invalid integer %q", v))
+		}
+		c.MaxIdleConnsPerHost = n
+	}
+	if v := os.Getenv("RC_RETRY_MAX_ATTEMPTS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			*problems = append(*problems, fmt.Sprintf("RC_RETRY_MAX_ATTEMPTS: invalid integer %q", v))
+		}
+		if c.Retry == nil {
+			c.Retry = &RetryConfig{}
+		}
+		c.Retry.MaxAttempts = n
+	}
+}
+
+// Validate 校验配置，返回 *ConfigError
+func (c *Config) Validate() error {
+	if problems := c.validate(); len(problems) > 0 {
+		return &ConfigError{Problems: problems}
+	}
+	return nil
+}
+
+func (c *Config) validate() []string {
+	var problems []string
+	check := func(ok bool, format string, args ...interface{}) {
+		if !ok {
+			problems = append(problems, fmt.Sprintf(format, args...))
+		}
+	}
+	checkURI := func(name, uri string) {
+		u, err := url.Parse(uri)
+		check(err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "",
+			"%s: %q is not a valid http(s) url", name, uri)
+	}
+	checkSeconds := func(name string, d Duration) {
+		check(d >= 0 && time.Duration(d)%time.Second == 0, "%s: must be a non-negative whole number of seconds, got %s", name, time.Duration(d))
+	}
+	checkDuration := func(name string, d Duration) {
+		check(d >= 0, "%s: must not be negative", name)
+	}
+
+	check(c.AppKey != "", "appKey is required")
+	check(c.AppSecret != "", "appSecret is required")
+	if c.URI != "" {
+		checkURI("uri", c.URI)
+	}
+	if c.SMSURI != "" {
+		checkURI("smsUri", c.SMSURI)
+	}
+	check(c.URI == "" || len(c.Endpoints) == 0, "uri and endpoints are mutually exclusive")
+	for i, uri := range c.Endpoints {
+		checkURI(fmt.Sprintf("endpoints[%d]", i), uri)
+	}
+	checkSeconds("timeout", c.Timeout)
+	checkSeconds("keepAlive", c.KeepAlive)
+	check(c.MaxIdleConnsPerHost >= 0, "maxIdleConnsPerHost: must not be negative")
+
+	if r := c.Retry; r != nil {
+		check(r.MaxAttempts >= 0, "retry.maxAttempts: must not be negative")
+		checkDuration("retry.baseDelay", r.BaseDelay)
+		checkDuration("retry.maxDelay", r.MaxDelay)
+		check(r.MaxDelay == 0 || r.MaxDelay >= r.BaseDelay, "retry.maxDelay: must not be less than retry.baseDelay")
+		check(r.Jitter >= 0 && r.Jitter <= 1, "retry.jitter: must be between 0 and 1")
+	}
+	if r := c.RateLimit; r != nil {
+		paths := make([]string, 0, len(r.Limits))
+		for path := range r.Limits {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+		for _, path := range paths {
+			check(strings.HasPrefix(path, "/"), "rateLimit.limits: path %q must start with /", path)
+			for i, limit := range r.Limits[path] {
+				check(limit.Limit > 0, "rateLimit.limits[%s][%d].limit: must be positive", path, i)
+				check(limit.Window > 0, "rateLimit.limits[%s][%d].window: must be positive", path, i)
+			}
+		}
+	}
+	if f := c.Failover; f != nil {
+		check(f.MaxConsecutiveFailures >= 0, "failover.maxConsecutiveFailures: must not be negative")
+		check(f.MaxErrorRate >= 0 && f.MaxErrorRate <= 1, "failover.maxErrorRate: must be between 0 and 1")
+		check(f.MinRequests >= 0, "failover.minRequests: must not be negative")
+		checkDuration("failover.window", f.Window)
+		checkDuration("failover.coolDown", f.CoolDown)
+		checkDuration("failover.probeInterval", f.ProbeInterval)
+	}
+	return problems
+}
+
+// Options 将配置转换为创建 RongCloud 的选项，未设置的配置项使用默认值
+func (c *Config) Options() []RongCloudOption {
+	var options []RongCloudOption
+	if c.URI != "" {
+		options = append(options, WithRongCloudURI(c.URI))
+	}
+	if c.SMSURI != "" {
+		options = append(options, WithRongCloudSMSURI(c.SMSURI))
+	}
+	if len(c.Endpoints) > 0 {
+		options = append(options, WithEndpoints(c.Endpoints...))
+	}
+	// WithTimeout、WithKeepAlive 的单位为秒
+	if c.Timeout > 0 {
+		options = append(options, WithTimeout(time.Duration(c.Timeout)/time.Second))
+	}
+	if c.KeepAlive > 0 {
+		options = append(options, WithKeepAlive(time.Duration(c.KeepAlive)/time.Second))
+	}
+	if c.MaxIdleConnsPerHost > 0 {
+		options = append(options, WithMaxIdleConnsPerHost(c.MaxIdleConnsPerHost))
+	}
+	if r := c.Retry; r != nil {
+		options = append(options, WithRetryPolicy(RetryPolicy{
+			MaxAttempts:    r.MaxAttempts,
+			BaseDelay:      time.Duration(r.BaseDelay),
+			MaxDelay:       time.Duration(r.MaxDelay),
+			Jitter:         r.Jitter,
+			RetryableCodes: r.RetryableCodes,
+		}))
+	}
+	if r := c.RateLimit; r != nil {
+		// 未配置的接口由 NewRateLimiter 使用默认限额
+		limits := map[string][]RateLimit{}
+		for path, items := range r.Limits {
+			limits[path] = nil
+			for _, item := range items {
+				limits[path] = append(limits[path], RateLimit{Limit: item.Limit, Window: time.Duration(item.Window)})
+			}
+		}
+		options = append(options, WithRateLimiter(NewRateLimiter(RateLimitPolicy{Limits: limits, FailFast: r.FailFast})))
+	}
+	if f := c.Failover; f != nil {
+		options = append(options, WithFailoverPolicy(FailoverPolicy{
+			MaxConsecutiveFailures: f.MaxConsecutiveFailures,
+			MaxErrorRate:           f.MaxErrorRate,
+			MinRequests:            f.MinRequests,
+			Window:                 time.Duration(f.Window),
+			CoolDown:               time.Duration(f.CoolDown),
+			ProbeInterval:          time.Duration(f.ProbeInterval),
+		}))
+	}
+	return options
+}
+
+// NewFromConfig 校验配置并创建 RongCloud 对象，options 在配置之后生效
+func NewFromConfig(c *Config, options ...RongCloudOption) (*RongCloud, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return New(c.AppKey, c.AppSecret, append(c.Options(), options...)...), nil
+}
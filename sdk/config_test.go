@@ -0,0 +1,255 @@
+package sdk
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// tempDir 创建临时目录，返回的函数用于删除该目录
+func tempDir(t *testing.T) (string, func()) {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "rongcloud-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir, func() { _ = os.RemoveAll(dir) }
+}
+
+// writeConfig 在 dir 中写入临时配置文件
+func writeConfig(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	file := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(file, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+// setenv 设置环境变量，返回的函数用于恢复原值
+func setenv(t *testing.T, key, value string) func() {
+	t.Helper()
+	old, ok := os.LookupEnv(key)
+	os.Setenv(key, value)
+	return func() {
+		if ok {
+			os.Setenv(key, old)
+		} else {
+			os.Unsetenv(key)
+		}
+	}
+}
+
+const yamlConfig = `
+appKey: key
+appSecret: secret
+timeout: 10s
+keepAlive: 30
+retry:
+  maxAttempts: 3
+  baseDelay: 100ms
+rateLimit:
+  limits:
+    /push: []
+    /message/private/publish:
+      - limit: 10
+        window: 1s
+profiles:
+  dev:
+    uri: http://localhost:8080
+  prod:
+    endpoints: [https://api-cn.ronghub.com, https://api2-cn.ronghub.com]
+    maxIdleConnsPerHost: 200
+    retry:
+      maxAttempts: 5
+    rateLimit:
+      failFast: true
+`
+
+func TestLoadConfig_YAML(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	file := writeConfig(t, dir, "rongcloud.yaml", yamlConfig)
+
+	cfg, err := LoadConfig(file, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.AppKey != "key" || cfg.AppSecret != "secret" || cfg.URI != "" {
+		t.Errorf("cfg = %+v", cfg)
+	}
+	if time.Duration(cfg.Timeout) != 10*time.Second || time.Duration(cfg.KeepAlive) != 30*time.Second {
+		t.Errorf("timeout = %v, keepAlive = %v", time.Duration(cfg.Timeout), time.Duration(cfg.KeepAlive))
+	}
+	if limits, ok := cfg.RateLimit.Limits["/push"]; !ok || len(limits) != 0 {
+		t.Errorf("limits = %+v", cfg.RateLimit.Limits)
+	}
+
+	cfg, err = LoadConfig(file, "prod")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// profile 覆盖顶层配置，未设置的配置项保持不变
+	if len(cfg.Endpoints) != 2 || cfg.MaxIdleConnsPerHost != 200 || time.Duration(cfg.Timeout) != 10*time.Second {
+		t.Errorf("cfg = %+v", cfg)
+	}
+	if cfg.Retry.MaxAttempts != 5 || time.Duration(cfg.Retry.BaseDelay) != 100*time.Millisecond {
+		t.Errorf("retry = %+v", cfg.Retry)
+	}
+	if !cfg.RateLimit.FailFast || len(cfg.RateLimit.Limits["/message/private/publish"]) != 1 {
+		t.Errorf("rateLimit = %+v", cfg.RateLimit)
+	}
+
+	defer setenv(t, "RC_PROFILE", "dev")()
+	if cfg, err = LoadConfig(file, ""); err != nil || cfg.URI != "http://localhost:8080" {
+		t.Errorf("RC_PROFILE: %+v, %v", cfg, err)
+	}
+}
+
+func TestLoadConfig_JSON(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	file := writeConfig(t, dir, "rongcloud.json", `{
+		"appKey": "key",
+		"appSecret": "secret",
+		"timeout": 5,
+		"failover": {"maxConsecutiveFailures": 3, "coolDown": "1m"},
+		"profiles": {"staging": {"uri": "https://staging.example.com", "timeout": "20s"}}
+	}`)
+
+	cfg, err := LoadConfig(file, "staging")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.URI != "https://staging.example.com" || time.Duration(cfg.Timeout) != 20*time.Second {
+		t.Errorf("cfg = %+v", cfg)
+	}
+	if cfg.Failover.MaxConsecutiveFailures != 3 || time.Duration(cfg.Failover.CoolDown) != time.Minute {
+		t.Errorf("failover = %+v", cfg.Failover)
+	}
+
+	rc, err := NewFromConfig(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rc.Close()
+	if rc.AppKey() != "key" || rc.rongCloudURI != cfg.URI || rc.timeout != 20 || rc.failoverPolicy.MaxConsecutiveFailures != 3 {
+		t.Errorf("rc = %+v", rc.rongCloudExtra)
+	}
+}
+
+func TestLoadConfig_Env(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	file := writeConfig(t, dir, "rongcloud.yml", "appKey: key\nappSecret: secret\n")
+	defer setenv(t, "RC_APP_SECRET", "env-secret")()
+	defer setenv(t, "RC_ENDPOINTS", "https://a.example.com,https://b.example.com")()
+	defer setenv(t, "RC_TIMEOUT", "15s")()
+	defer setenv(t, "RC_RETRY_MAX_ATTEMPTS", "2")()
+
+	cfg, err := LoadConfig(file, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.AppKey != "key" || cfg.AppSecret != "env-secret" || len(cfg.Endpoints) != 2 ||
+		time.Duration(cfg.Timeout) != 15*time.Second || cfg.Retry.MaxAttempts != 2 {
+		t.Errorf("cfg = %+v", cfg)
+	}
+
+	// 不使用配置文件
+	defer setenv(t, "RC_APP_KEY", "env-key")()
+	if cfg, err = LoadConfig("", ""); err != nil || cfg.AppKey != "env-key" {
+		t.Errorf("env only: %+v, %v", cfg, err)
+	}
+}
+
+func TestLoadConfig_OverrideURI(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	file := writeConfig(t, dir, "rongcloud.yml", `
+appKey: key
+appSecret: secret
+endpoints: [https://a.example.com, https://b.example.com]
+profiles:
+  dev:
+    uri: http://localhost:8080
+  prod:
+    timeout: 5s
+`)
+	// profile 中的 uri 覆盖顶层的 endpoints
+	cfg, err := LoadConfig(file, "dev")
+	if err != nil || cfg.URI != "http://localhost:8080" || len(cfg.Endpoints) != 0 {
+		t.Fatalf("dev: %+v, %v", cfg, err)
+	}
+	// 未设置时继承顶层配置
+	if cfg, err = LoadConfig(file, "prod"); err != nil || cfg.URI != "" || len(cfg.Endpoints) != 2 {
+		t.Fatalf("prod: %+v, %v", cfg, err)
+	}
+	defer setenv(t, "RC_API_URI", "http://localhost:9090")()
+	if cfg, err = LoadConfig(file, "prod"); err != nil || cfg.URI != "http://localhost:9090" || len(cfg.Endpoints) != 0 {
+		t.Errorf("env: %+v, %v", cfg, err)
+	}
+
+	// 同一层中同时设置仍然报错
+	both := writeConfig(t, dir, "c.yml", "appKey: key\nappSecret: secret\nuri: http://a.example.com\nendpoints: [http://b.example.com]\n")
+	os.Unsetenv("RC_API_URI")
+	if _, err = LoadConfig(both, ""); err == nil || !strings.Contains(err.Error(), "mutually exclusive") {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestLoadConfig_Errors(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	for _, tc := range []struct {
+		name, content, profile string
+		want                   []string
+	}{
+		{
+			name:    "c.yaml",
+			content: "appSecret: secret\nuri: api.example.com\ntimeout: 1500ms\nretry:\n  jitter: 2\n",
+			want:    []string{"appKey is required", `uri: "api.example.com"`, "timeout: must be a non-negative whole number of seconds", "retry.jitter"},
+		},
+		{
+			name:    "c.yaml",
+			content: "appKey: key\nappSecret: secret\nrateLimit:\n  limits:\n    push:\n      - limit: 0\n        window: 1s\n",
+			want:    []string{`path "push" must start with /`, "rateLimit.limits[push][0].limit"},
+		},
+		{
+			name:    "c.yaml",
+			content: "appKey: key\nappSecret: secret\nprofiles:\n  dev: {}\n  prod: {}\n",
+			profile: "staging",
+			want:    []string{`profile "staging" not found, available profiles: dev, prod`},
+		},
+		{name: "c.yaml", content: "appKey: key\ntimeOut: 10s\n", want: []string{"timeOut"}},
+		{name: "c.json", content: `{"appKey": "key", "timeout": "10x"}`, want: []string{`invalid duration "10x"`}},
+		{name: "c.toml", content: "", want: []string{"unsupported config format"}},
+	} {
+		_, err := LoadConfig(writeConfig(t, dir, tc.name, tc.content), tc.profile)
+		if err == nil {
+			t.Errorf("%s: expected error", tc.content)
+			continue
+		}
+		for _, want := range tc.want {
+			if !strings.Contains(err.Error(), want) {
+				t.Errorf("error %q does not contain %q", err, want)
+			}
+		}
+	}
+
+	_, err := LoadConfig(writeConfig(t, dir, "c.yaml", "appKey: key\n"), "")
+	var configErr *ConfigError
+	if !errors.As(err, &configErr) || !errors.Is(err, ErrValidation) || len(configErr.Problems) != 1 {
+		t.Errorf("err = %#v", err)
+	}
+
+	defer setenv(t, "RC_MAX_IDLE_CONNS_PER_HOST", "many")()
+	if _, err := LoadConfig(writeConfig(t, dir, "c.yaml", "appKey: key\nappSecret: secret\n"), ""); err == nil ||
+		!strings.Contains(err.Error(), `RC_MAX_IDLE_CONNS_PER_HOST: invalid integer "many"`) {
+		t.Errorf("err = %v", err)
+	}
+}
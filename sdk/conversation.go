@@ -3,11 +3,9 @@
 package sdk
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"time"
-
-	"github.com/astaxie/beego/httplib"
 )
 
 // ConversationType 会话类型
@@ -36,6 +34,12 @@ const (
  */
 func (rc *RongCloud) ConversationMute(conversationType ConversationType, userID, targetID string,
 	options ...MsgOption) error {
+	return rc.ConversationMuteContext(context.Background(), conversationType, userID, targetID, options...)
+}
+
+// ConversationMuteContext 同 ConversationMute，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) ConversationMuteContext(ctx context.Context, conversationType ConversationType, userID, targetID string,
+	options ...MsgOption) error {
 
 	if conversationType == 0 {
 		return RCErrorNew(1002, "Paramer 'userId' is required")
@@ -51,9 +55,7 @@ func (rc *RongCloud) ConversationMute(conversationType ConversationType, userID,
 
 	extraOptins := modifyMsgOptions(options)
 
-	req := httplib.Post(rc.rongCloudURI + "/conversation/notification/set." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/conversation/notification/set")
 	req.Param("requestId", userID)
 	req.Param("conversationType", fmt.Sprintf("%v", conversationType))
 	req.Param("targetId", targetID)
@@ -63,7 +65,7 @@ func (rc *RongCloud) ConversationMute(conversationType ConversationType, userID,
 		req.Param("busChannel", extraOptins.busChannel)
 	}
 
-	_, err := rc.do(req)
+	_, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 	}
@@ -79,6 +81,12 @@ func (rc *RongCloud) ConversationMute(conversationType ConversationType, userID,
 *@return error
  */
 func (rc *RongCloud) ConversationUnmute(conversationType ConversationType, userID, targetID string,
+	options ...MsgOption) error {
+	return rc.ConversationUnmuteContext(context.Background(), conversationType, userID, targetID, options...)
+}
+
+// ConversationUnmuteContext 同 ConversationUnmute，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) ConversationUnmuteContext(ctx context.Context, conversationType ConversationType, userID, targetID string,
 	options ...MsgOption) error {
 	if conversationType == 0 {
 		return RCErrorNew(1002, "Paramer 'conversationType' is required")
@@ -94,9 +102,7 @@ func (rc *RongCloud) ConversationUnmute(conversationType ConversationType, userI
 
 	extraOptins := modifyMsgOptions(options)
 
-	req := httplib.Post(rc.rongCloudURI + "/conversation/notification/set." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/conversation/notification/set")
 	req.Param("requestId", userID)
 	req.Param("conversationType", fmt.Sprintf("%v", conversationType))
 	req.Param("targetId", targetID)
@@ -106,7 +112,7 @@ func (rc *RongCloud) ConversationUnmute(conversationType ConversationType, userI
 		req.Param("busChannel", extraOptins.busChannel)
 	}
 
-	_, err := rc.do(req)
+	_, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 	}
@@ -122,6 +128,12 @@ func (rc *RongCloud) ConversationUnmute(conversationType ConversationType, userI
 *@return int error
  */
 func (rc *RongCloud) ConversationGet(conversationType ConversationType, userID, targetID string,
+	options ...MsgOption) (int, error) {
+	return rc.ConversationGetContext(context.Background(), conversationType, userID, targetID, options...)
+}
+
+// ConversationGetContext 同 ConversationGet，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) ConversationGetContext(ctx context.Context, conversationType ConversationType, userID, targetID string,
 	options ...MsgOption) (int, error) {
 	if conversationType == 0 {
 		return -1, RCErrorNew(1002, "Paramer 'conversationType' is required")
@@ -137,9 +149,7 @@ func (rc *RongCloud) ConversationGet(conversationType ConversationType, userID,
 
 	extraOptins := modifyMsgOptions(options)
 
-	req := httplib.Post(rc.rongCloudURI + "/conversation/notification/get." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/conversation/notification/get")
 	req.Param("requestId", userID)
 	req.Param("conversationType", fmt.Sprintf("%v", conversationType))
 	req.Param("targetId", targetID)
@@ -148,14 +158,7 @@ func (rc *RongCloud) ConversationGet(conversationType ConversationType, userID,
 		req.Param("busChannel", extraOptins.busChannel)
 	}
 
-	response, err := req.Response()
-	if err != nil {
-		return -1, err
-	}
-
-	rc.checkStatusCode(response)
-
-	rep, err := req.Bytes()
+	rep, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 		return -1, err
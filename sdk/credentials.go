@@ -0,0 +1,128 @@
+package sdk
+
+import (
+	"context"
+	"time"
+)
+
+// DEFAULT_SECRET_GRACE_PERIOD 轮换 appSecret 后旧 appSecret 仍可用于校验回调签名的时间，5 分钟
+const DEFAULT_SECRET_GRACE_PERIOD = 5 * time.Minute
+
+// Credentials 应用凭证
+type Credentials struct {
+	AppKey    string
+	AppSecret string
+}
+
+// CredentialsProvider 提供应用凭证，用于运行时轮换 appSecret，如从配置中心或密钥管理服务读取
+type CredentialsProvider interface {
+	Credentials(ctx context.Context) (Credentials, error)
+}
+
+// CredentialsProviderFunc 函数形式的 CredentialsProvider
+type CredentialsProviderFunc func(ctx context.Context) (Credentials, error)
+
+// Credentials 实现 CredentialsProvider
+func (f CredentialsProviderFunc) Credentials(ctx context.Context) (Credentials, error) {
+	return f(ctx)
+}
+
+// CredentialsNotifier 可由 CredentialsProvider 实现，凭证变化时向 channel 发送通知，WatchCredentials 收到后立即刷新
+type CredentialsNotifier interface {
+	Changed() <-chan struct{}
+}
+
+// credentialState 当前凭证及轮换前的 appSecret，整体替换以保证请求读取到一致的 appKey、appSecret
+type credentialState struct {
+	Credentials
+	previousSecret  string
+	previousExpires time.Time
+}
+
+// credentials 获取当前凭证
+func (rc *RongCloud) credentials() *credentialState {
+	return rc.creds.Load().(*credentialState)
+}
+
+// SetCredentials 替换 appKey、appSecret，之后发起的请求使用新凭证，已发出的请求不受影响
+// 替换 appSecret 后，旧 appSecret 在 WithSecretGracePeriod 设置的时间内仍可通过 VerifySignature 的校验
+func (rc *RongCloud) SetCredentials(appKey, appSecret string) error {
+	if appKey == "" {
+		return RCErrorNew(1002, "Paramer 'appKey' is required")
+	}
+	if appSecret == "" {
+		return RCErrorNew(1002, "Paramer 'appSecret' is required")
+	}
+
+	rc.credsLock.Lock()
+	defer rc.credsLock.Unlock()
+	old := rc.credentials()
+	next := &credentialState{
+		Credentials:     Credentials{AppKey: appKey, AppSecret: appSecret},
+		previousSecret:  old.previousSecret,
+		previousExpires: old.previousExpires,
+	}
+	if old.AppSecret != appSecret && rc.secretGracePeriod > 0 {
+		next.previousSecret = old.AppSecret
+		next.previousExpires = time.Now().Add(rc.secretGracePeriod)
+	}
+	rc.creds.Store(next)
+	return nil
+}
+
+// WatchCredentials 从 provider 加载凭证，之后每隔 interval 及收到 CredentialsNotifier 通知时刷新，直到 ctx 结束或 Close
+// 首次加载失败时返回 error；之后刷新失败时继续使用当前凭证，错误交给 onError 处理，onError 可为 nil。interval 为 0 时不轮询
+func (rc *RongCloud) WatchCredentials(ctx context.Context, provider CredentialsProvider, interval time.Duration, onError func(error)) error {
+	if err := rc.refreshCredentials(ctx, provider); err != nil {
+		return err
+	}
+
+	go rc.watchCredentials(ctx, provider, interval, onError)
+	return nil
+}
+
+// watchCredentials 后台刷新凭证
+func (rc *RongCloud) watchCredentials(ctx context.Context, provider CredentialsProvider, interval time.Duration, onError func(error)) {
+	var tick <-chan time.Time
+	if interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+	var changed <-chan struct{}
+	if notifier, ok := provider.(CredentialsNotifier); ok {
+		changed = notifier.Changed()
+	}
+	if tick == nil && changed == nil {
+		return
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-rc.closed:
+			return
+		case <-tick:
+		case _, ok := <-changed:
+			if !ok {
+				changed = nil
+				if tick == nil {
+					return
+				}
+				continue
+			}
+		}
+		if err := rc.refreshCredentials(ctx, provider); err != nil && onError != nil {
+			onError(err)
+		}
+	}
+}
+
+// refreshCredentials 从 provider 加载凭证并替换
+func (rc *RongCloud) refreshCredentials(ctx context.Context, provider CredentialsProvider) error {
+	creds, err := provider.Credentials(ctx)
+	if err != nil {
+		return err
+	}
+	return rc.SetCredentials(creds.AppKey, creds.AppSecret)
+}
@@ -0,0 +1,164 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+// signedHeaders 记录请求头中的 appKey 及签名
+func signedHeaders(headers chan<- http.Header) HTTPExecutor {
+	return executorFunc(func(req *http.Request) (*http.Response, error) {
+		headers <- req.Header.Clone()
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Body:       ioutil.NopCloser(bytes.NewBufferString(`{"code":200}`)),
+		}, nil
+	})
+}
+
+func TestRongCloud_SetCredentials(t *testing.T) {
+	headers := make(chan http.Header, 1)
+	rc := New("key1", "secret1", WithRongCloudURI("http://api.test.com"), WithHTTPExecutor(signedHeaders(headers)))
+	defer rc.Close()
+
+	if err := rc.SetCredentials("key2", ""); err == nil {
+		t.Error("expected error for empty appSecret")
+	}
+	if err := rc.SetCredentials("key2", "secret2"); err != nil {
+		t.Fatal(err)
+	}
+	if err := rc.UserUpdate("u01", "name", ""); err != nil {
+		t.Fatal(err)
+	}
+	h := <-headers
+	if h.Get("App-Key") != "key2" || h.Get("Signature") != sign("secret2", h.Get("Nonce"), h.Get("Timestamp")) {
+		t.Errorf("headers = %v", h)
+	}
+	if rc.AppKey() != "key2" {
+		t.Errorf("AppKey = %s", rc.AppKey())
+	}
+}
+
+func TestRongCloud_VerifySignatureGracePeriod(t *testing.T) {
+	rc := New("key", "old", WithSecretGracePeriod(50*time.Millisecond))
+	defer rc.Close()
+	if err := rc.SetCredentials("key", "new"); err != nil {
+		t.Fatal(err)
+	}
+
+	if !rc.VerifySignature("1", "2", sign("new", "1", "2")) {
+		t.Error("new secret should verify")
+	}
+	if !rc.VerifySignature("1", "2", sign("old", "1", "2")) {
+		t.Error("old secret should verify during grace period")
+	}
+	if rc.VerifySignature("1", "2", sign("other", "1", "2")) {
+		t.Error("unknown secret should not verify")
+	}
+	time.Sleep(60 * time.Millisecond)
+	if rc.VerifySignature("1", "2", sign("old", "1", "2")) {
+		t.Error("old secret should not verify after grace period")
+	}
+
+	rc = New("key", "old", WithSecretGracePeriod(0))
+	defer rc.Close()
+	rc.SetCredentials("key", "new")
+	if rc.VerifySignature("1", "2", sign("old", "1", "2")) {
+		t.Error("old secret should not verify without grace period")
+	}
+}
+
+// notifyProvider 通过 channel 通知凭证变化
+type notifyProvider struct {
+	lock    sync.Mutex
+	creds   Credentials
+	err     error
+	changed chan struct{}
+}
+
+func (p *notifyProvider) Credentials(ctx context.Context) (Credentials, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.creds, p.err
+}
+
+func (p *notifyProvider) Changed() <-chan struct{} {
+	return p.changed
+}
+
+func (p *notifyProvider) set(creds Credentials, err error) {
+	p.lock.Lock()
+	p.creds, p.err = creds, err
+	p.lock.Unlock()
+	p.changed <- struct{}{}
+}
+
+func TestRongCloud_WatchCredentials(t *testing.T) {
+	rc := New("", "")
+	defer rc.Close()
+
+	provider := &notifyProvider{changed: make(chan struct{})}
+	if err := rc.WatchCredentials(context.Background(), provider, 0, nil); err == nil {
+		t.Error("expected error for empty credentials")
+	}
+
+	provider.creds = Credentials{AppKey: "key", AppSecret: "secret1"}
+	errs := make(chan error, 1)
+	if err := rc.WatchCredentials(context.Background(), provider, 0, func(err error) { errs <- err }); err != nil {
+		t.Fatal(err)
+	}
+	if rc.AppKey() != "key" || !rc.VerifySignature("1", "2", sign("secret1", "1", "2")) {
+		t.Fatal("credentials were not loaded")
+	}
+
+	provider.set(Credentials{}, errors.New("unavailable"))
+	if err := <-errs; err == nil || err.Error() != "unavailable" {
+		t.Errorf("onError = %v", err)
+	}
+	// 刷新失败时继续使用当前凭证
+	if rc.AppKey() != "key" {
+		t.Errorf("AppKey = %s", rc.AppKey())
+	}
+
+	provider.set(Credentials{AppKey: "key", AppSecret: "secret2"}, nil)
+	deadline := time.Now().Add(time.Second)
+	for rc.credentials().AppSecret != "secret2" {
+		if time.Now().After(deadline) {
+			t.Fatal("credentials were not refreshed")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestRongCloud_WatchCredentialsInterval(t *testing.T) {
+	rc := New("key", "secret")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var lock sync.Mutex
+	calls := 0
+	provider := CredentialsProviderFunc(func(ctx context.Context) (Credentials, error) {
+		lock.Lock()
+		defer lock.Unlock()
+		calls++
+		return Credentials{AppKey: "key", AppSecret: "secret"}, nil
+	})
+	if err := rc.WatchCredentials(ctx, provider, 5*time.Millisecond, nil); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(30 * time.Millisecond)
+	rc.Close()
+	lock.Lock()
+	n := calls
+	lock.Unlock()
+	if n < 2 {
+		t.Errorf("provider called %d times, want at least 2", n)
+	}
+}
@@ -0,0 +1,417 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// DEFAULT_DISPATCH_QUEUE_SIZE 异步发送队列默认长度
+	DEFAULT_DISPATCH_QUEUE_SIZE = 1000
+	// DEFAULT_DISPATCH_WORKERS 异步发送默认并发数
+	DEFAULT_DISPATCH_WORKERS = 4
+	// DEFAULT_DISPATCH_MAX_ATTEMPTS 异步发送默认最多发送次数（包含第一次发送）
+	DEFAULT_DISPATCH_MAX_ATTEMPTS = 3
+)
+
+var (
+	// ErrQueueFull 异步发送队列已满
+	ErrQueueFull = errors.New("rongcloud: send queue is full")
+	// ErrDispatcherClosed Dispatcher 已关闭，不再接受新消息
+	ErrDispatcherClosed = errors.New("rongcloud: dispatcher is closed")
+)
+
+// SendKind 异步发送的消息类型
+type SendKind int
+
+const (
+	// SEND_PRIVATE 单聊消息，见 PrivateSend
+	SEND_PRIVATE SendKind = iota + 1
+	// SEND_GROUP 群组消息，见 GroupSend
+	SEND_GROUP
+	// SEND_SYSTEM 系统消息，见 SystemSend
+	SEND_SYSTEM
+	// SEND_CHATROOM 聊天室消息，见 ChatRoomSend
+	SEND_CHATROOM
+)
+
+// SendMessage 异步发送的消息，字段含义同 PrivateSend、GroupSend、SystemSend、ChatRoomSend 的参数，各类型未使用的字段会被忽略
+type SendMessage struct {
+	ID               string // 业务 ID，用于在回调中识别消息，可为空
+	Kind             SendKind
+	SenderID         string
+	TargetID         []string
+	UserID           []string // 群组定向消息的接收用户，仅 SEND_GROUP 使用
+	ObjectName       string   // 为空时根据 Msg 的类型推断，见 RegisterMsgType
+	Msg              RCMsg
+	PushContent      string
+	PushData         string
+	Count            int
+	VerifyBlacklist  int
+	IsPersisted      int
+	IsIncludeSender  int
+	ContentAvailable int
+	Options          []MsgOption
+
+	outboxSeq uint64 // 在 Outbox 中的序号，未使用 Outbox 时为 0
+}
+
+// validate 校验消息类型及必填参数，其余参数由发送接口校验
+func (m *SendMessage) validate() error {
+	if m == nil {
+		return RCErrorNew(1002, "Paramer 'msg' is required")
+	}
+	if m.Kind < SEND_PRIVATE || m.Kind > SEND_CHATROOM {
+		return RCErrorNew(1002, "Paramer 'Kind' is invalid")
+	}
+	if m.Msg == nil {
+		return RCErrorNew(1002, "Paramer 'Msg' is required")
+	}
+	return nil
+}
+
+// send 按消息类型调用发送接口
+func (m *SendMessage) send(ctx context.Context, client MessageClient) error {
+	switch m.Kind {
+	case SEND_PRIVATE:
+		return client.PrivateSendContext(ctx, m.SenderID, m.TargetID, m.ObjectName, m.Msg,
+			m.PushContent, m.PushData, m.Count, m.VerifyBlacklist, m.IsPersisted, m.IsIncludeSender,
+			m.ContentAvailable, m.Options...)
+	case SEND_GROUP:
+		return client.GroupSendContext(ctx, m.SenderID, m.TargetID, m.UserID, m.ObjectName, m.Msg,
+			m.PushContent, m.PushData, m.IsPersisted, m.IsIncludeSender, m.Options...)
+	case SEND_SYSTEM:
+		return client.SystemSendContext(ctx, m.SenderID, m.TargetID, m.ObjectName, m.Msg,
+			m.PushContent, m.PushData, m.Count, m.IsPersisted, m.Options...)
+	case SEND_CHATROOM:
+		return client.ChatRoomSendContext(ctx, m.SenderID, m.TargetID, m.ObjectName, m.Msg)
+	}
+	return m.validate()
+}
+
+// DispatchResult 异步发送的结果
+type DispatchResult struct {
+	Message  *SendMessage
+	Attempts int   // 发送次数
+	Err      error // 最后一次发送的错误，为 nil 时表示发送成功
+	// OutboxErr 在 Outbox 中标记发送意图完成失败的错误，不为 nil 时消息会在下次创建 Dispatcher 时重新发送
+	OutboxErr error
+}
+
+// Dispatcher 异步发送消息，消息进入有界队列后由多个 worker 并发发送，失败时按重试策略重试
+type Dispatcher struct {
+	client     MessageClient
+	queue      chan *SendMessage
+	workers    int
+	retry      RetryPolicy
+	onDone     func(DispatchResult)
+	deadLetter func(DispatchResult)
+	outbox     *Outbox
+
+	// ctx Shutdown 超时后取消，中断正在发送及等待重试的消息
+	ctx        context.Context
+	cancel     context.CancelFunc
+	wg         sync.WaitGroup
+	recovering sync.WaitGroup // Outbox 中未完成的消息重新入队完成前不关闭队列
+
+	// lock 保护 closed，入队时持有读锁，closed 设置后才会关闭 queue
+	lock      sync.RWMutex
+	closed    bool
+	closing   chan struct{}
+	closeOnce sync.Once
+
+	pendingLock sync.Mutex
+	pending     []*SendMessage
+}
+
+// DispatcherOption Dispatcher 可选配置
+type DispatcherOption func(*Dispatcher)
+
+// WithDispatchQueueSize 设置队列长度，默认 1000
+func WithDispatchQueueSize(n int) DispatcherOption {
+	return func(d *Dispatcher) {
+		d.queue = make(chan *SendMessage, n)
+	}
+}
+
+// WithDispatchWorkers 设置并发发送的 worker 数，默认 4
+func WithDispatchWorkers(n int) DispatcherOption {
+	return func(d *Dispatcher) {
+		d.workers = n
+	}
+}
+
+// WithDispatchRetry 设置发送失败后的重试策略，默认最多发送 3 次
+// 与 WithRetryPolicy 不同，消息发送接口不可安全重复调用，重试可能导致接收方收到重复消息
+func WithDispatchRetry(policy RetryPolicy) DispatcherOption {
+	return func(d *Dispatcher) {
+		d.retry = policy
+	}
+}
+
+// WithDispatchCallback 设置消息发送完成（成功或最终失败）后的回调，回调在 worker 中执行，不应长时间阻塞
+// 使用 Outbox 时，标记发送意图完成失败的错误通过 DispatchResult.OutboxErr 返回
+func WithDispatchCallback(fn func(DispatchResult)) DispatcherOption {
+	return func(d *Dispatcher) {
+		d.onDone = fn
+	}
+}
+
+// WithDeadLetter 设置最终发送失败（重试次数用完或错误不可重试）的消息的处理，如写入数据库稍后人工处理
+func WithDeadLetter(fn func(DispatchResult)) DispatcherOption {
+	return func(d *Dispatcher) {
+		d.deadLetter = fn
+	}
+}
+
+// WithDispatchOutbox 设置 Outbox，消息入队前记录发送意图，发送成功或进入死信后标记完成
+// 创建 Dispatcher 时 Outbox 中未完成的消息（如上次进程崩溃或 Shutdown 超时未发送的消息）会在后台重新入队，
+// 可能与之后 Enqueue 的消息交错发送
+func WithDispatchOutbox(outbox *Outbox) DispatcherOption {
+	return func(d *Dispatcher) {
+		d.outbox = outbox
+	}
+}
+
+// NewDispatcher 创建 Dispatcher 并启动 worker，client 通常为 *RongCloud
+// 设置了 WithDispatchOutbox 且未完成的消息无法还原时返回错误
+func NewDispatcher(client MessageClient, options ...DispatcherOption) (*Dispatcher, error) {
+	d := &Dispatcher{
+		client:  client,
+		queue:   make(chan *SendMessage, DEFAULT_DISPATCH_QUEUE_SIZE),
+		workers: DEFAULT_DISPATCH_WORKERS,
+		retry:   RetryPolicy{MaxAttempts: DEFAULT_DISPATCH_MAX_ATTEMPTS},
+		closing: make(chan struct{}),
+	}
+	for _, option := range options {
+		option(d)
+	}
+	if d.workers <= 0 {
+		d.workers = 1
+	}
+	var entries []OutboxEntry
+	if d.outbox != nil {
+		var err error
+		if entries, err = d.outbox.Unfinished(); err != nil {
+			return nil, err
+		}
+	}
+	d.ctx, d.cancel = context.WithCancel(context.Background())
+
+	d.wg.Add(d.workers)
+	for i := 0; i < d.workers; i++ {
+		go d.work()
+	}
+	if len(entries) > 0 {
+		d.recovering.Add(1)
+		go d.recover(entries)
+	}
+	return d, nil
+}
+
+// recover 将 Outbox 中未完成的消息重新入队，队列已满时等待 worker 取出
+// Shutdown 超时后未入队的消息作为未发送完成的消息返回，仍保留在 Outbox 中
+func (d *Dispatcher) recover(entries []OutboxEntry) {
+	defer d.recovering.Done()
+	for i, e := range entries {
+		e.Message.outboxSeq = e.Seq
+		select {
+		case d.queue <- e.Message:
+		case <-d.ctx.Done():
+			for _, e := range entries[i:] {
+				e.Message.outboxSeq = e.Seq
+				d.addPending(e.Message)
+			}
+			return
+		}
+	}
+}
+
+// Enqueue 将消息加入队列，队列已满时等待，直到有空位、ctx 结束或 Dispatcher 关闭
+func (d *Dispatcher) Enqueue(ctx context.Context, msg *SendMessage) error {
+	if err := msg.validate(); err != nil {
+		return err
+	}
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	if d.closed {
+		return ErrDispatcherClosed
+	}
+	if err := d.record(msg); err != nil {
+		return err
+	}
+	var err error
+	select {
+	case d.queue <- msg:
+		return nil
+	case <-ctx.Done():
+		err = ctx.Err()
+	case <-d.closing:
+		err = ErrDispatcherClosed
+	}
+	return d.abort(msg, err)
+}
+
+// TryEnqueue 将消息加入队列，队列已满时立即返回 ErrQueueFull
+func (d *Dispatcher) TryEnqueue(msg *SendMessage) error {
+	if err := msg.validate(); err != nil {
+		return err
+	}
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	if d.closed {
+		return ErrDispatcherClosed
+	}
+	if err := d.record(msg); err != nil {
+		return err
+	}
+	select {
+	case d.queue <- msg:
+		return nil
+	default:
+		return d.abort(msg, ErrQueueFull)
+	}
+}
+
+// record 在 Outbox 中记录发送意图
+func (d *Dispatcher) record(msg *SendMessage) error {
+	if d.outbox == nil {
+		return nil
+	}
+	seq, err := d.outbox.Add(msg)
+	if err != nil {
+		return err
+	}
+	msg.outboxSeq = seq
+	return nil
+}
+
+// done 在 Outbox 中标记发送意图已完成，标记失败时消息会在下次创建 Dispatcher 时重新发送
+func (d *Dispatcher) done(msg *SendMessage) error {
+	if d.outbox != nil && msg.outboxSeq != 0 {
+		return d.outbox.Done(msg.outboxSeq)
+	}
+	return nil
+}
+
+// abort 入队失败时撤销已记录的发送意图，撤销失败时在返回的错误中说明，err 仍可通过 errors.Is 判断
+func (d *Dispatcher) abort(msg *SendMessage, err error) error {
+	if outboxErr := d.done(msg); outboxErr != nil {
+		return fmt.Errorf("%w (outbox: %v)", err, outboxErr)
+	}
+	return err
+}
+
+// Len 队列中等待发送的消息数
+func (d *Dispatcher) Len() int {
+	return len(d.queue)
+}
+
+// Shutdown 停止接受新消息，并等待队列中的消息发送完成
+// ctx 结束时中断正在发送及等待重试的消息，返回未发送完成的消息及 ctx.Err()，调用方可持久化后在重启时重新发送
+// 设置了 WithDispatchOutbox 时，未发送完成的消息（包括尚未重新入队的消息）保留在 Outbox 中
+func (d *Dispatcher) Shutdown(ctx context.Context) ([]*SendMessage, error) {
+	d.closeOnce.Do(func() {
+		close(d.closing)
+		d.lock.Lock()
+		d.closed = true
+		d.lock.Unlock()
+		go func() {
+			d.recovering.Wait()
+			close(d.queue)
+		}()
+	})
+
+	done := make(chan struct{})
+	go func() {
+		d.wg.Wait()
+		close(done)
+	}()
+
+	var err error
+	select {
+	case <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+		d.cancel()
+		<-done
+	}
+	d.cancel()
+
+	d.pendingLock.Lock()
+	defer d.pendingLock.Unlock()
+	pending := d.pending
+	d.pending = nil
+	return pending, err
+}
+
+// work 从队列中取出消息发送，直到队列关闭并取完
+func (d *Dispatcher) work() {
+	defer d.wg.Done()
+	for msg := range d.queue {
+		d.dispatch(msg)
+	}
+}
+
+// dispatch 发送一条消息，失败时按重试策略重试
+func (d *Dispatcher) dispatch(msg *SendMessage) {
+	result := DispatchResult{Message: msg}
+	for {
+		if d.ctx.Err() != nil {
+			d.addPending(msg)
+			return
+		}
+		result.Attempts++
+		result.Err = msg.send(d.ctx, d.client)
+		if result.Err == nil || result.Attempts >= d.retry.MaxAttempts || !d.retryable(result.Err) {
+			break
+		}
+
+		timer := time.NewTimer(d.retry.backoff(result.Attempts))
+		select {
+		case <-d.ctx.Done():
+			timer.Stop()
+		case <-timer.C:
+		}
+	}
+
+	// Shutdown 超时中断的发送不视为失败
+	if result.Err != nil && d.ctx.Err() != nil {
+		d.addPending(msg)
+		return
+	}
+	if result.Err != nil && d.deadLetter != nil {
+		d.deadLetter(result)
+	}
+	result.OutboxErr = d.done(msg)
+	if d.onDone != nil {
+		d.onDone(result)
+	}
+}
+
+// retryable 判断发送错误是否可重试，未设置 RetryPolicy.Retryable 时参数错误及鉴权失败不重试，频率超限重试
+func (d *Dispatcher) retryable(err error) bool {
+	statusCode := 0
+	var e *Error
+	if errors.As(err, &e) {
+		statusCode = e.HTTPStatus
+	}
+	if d.retry.Retryable == nil {
+		if errors.Is(err, ErrValidation) || errors.Is(err, ErrAuthentication) {
+			return false
+		}
+		if errors.Is(err, ErrRateLimited) {
+			return true
+		}
+	}
+	return d.retry.retryableError(statusCode, err)
+}
+
+func (d *Dispatcher) addPending(msg *SendMessage) {
+	d.pendingLock.Lock()
+	defer d.pendingLock.Unlock()
+	d.pending = append(d.pending, msg)
+}
@@ -0,0 +1,144 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeSender 记录 PrivateSendContext 调用，依次返回 errs 中的错误
+type fakeSender struct {
+	MessageClient
+	lock  sync.Mutex
+	sent  []string
+	errs  []error
+	block chan struct{}
+}
+
+func (f *fakeSender) PrivateSendContext(ctx context.Context, senderID string, targetID []string, objectName string, msg RCMsg,
+	pushContent, pushData string, count, verifyBlacklist, isPersisted, isIncludeSender, contentAvailable int,
+	options ...MsgOption) error {
+	if f.block != nil {
+		select {
+		case <-f.block:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.sent = append(f.sent, targetID[0])
+	if len(f.errs) > 0 {
+		err := f.errs[0]
+		f.errs = f.errs[1:]
+		return err
+	}
+	return nil
+}
+
+func privateMessage(id string) *SendMessage {
+	return &SendMessage{ID: id, Kind: SEND_PRIVATE, SenderID: "admin", TargetID: []string{id}, ObjectName: "RC:TxtMsg", Msg: &TXTMsg{Content: "hello"}}
+}
+
+func TestDispatcher(t *testing.T) {
+	serverErr := &Error{Code: 1000, HTTPStatus: http.StatusInternalServerError, Err: errors.New("internal error")}
+	sender := &fakeSender{errs: []error{serverErr, nil, RCErrorNew(1002, "invalid")}}
+
+	var lock sync.Mutex
+	results := map[string]DispatchResult{}
+	var dead []string
+	d, _ := NewDispatcher(sender,
+		WithDispatchWorkers(1),
+		WithDispatchRetry(RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}),
+		WithDispatchCallback(func(r DispatchResult) {
+			lock.Lock()
+			results[r.Message.ID] = r
+			lock.Unlock()
+		}),
+		WithDeadLetter(func(r DispatchResult) {
+			lock.Lock()
+			dead = append(dead, r.Message.ID)
+			lock.Unlock()
+		}),
+	)
+
+	if err := d.Enqueue(context.Background(), &SendMessage{Kind: SEND_PRIVATE}); !errors.Is(err, ErrValidation) {
+		t.Errorf("invalid message: %v", err)
+	}
+	for _, id := range []string{"u01", "u02"} {
+		if err := d.TryEnqueue(privateMessage(id)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	pending, err := d.Shutdown(context.Background())
+	if err != nil || len(pending) != 0 {
+		t.Fatalf("Shutdown = %v, %v", pending, err)
+	}
+
+	// u01 第一次失败后重试成功，u02 参数错误不重试
+	if r := results["u01"]; r.Err != nil || r.Attempts != 2 {
+		t.Errorf("u01 = %+v", r)
+	}
+	if r := results["u02"]; !errors.Is(r.Err, ErrValidation) || r.Attempts != 1 {
+		t.Errorf("u02 = %+v", r)
+	}
+	if len(dead) != 1 || dead[0] != "u02" {
+		t.Errorf("dead letter = %v", dead)
+	}
+	if err := d.TryEnqueue(privateMessage("u03")); err != ErrDispatcherClosed {
+		t.Errorf("after Shutdown: %v", err)
+	}
+}
+
+func TestDispatcher_QueueFull(t *testing.T) {
+	sender := &fakeSender{block: make(chan struct{})}
+	d, _ := NewDispatcher(sender, WithDispatchWorkers(1), WithDispatchQueueSize(1))
+
+	// 第一条消息被 worker 取出后阻塞，第二条占满队列
+	d.TryEnqueue(privateMessage("u01"))
+	deadline := time.Now().Add(time.Second)
+	for d.Len() != 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if err := d.TryEnqueue(privateMessage("u02")); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.TryEnqueue(privateMessage("u03")); err != ErrQueueFull {
+		t.Errorf("TryEnqueue = %v, want ErrQueueFull", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := d.Enqueue(ctx, privateMessage("u03")); err != context.DeadlineExceeded {
+		t.Errorf("Enqueue = %v, want DeadlineExceeded", err)
+	}
+
+	close(sender.block)
+	if pending, err := d.Shutdown(context.Background()); err != nil || len(pending) != 0 || len(sender.sent) != 2 {
+		t.Errorf("Shutdown = %v, %v, sent %v", pending, err, sender.sent)
+	}
+}
+
+func TestDispatcher_ShutdownTimeout(t *testing.T) {
+	sender := &fakeSender{block: make(chan struct{})}
+	var dead []string
+	d, _ := NewDispatcher(sender, WithDispatchWorkers(1), WithDeadLetter(func(r DispatchResult) {
+		dead = append(dead, r.Message.ID)
+	}))
+	for _, id := range []string{"u01", "u02", "u03"} {
+		d.TryEnqueue(privateMessage(id))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	pending, err := d.Shutdown(ctx)
+	if err != context.DeadlineExceeded || len(pending) != 3 {
+		t.Errorf("Shutdown = %d pending, %v", len(pending), err)
+	}
+	// 被中断的消息不进入死信
+	if len(dead) != 0 {
+		t.Errorf("dead letter = %v", dead)
+	}
+}
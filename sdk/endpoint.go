@@ -0,0 +1,292 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	// DEFAULT_MAX_CONSECUTIVE_FAILURES 默认连续失败多少次后切换 API 地址
+	DEFAULT_MAX_CONSECUTIVE_FAILURES = 1
+	// DEFAULT_ERROR_RATE_MIN_REQUESTS 默认计算错误率所需的最少请求数
+	DEFAULT_ERROR_RATE_MIN_REQUESTS = 10
+	// DEFAULT_ERROR_RATE_WINDOW 默认错误率统计窗口
+	DEFAULT_ERROR_RATE_WINDOW = time.Minute
+)
+
+// FailoverPolicy 多 API 地址的故障切换策略
+// API 地址按设置顺序优先使用，地址不健康时切换到下一个健康的地址，冷却时间结束后重新尝试
+type FailoverPolicy struct {
+	// MaxConsecutiveFailures 连续失败达到该次数时标记为不健康，默认 DEFAULT_MAX_CONSECUTIVE_FAILURES
+	MaxConsecutiveFailures int
+	// MaxErrorRate 统计窗口内错误率达到该值（0 ~ 1）时标记为不健康，为 0 时不按错误率切换
+	MaxErrorRate float64
+	// MinRequests 统计窗口内请求数达到该值后才计算错误率，默认 DEFAULT_ERROR_RATE_MIN_REQUESTS
+	MinRequests int
+	// Window 错误率统计窗口，默认 DEFAULT_ERROR_RATE_WINDOW
+	Window time.Duration
+	// CoolDown 不健康地址的冷却时间，冷却期间不会被使用，默认 DEFAULT_CHANGE_URI_DURATION 秒
+	CoolDown time.Duration
+	// ProbeInterval 后台探测间隔，大于 0 时定期探测所有地址，探测成功的地址提前结束冷却，使用 Close 停止探测
+	ProbeInterval time.Duration
+}
+
+// EndpointStatus API 地址的健康状态
+type EndpointStatus struct {
+	URI                 string    // API 地址
+	Active              bool      // 是否为当前使用的地址
+	Healthy             bool      // 是否健康，不健康的地址在冷却结束前不会被使用
+	Requests            uint64    // 统计窗口内的请求数
+	Failures            uint64    // 统计窗口内的失败数
+	ErrorRate           float64   // 统计窗口内的错误率
+	ConsecutiveFailures int       // 连续失败次数
+	CoolDownUntil       time.Time // 冷却结束时间
+	LastError           string    // 最近一次失败的原因
+}
+
+// endpoint API 地址及其被动健康统计
+type endpoint struct {
+	uri                 string
+	requests            uint64
+	failures            uint64
+	windowStart         time.Time
+	consecutiveFailures int
+	coolDownUntil       time.Time
+	lastError           string
+}
+
+// healthy 冷却时间已结束
+func (e *endpoint) healthy(now time.Time) bool {
+	return !now.Before(e.coolDownUntil)
+}
+
+func (e *endpoint) status(now time.Time) EndpointStatus {
+	status := EndpointStatus{
+		URI:                 e.uri,
+		Healthy:             e.healthy(now),
+		Requests:            e.requests,
+		Failures:            e.failures,
+		ConsecutiveFailures: e.consecutiveFailures,
+		CoolDownUntil:       e.coolDownUntil,
+		LastError:           e.lastError,
+	}
+	if e.requests > 0 {
+		status.ErrorRate = float64(e.failures) / float64(e.requests)
+	}
+	return status
+}
+
+// initEndpoints 根据设置的 API 地址生成地址列表
+// 使用融云默认地址时在 api、api2 之间切换，其他地址需通过 WithEndpoints 设置备用地址
+func (rc *RongCloud) initEndpoints(uris []string) {
+	if len(uris) == 0 {
+		switch rc.rongCloudURI {
+		case RONGCLOUDURI:
+			uris = []string{RONGCLOUDURI, RONGCLOUDURI2}
+		case RONGCLOUDURI2:
+			uris = []string{RONGCLOUDURI2, RONGCLOUDURI}
+		default:
+			uris = []string{rc.rongCloudURI}
+		}
+	}
+
+	endpoints := make([]*endpoint, 0, len(uris))
+	for _, uri := range uris {
+		endpoints = append(endpoints, &endpoint{uri: uri})
+	}
+
+	rc.uriLock.Lock()
+	rc.endpoints = endpoints
+	rc.activeEndpoint = 0
+	rc.rongCloudURI = uris[0]
+	rc.endpointReason = "primary endpoint"
+	rc.uriLock.Unlock()
+}
+
+// selectEndpoint 选择当前使用的地址，需持有 uriLock
+// 按顺序选择第一个健康的地址，全部不健康时选择最早结束冷却的地址
+func (rc *RongCloud) selectEndpoint(now time.Time) *endpoint {
+	selected, reason := -1, ""
+	for i, e := range rc.endpoints {
+		if e.healthy(now) {
+			selected = i
+			break
+		}
+	}
+	switch {
+	case selected == 0:
+		reason = "primary endpoint"
+	case selected > 0:
+		prev := rc.endpoints[selected-1]
+		reason = fmt.Sprintf("failover: %s unhealthy until %s, last error: %s",
+			prev.uri, prev.coolDownUntil.Format(time.RFC3339), prev.lastError)
+	default:
+		selected = 0
+		for i, e := range rc.endpoints {
+			if e.coolDownUntil.Before(rc.endpoints[selected].coolDownUntil) {
+				selected = i
+			}
+		}
+		reason = "all endpoints unhealthy, using the one whose cool-down ends first"
+	}
+
+	if selected != rc.activeEndpoint {
+		if rc.metrics != nil {
+			rc.metrics.ObserveFailover(rc.endpoints[rc.activeEndpoint].uri, rc.endpoints[selected].uri, reason)
+		}
+		rc.activeEndpoint = selected
+		rc.endpointReason = reason
+		rc.rongCloudURI = rc.endpoints[selected].uri
+		rc.lastChageUriTime = now.Unix()
+	}
+	return rc.endpoints[selected]
+}
+
+// currentEndpoint 获取本次请求使用的地址
+func (rc *RongCloud) currentEndpoint() *endpoint {
+	rc.uriLock.Lock()
+	defer rc.uriLock.Unlock()
+	return rc.selectEndpoint(time.Now())
+}
+
+// reportEndpoint 记录请求结果，failure 为 nil 表示成功
+// 连续失败次数或错误率超过阈值时地址进入冷却，后续请求切换到下一个健康的地址
+func (rc *RongCloud) reportEndpoint(e *endpoint, failure error) {
+	rc.uriLock.Lock()
+	defer rc.uriLock.Unlock()
+
+	now := time.Now()
+	policy := rc.failoverPolicy
+	window := policy.Window
+	if window <= 0 {
+		window = DEFAULT_ERROR_RATE_WINDOW
+	}
+	if now.Sub(e.windowStart) >= window {
+		e.windowStart = now
+		e.requests, e.failures = 0, 0
+	}
+
+	e.requests++
+	if failure == nil {
+		e.consecutiveFailures = 0
+		return
+	}
+	e.failures++
+	e.consecutiveFailures++
+	e.lastError = failure.Error()
+
+	maxFailures := policy.MaxConsecutiveFailures
+	if maxFailures <= 0 {
+		maxFailures = DEFAULT_MAX_CONSECUTIVE_FAILURES
+	}
+	minRequests := policy.MinRequests
+	if minRequests <= 0 {
+		minRequests = DEFAULT_ERROR_RATE_MIN_REQUESTS
+	}
+	unhealthy := e.consecutiveFailures >= maxFailures
+	if policy.MaxErrorRate > 0 && e.requests >= uint64(minRequests) &&
+		float64(e.failures)/float64(e.requests) >= policy.MaxErrorRate {
+		unhealthy = true
+	}
+	if unhealthy {
+		rc.coolDown(e, now)
+	}
+}
+
+// coolDown 地址进入冷却并重新选择地址，需持有 uriLock
+func (rc *RongCloud) coolDown(e *endpoint, now time.Time) {
+	coolDown := rc.failoverPolicy.CoolDown
+	if coolDown <= 0 {
+		coolDown = time.Duration(rc.changeUriDuration) * time.Second
+	}
+	e.coolDownUntil = now.Add(coolDown)
+	rc.selectEndpoint(now)
+}
+
+// Endpoints 获取所有 API 地址的健康状态，按优先级排序
+func (rc *RongCloud) Endpoints() []EndpointStatus {
+	rc.uriLock.Lock()
+	defer rc.uriLock.Unlock()
+
+	now := time.Now()
+	statuses := make([]EndpointStatus, 0, len(rc.endpoints))
+	for i, e := range rc.endpoints {
+		status := e.status(now)
+		status.Active = i == rc.activeEndpoint
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+// ActiveEndpoint 获取当前使用的 API 地址及选择该地址的原因
+func (rc *RongCloud) ActiveEndpoint() (status EndpointStatus, reason string) {
+	rc.uriLock.Lock()
+	defer rc.uriLock.Unlock()
+
+	now := time.Now()
+	e := rc.selectEndpoint(now)
+	status = e.status(now)
+	status.Active = true
+	return status, rc.endpointReason
+}
+
+// probeEndpoints 定期探测所有地址，直到调用 Close
+func (rc *RongCloud) probeEndpoints(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-rc.closed:
+			return
+		case <-ticker.C:
+		}
+
+		rc.uriLock.Lock()
+		endpoints := rc.endpoints
+		rc.uriLock.Unlock()
+		for _, e := range endpoints {
+			rc.probeEndpoint(e, interval)
+		}
+	}
+}
+
+// probeEndpoint 探测地址是否可连接，能收到 http 响应即认为可用
+func (rc *RongCloud) probeEndpoint(e *endpoint, timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	req, err := http.NewRequest(http.MethodGet, e.uri, nil)
+	if err != nil {
+		return
+	}
+	req.Header.Set("User-Agent", USERAGENT)
+	resp, err := rc.GetHTTPExecutor().Do(req.WithContext(ctx))
+	if err == nil {
+		_ = resp.Body.Close()
+	}
+
+	rc.uriLock.Lock()
+	defer rc.uriLock.Unlock()
+	now := time.Now()
+	if err != nil || resp.StatusCode >= 500 {
+		if err != nil {
+			e.lastError = "probe: " + err.Error()
+		} else {
+			e.lastError = "probe: " + resp.Status
+		}
+		if e.healthy(now) {
+			rc.coolDown(e, now)
+		}
+		return
+	}
+	if !e.healthy(now) {
+		e.coolDownUntil = time.Time{}
+		e.consecutiveFailures = 0
+		rc.selectEndpoint(now)
+		if rc.endpoints[rc.activeEndpoint] == e {
+			rc.endpointReason = "probe succeeded: " + e.uri + " recovered"
+		}
+	}
+}
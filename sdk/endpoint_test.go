@@ -0,0 +1,157 @@
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newStatusServer(status int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		if status >= 500 {
+			_, _ = w.Write([]byte(`{"code":1000,"errorMessage":"internal error"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"code":200,"users":[]}`))
+	}))
+}
+
+func TestWithEndpoints(t *testing.T) {
+	bad := newStatusServer(http.StatusBadGateway)
+	defer bad.Close()
+	good := newStatusServer(http.StatusOK)
+	defer good.Close()
+	spare := newStatusServer(http.StatusOK)
+	defer spare.Close()
+
+	rc := New("appKey", "appSecret",
+		WithEndpoints(bad.URL, good.URL, spare.URL),
+		WithFailoverPolicy(FailoverPolicy{CoolDown: 100 * time.Millisecond}),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond}),
+	)
+	if _, err := rc.BlockGetList(); err != nil {
+		t.Fatal(err)
+	}
+
+	status, reason := rc.ActiveEndpoint()
+	if status.URI != good.URL {
+		t.Errorf("expected %s, got %s", good.URL, status.URI)
+	}
+	if !strings.HasPrefix(reason, "failover: "+bad.URL) {
+		t.Errorf("invalid reason: %s", reason)
+	}
+	endpoints := rc.Endpoints()
+	if len(endpoints) != 3 || endpoints[0].Healthy || endpoints[0].Failures != 1 || !endpoints[1].Active {
+		t.Errorf("invalid endpoints: %+v", endpoints)
+	}
+
+	time.Sleep(150 * time.Millisecond)
+	if status, reason = rc.ActiveEndpoint(); status.URI != bad.URL || reason != "primary endpoint" {
+		t.Errorf("primary endpoint should be tried again after cool-down, got %s: %s", status.URI, reason)
+	}
+}
+
+func TestFailoverPolicy_MaxErrorRate(t *testing.T) {
+	rc := New("appKey", "appSecret",
+		WithEndpoints("http://api1.test.com", "http://api2.test.com"),
+		WithFailoverPolicy(FailoverPolicy{MaxConsecutiveFailures: 100, MaxErrorRate: 0.5, MinRequests: 4}),
+	)
+	e := rc.currentEndpoint()
+	for _, failed := range []bool{true, false, true} {
+		if failed {
+			rc.reportEndpoint(e, http.ErrHandlerTimeout)
+		} else {
+			rc.reportEndpoint(e, nil)
+		}
+	}
+	if rc.currentEndpoint() != e {
+		t.Fatal("should not switch before MinRequests")
+	}
+	rc.reportEndpoint(e, http.ErrHandlerTimeout)
+	if rc.currentEndpoint().uri != "http://api2.test.com" {
+		t.Error("should switch when error rate is reached")
+	}
+}
+
+func TestWithEndpoints_callerTimeout(t *testing.T) {
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+		_, _ = w.Write([]byte(`{"code":200}`))
+	}))
+	defer slow.Close()
+	backup := newStatusServer(http.StatusOK)
+	defer backup.Close()
+
+	rc := New("appKey", "appSecret", WithEndpoints(slow.URL, backup.URL))
+	// 调用方的 ctx 超时不视为地址失败
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := rc.BlockRemoveContext(ctx, "u01"); err == nil {
+		t.Fatal("expected error")
+	}
+	if status, reason := rc.ActiveEndpoint(); status.URI != slow.URL || status.Failures != 0 {
+		t.Errorf("active = %+v, reason = %s", status, reason)
+	}
+
+	// SDK 自身的请求超时视为地址失败
+	rc = New("appKey", "appSecret", WithEndpoints(slow.URL, backup.URL))
+	rc.timeout = 0
+	rc.SetHTTPExecutor(&http.Client{Timeout: 20 * time.Millisecond})
+	_ = rc.BlockRemove("u01")
+	if status, _ := rc.ActiveEndpoint(); status.URI != backup.URL {
+		t.Errorf("expected %s, got %s", backup.URL, status.URI)
+	}
+}
+
+func TestRongCloud_ChangeURI(t *testing.T) {
+	rc := New("appKey", "appSecret")
+	rc.ChangeURI()
+	if rc.rongCloudURI != RONGCLOUDURI2 {
+		t.Errorf("expected %s, got %s", RONGCLOUDURI2, rc.rongCloudURI)
+	}
+	// changeUriDuration 内再次调用不切换
+	rc.ChangeURI()
+	if rc.rongCloudURI != RONGCLOUDURI2 {
+		t.Errorf("ChangeURI should be throttled, got %s", rc.rongCloudURI)
+	}
+
+	rc.PrivateURI("http://private.test.com", "http://sms.test.com")
+	rc.ChangeURI()
+	if status, _ := rc.ActiveEndpoint(); status.URI != "http://private.test.com" {
+		t.Errorf("invalid endpoint: %s", status.URI)
+	}
+}
+
+func TestFailoverPolicy_ProbeInterval(t *testing.T) {
+	primary := newStatusServer(http.StatusNotFound)
+	defer primary.Close()
+	backup := newStatusServer(http.StatusNotFound)
+	defer backup.Close()
+
+	rc := New("appKey", "appSecret",
+		WithEndpoints(primary.URL, backup.URL),
+		WithFailoverPolicy(FailoverPolicy{CoolDown: time.Hour, ProbeInterval: 20 * time.Millisecond}),
+	)
+	defer rc.Close()
+
+	rc.ChangeURI()
+	if status, _ := rc.ActiveEndpoint(); status.URI != backup.URL {
+		t.Fatalf("expected %s, got %s", backup.URL, status.URI)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if status, reason := rc.ActiveEndpoint(); status.URI == primary.URL {
+			if !strings.HasPrefix(reason, "probe succeeded") {
+				t.Errorf("invalid reason: %s", reason)
+			}
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Error("primary endpoint should be recovered by probe")
+}
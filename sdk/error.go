@@ -1,7 +1,12 @@
 package sdk
 
 import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
 	"strconv"
+	"strings"
 	"sync"
 )
 
@@ -31,3 +36,123 @@ func (e CodeResult) Error() string {
 func (e CodeResult) ErrorCode() int {
 	return e.Code
 }
+
+// 错误类别，可通过 errors.Is 判断，如 errors.Is(err, sdk.ErrRateLimited)
+var (
+	// ErrValidation 参数错误，包括 SDK 本地参数校验失败（错误码 1002）
+	ErrValidation = errors.New("rongcloud: validation error")
+	// ErrAuthentication 鉴权失败，如签名错误（http 401）
+	ErrAuthentication = errors.New("rongcloud: authentication error")
+	// ErrRateLimited 调用频率超限，包括客户端限流
+	ErrRateLimited = errors.New("rongcloud: rate limited")
+	// ErrNotFound 接口不存在（http 404），融云返回码表示的用户、群组等资源不存在不属于该类别
+	ErrNotFound = errors.New("rongcloud: not found")
+	// ErrServer 融云服务端错误
+	ErrServer = errors.New("rongcloud: server error")
+	// ErrNetwork 网络错误，如连接失败、超时
+	ErrNetwork = errors.New("rongcloud: network error")
+)
+
+// codeKinds 融云返回码对应的错误类别
+var codeKinds = map[int]error{
+	1000: ErrServer,         // 服务内部错误
+	1001: ErrAuthentication, // App Secret 错误
+	1002: ErrValidation,     // 参数错误
+	1003: ErrValidation,     // 没有 POST 数据
+	1004: ErrAuthentication, // 验证签名错误
+	1005: ErrValidation,     // 参数长度超限
+	1008: ErrRateLimited,    // 调用频率超限
+	1050: ErrServer,         // 内部服务响应超时
+}
+
+// Error API 调用失败的详细信息
+// 可通过 errors.Is 判断错误类别，通过 errors.As 获取 CodeResult、net.Error 等原始错误
+type Error struct {
+	Code       int    // 融云返回码，无法获取时为 0
+	Message    string // 错误信息
+	HTTPStatus int    // http 状态码，未收到响应时为 0
+	Endpoint   string // 不含域名及后缀的接口路径
+	RequestID  string // 响应头中的 X-Request-ID
+	Err        error  // 原始错误
+}
+
+// newError 包装 API 调用的原始错误
+func newError(endpoint string, httpStatus int, requestID string, err error) *Error {
+	e := &Error{
+		Message:    err.Error(),
+		HTTPStatus: httpStatus,
+		Endpoint:   endpoint,
+		RequestID:  requestID,
+		Err:        err,
+	}
+	var code CodeResult
+	if errors.As(err, &code) {
+		e.Code, e.Message = code.Code, code.ErrorMessage
+	}
+	return e
+}
+
+// Error 获取错误信息
+func (e *Error) Error() string {
+	var b strings.Builder
+	b.WriteString("rongcloud " + e.Endpoint + ": ")
+	if e.Code != 0 {
+		b.WriteString(strconv.Itoa(e.Code) + ": ")
+	}
+	b.WriteString(e.Message)
+	if e.HTTPStatus != 0 {
+		b.WriteString(", http status " + strconv.Itoa(e.HTTPStatus))
+	}
+	if e.RequestID != "" {
+		b.WriteString(", request id " + e.RequestID)
+	}
+	return b.String()
+}
+
+// ErrorCode 获取错误码
+func (e *Error) ErrorCode() int {
+	return e.Code
+}
+
+// Unwrap 获取原始错误
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is 判断错误类别
+func (e *Error) Is(target error) bool {
+	kind := e.kind()
+	return kind != nil && kind == target
+}
+
+// kind 获取错误类别，依次按 http 状态码、融云返回码、原始错误判断
+func (e *Error) kind() error {
+	switch {
+	case e.HTTPStatus == http.StatusUnauthorized:
+		return ErrAuthentication
+	case e.HTTPStatus == http.StatusNotFound:
+		return ErrNotFound
+	case e.HTTPStatus == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case e.HTTPStatus >= 500 && e.HTTPStatus < 600:
+		return ErrServer
+	}
+	if kind, ok := codeKinds[e.Code]; ok {
+		return kind
+	}
+	// 调用方主动取消不属于网络错误
+	if errors.Is(e.Err, context.Canceled) {
+		return nil
+	}
+	var netErr net.Error
+	if e.HTTPStatus == 0 && errors.As(e.Err, &netErr) {
+		return ErrNetwork
+	}
+	return nil
+}
+
+// Is 判断错误类别，SDK 本地参数校验返回的 CodeResult 同样可以判断
+func (e CodeResult) Is(target error) bool {
+	kind, ok := codeKinds[e.Code]
+	return ok && kind == target
+}
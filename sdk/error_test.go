@@ -1,6 +1,10 @@
 package sdk
 
 import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 )
 
@@ -17,3 +21,56 @@ func TestCodeResult_Error(t *testing.T) {
 	err := CodeResult{200, "rcerr"}
 	t.Log(err.Error())
 }
+
+func TestError_Is(t *testing.T) {
+	cases := []struct {
+		err  error
+		kind error
+	}{
+		{RCErrorNew(1002, "Paramer 'userId' is required"), ErrValidation},
+		{newError("/user/getToken", 200, "", RCErrorNew(1002, "invalid")), ErrValidation},
+		{newError("/user/getToken", 401, "", RCErrorNew(1004, "signature error")), ErrAuthentication},
+		{newError("/push", 200, "", RCErrorNew(1008, "rate limited")), ErrRateLimited},
+		{newError("/push", 429, "", errors.New("too many requests")), ErrRateLimited},
+		{newError("/user/unknown", 404, "", errors.New("invalid character")), ErrNotFound},
+		{newError("/push", 502, "", errors.New("invalid character")), ErrServer},
+		{newError("/push", 200, "", RCErrorNew(1050, "timeout")), ErrServer},
+		{newError("/push", 0, "", &net.DNSError{Err: "no such host", IsNotFound: true}), ErrNetwork},
+	}
+	kinds := []error{ErrValidation, ErrAuthentication, ErrRateLimited, ErrNotFound, ErrServer, ErrNetwork}
+	for i, c := range cases {
+		for _, kind := range kinds {
+			if errors.Is(c.err, kind) != (kind == c.kind) {
+				t.Errorf("case %d: errors.Is(%v, %v) should be %v", i, c.err, kind, kind == c.kind)
+			}
+		}
+	}
+}
+
+func TestError_As(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-ID", "req01")
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"code":1004,"errorMessage":"signature error"}`))
+	}))
+	defer server.Close()
+
+	rc := New("appKey", "appSecret", WithRongCloudURI(server.URL))
+	_, err := rc.UserRegister("u01", "name", "")
+
+	var e *Error
+	if !errors.As(err, &e) {
+		t.Fatalf("expected *Error, got %T", err)
+	}
+	if e.Code != 1004 || e.HTTPStatus != 401 || e.Endpoint != "/user/getToken" || e.RequestID != "req01" {
+		t.Errorf("invalid error: %+v", e)
+	}
+	var code CodeResult
+	if !errors.As(err, &code) || code.ErrorMessage != "signature error" {
+		t.Errorf("cause should be CodeResult, got %v", e.Err)
+	}
+	if !errors.Is(err, ErrAuthentication) {
+		t.Error("should be authentication error")
+	}
+	t.Log(err)
+}
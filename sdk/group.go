@@ -1,11 +1,9 @@
 package sdk
 
 import (
+	"context"
 	"encoding/json"
 	"strconv"
-	"time"
-
-	"github.com/astaxie/beego/httplib"
 )
 
 // Group 群组信息
@@ -37,6 +35,11 @@ type GroupInfo struct {
  *@return error
  */
 func (rc *RongCloud) GroupCreate(id, name string, members []string) error {
+	return rc.GroupCreateContext(context.Background(), id, name, members)
+}
+
+// GroupCreateContext 同 GroupCreate，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) GroupCreateContext(ctx context.Context, id, name string, members []string) error {
 	if len(members) == 0 {
 		return RCErrorNew(1002, "Paramer 'members' is required")
 	}
@@ -49,9 +52,7 @@ func (rc *RongCloud) GroupCreate(id, name string, members []string) error {
 		return RCErrorNew(1002, "Paramer 'name' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/group/create." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/group/create")
 
 	for _, item := range members {
 		req.Param("userId", item)
@@ -59,7 +60,7 @@ func (rc *RongCloud) GroupCreate(id, name string, members []string) error {
 	req.Param("groupId", id)
 	req.Param("groupName", name)
 
-	_, err := rc.do(req)
+	_, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 	}
@@ -74,6 +75,11 @@ func (rc *RongCloud) GroupCreate(id, name string, members []string) error {
  *@return error
  */
 func (rc *RongCloud) GroupSync(id string, groups []Group) error {
+	return rc.GroupSyncContext(context.Background(), id, groups)
+}
+
+// GroupSyncContext 同 GroupSync，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) GroupSyncContext(ctx context.Context, id string, groups []Group) error {
 	if id == "" {
 		return RCErrorNew(1002, "Paramer 'id' is required")
 	}
@@ -82,16 +88,14 @@ func (rc *RongCloud) GroupSync(id string, groups []Group) error {
 		return RCErrorNew(1002, "Paramer 'groups' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/group/sync." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/group/sync")
 
 	req.Param("userId", id)
 	for _, item := range groups {
 		req.Param("group["+item.ID+"]", item.Name)
 	}
 
-	_, err := rc.do(req)
+	_, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 	}
@@ -106,6 +110,11 @@ func (rc *RongCloud) GroupSync(id string, groups []Group) error {
 *@return error
  */
 func (rc *RongCloud) GroupUpdate(id, name string) error {
+	return rc.GroupUpdateContext(context.Background(), id, name)
+}
+
+// GroupUpdateContext 同 GroupUpdate，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) GroupUpdateContext(ctx context.Context, id, name string) error {
 	if id == "" {
 		return RCErrorNew(1002, "Paramer 'id' is required")
 	}
@@ -114,14 +123,12 @@ func (rc *RongCloud) GroupUpdate(id, name string) error {
 		return RCErrorNew(1002, "Paramer 'name' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/group/refresh." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/group/refresh")
 
 	req.Param("groupId", id)
 	req.Param("groupName", name)
 
-	_, err := rc.do(req)
+	_, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 	}
@@ -137,6 +144,11 @@ func (rc *RongCloud) GroupUpdate(id, name string) error {
  *@return error
  */
 func (rc *RongCloud) GroupJoin(id, name, member string) error {
+	return rc.GroupJoinContext(context.Background(), id, name, member)
+}
+
+// GroupJoinContext 同 GroupJoin，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) GroupJoinContext(ctx context.Context, id, name, member string) error {
 	if member == "" {
 		return RCErrorNew(1002, "Paramer 'member' is required")
 	}
@@ -149,15 +161,13 @@ func (rc *RongCloud) GroupJoin(id, name, member string) error {
 		return RCErrorNew(1002, "Paramer 'name' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/group/join." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/group/join")
 
 	req.Param("userId", member)
 	req.Param("groupId", id)
 	req.Param("groupName", name)
 
-	_, err := rc.do(req)
+	_, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 	}
@@ -171,16 +181,19 @@ func (rc *RongCloud) GroupJoin(id, name, member string) error {
  *@return Group error
  */
 func (rc *RongCloud) GroupGet(id string) (Group, error) {
+	return rc.GroupGetContext(context.Background(), id)
+}
+
+// GroupGetContext 同 GroupGet，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) GroupGetContext(ctx context.Context, id string) (Group, error) {
 	if id == "" {
 		return Group{}, RCErrorNew(1002, "Paramer 'id' is required")
 	}
-	req := httplib.Post(rc.rongCloudURI + "/group/user/query." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/group/user/query")
 
 	req.Param("groupId", id)
 
-	resp, err := rc.do(req)
+	resp, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 		return Group{}, err
@@ -201,6 +214,11 @@ func (rc *RongCloud) GroupGet(id string) (Group, error) {
  *@return error
  */
 func (rc *RongCloud) GroupQuit(member, id string) error {
+	return rc.GroupQuitContext(context.Background(), member, id)
+}
+
+// GroupQuitContext 同 GroupQuit，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) GroupQuitContext(ctx context.Context, member, id string) error {
 	if member == "" {
 		return RCErrorNew(1002, "Paramer 'member' is required")
 	}
@@ -209,14 +227,12 @@ func (rc *RongCloud) GroupQuit(member, id string) error {
 		return RCErrorNew(1002, "Paramer 'id' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/group/quit." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/group/quit")
 
 	req.Param("userId", member)
 	req.Param("groupId", id)
 
-	_, err := rc.do(req)
+	_, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 	}
@@ -231,6 +247,11 @@ func (rc *RongCloud) GroupQuit(member, id string) error {
  *@return error
  */
 func (rc *RongCloud) GroupDismiss(id, member string) error {
+	return rc.GroupDismissContext(context.Background(), id, member)
+}
+
+// GroupDismissContext 同 GroupDismiss，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) GroupDismissContext(ctx context.Context, id, member string) error {
 	if member == "" {
 		return RCErrorNew(1002, "Paramer 'member' is required")
 	}
@@ -239,14 +260,12 @@ func (rc *RongCloud) GroupDismiss(id, member string) error {
 		return RCErrorNew(1002, "Paramer 'id' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/group/dismiss." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/group/dismiss")
 
 	req.Param("userId", member)
 	req.Param("groupId", id)
 
-	_, err := rc.do(req)
+	_, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 	}
@@ -262,6 +281,11 @@ func (rc *RongCloud) GroupDismiss(id, member string) error {
 *@return error
  */
 func (rc *RongCloud) GroupGagAdd(id string, members []string, minute int) error {
+	return rc.GroupGagAddContext(context.Background(), id, members, minute)
+}
+
+// GroupGagAddContext 同 GroupGagAdd，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) GroupGagAddContext(ctx context.Context, id string, members []string, minute int) error {
 	if id == "" {
 		return RCErrorNew(1002, "Paramer 'id' is required")
 	}
@@ -274,16 +298,14 @@ func (rc *RongCloud) GroupGagAdd(id string, members []string, minute int) error
 		return RCErrorNew(1002, "Paramer 'minute' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/group/user/gag/add." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/group/user/gag/add")
 	for _, item := range members {
 		req.Param("userId", item)
 	}
 	req.Param("groupId", id)
 	req.Param("minute", strconv.Itoa(minute))
 
-	_, err := rc.do(req)
+	_, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 	}
@@ -299,6 +321,11 @@ func (rc *RongCloud) GroupGagAdd(id string, members []string, minute int) error
 *@return error
  */
 func (rc *RongCloud) GroupMuteMembersAdd(id string, members []string, minute int) error {
+	return rc.GroupMuteMembersAddContext(context.Background(), id, members, minute)
+}
+
+// GroupMuteMembersAddContext 同 GroupMuteMembersAdd，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) GroupMuteMembersAddContext(ctx context.Context, id string, members []string, minute int) error {
 	if id == "" {
 		return RCErrorNew(1002, "Paramer 'id' is required")
 	}
@@ -311,16 +338,14 @@ func (rc *RongCloud) GroupMuteMembersAdd(id string, members []string, minute int
 		return RCErrorNew(1002, "Paramer 'minute' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/group/user/gag/add." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/group/user/gag/add")
 	for _, item := range members {
 		req.Param("userId", item)
 	}
 	req.Param("groupId", id)
 	req.Param("minute", strconv.Itoa(minute))
 
-	_, err := rc.do(req)
+	_, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 	}
@@ -334,17 +359,20 @@ func (rc *RongCloud) GroupMuteMembersAdd(id string, members []string, minute int
 *@return Group error
  */
 func (rc *RongCloud) GroupGagList(id string) (Group, error) {
+	return rc.GroupGagListContext(context.Background(), id)
+}
+
+// GroupGagListContext 同 GroupGagList，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) GroupGagListContext(ctx context.Context, id string) (Group, error) {
 	if id == "" {
 		return Group{}, RCErrorNew(1002, "Paramer 'id' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/group/user/gag/list." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/group/user/gag/list")
 
 	req.Param("groupId", id)
 
-	resp, err := rc.do(req)
+	resp, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 		return Group{}, err
@@ -363,17 +391,20 @@ func (rc *RongCloud) GroupGagList(id string) (Group, error) {
 *@return Group error
  */
 func (rc *RongCloud) GroupMuteMembersGetList(id string) (Group, error) {
+	return rc.GroupMuteMembersGetListContext(context.Background(), id)
+}
+
+// GroupMuteMembersGetListContext 同 GroupMuteMembersGetList，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) GroupMuteMembersGetListContext(ctx context.Context, id string) (Group, error) {
 	if id == "" {
 		return Group{}, RCErrorNew(1002, "Paramer 'id' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/group/user/gag/list." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/group/user/gag/list")
 
 	req.Param("groupId", id)
 
-	resp, err := rc.do(req)
+	resp, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 		return Group{}, err
@@ -393,6 +424,11 @@ func (rc *RongCloud) GroupMuteMembersGetList(id string) (Group, error) {
 *@return error
  */
 func (rc *RongCloud) GroupGagRemove(id string, members []string) error {
+	return rc.GroupGagRemoveContext(context.Background(), id, members)
+}
+
+// GroupGagRemoveContext 同 GroupGagRemove，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) GroupGagRemoveContext(ctx context.Context, id string, members []string) error {
 	if len(members) == 0 {
 		return RCErrorNew(1002, "Paramer 'members' is required")
 	}
@@ -401,16 +437,14 @@ func (rc *RongCloud) GroupGagRemove(id string, members []string) error {
 		return RCErrorNew(1002, "Paramer 'id' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/group/user/gag/rollback." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/group/user/gag/rollback")
 
 	for _, item := range members {
 		req.Param("userId", item)
 	}
 	req.Param("groupId", id)
 
-	_, err := rc.do(req)
+	_, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 	}
@@ -425,6 +459,11 @@ func (rc *RongCloud) GroupGagRemove(id string, members []string) error {
 *@return error
  */
 func (rc *RongCloud) GroupMuteMembersRemove(id string, members []string) error {
+	return rc.GroupMuteMembersRemoveContext(context.Background(), id, members)
+}
+
+// GroupMuteMembersRemoveContext 同 GroupMuteMembersRemove，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) GroupMuteMembersRemoveContext(ctx context.Context, id string, members []string) error {
 	if len(members) == 0 {
 		return RCErrorNew(1002, "Paramer 'members' is required")
 	}
@@ -433,16 +472,14 @@ func (rc *RongCloud) GroupMuteMembersRemove(id string, members []string) error {
 		return RCErrorNew(1002, "Paramer 'id' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/group/user/gag/rollback." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/group/user/gag/rollback")
 
 	for _, item := range members {
 		req.Param("userId", item)
 	}
 	req.Param("groupId", id)
 
-	_, err := rc.do(req)
+	_, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 	}
@@ -456,19 +493,22 @@ func (rc *RongCloud) GroupMuteMembersRemove(id string, members []string) error {
 *@return error
  */
 func (rc *RongCloud) GroupMuteAllMembersAdd(members []string) error {
+	return rc.GroupMuteAllMembersAddContext(context.Background(), members)
+}
+
+// GroupMuteAllMembersAddContext 同 GroupMuteAllMembersAdd，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) GroupMuteAllMembersAddContext(ctx context.Context, members []string) error {
 	if len(members) == 0 {
 		return RCErrorNew(1002, "Paramer 'members' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/group/ban/add." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/group/ban/add")
 
 	for _, item := range members {
 		req.Param("groupId", item)
 	}
 
-	_, err := rc.do(req)
+	_, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 	}
@@ -482,19 +522,22 @@ func (rc *RongCloud) GroupMuteAllMembersAdd(members []string) error {
 *@return error
  */
 func (rc *RongCloud) GroupMuteAllMembersRemove(members []string) error {
+	return rc.GroupMuteAllMembersRemoveContext(context.Background(), members)
+}
+
+// GroupMuteAllMembersRemoveContext 同 GroupMuteAllMembersRemove，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) GroupMuteAllMembersRemoveContext(ctx context.Context, members []string) error {
 	if len(members) == 0 {
 		return RCErrorNew(1002, "Paramer 'members' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/group/ban/rollback." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/group/ban/rollback")
 
 	for _, item := range members {
 		req.Param("groupId", item)
 	}
 
-	_, err := rc.do(req)
+	_, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 	}
@@ -508,17 +551,20 @@ func (rc *RongCloud) GroupMuteAllMembersRemove(members []string) error {
 *@return Group error
  */
 func (rc *RongCloud) GroupMuteAllMembersGetList(members []string) (GroupInfo, error) {
+	return rc.GroupMuteAllMembersGetListContext(context.Background(), members)
+}
+
+// GroupMuteAllMembersGetListContext 同 GroupMuteAllMembersGetList，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) GroupMuteAllMembersGetListContext(ctx context.Context, members []string) (GroupInfo, error) {
 
-	req := httplib.Post(rc.rongCloudURI + "/group/ban/query." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/group/ban/query")
 	if len(members) > 0 {
 		for _, item := range members {
 			req.Param("groupId", item)
 		}
 	}
 
-	resp, err := rc.do(req)
+	resp, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 		return GroupInfo{}, err
@@ -538,6 +584,11 @@ func (rc *RongCloud) GroupMuteAllMembersGetList(members []string) (GroupInfo, er
 *@return error
  */
 func (rc *RongCloud) GroupMuteWhiteListUserAdd(id string, members []string) error {
+	return rc.GroupMuteWhiteListUserAddContext(context.Background(), id, members)
+}
+
+// GroupMuteWhiteListUserAddContext 同 GroupMuteWhiteListUserAdd，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) GroupMuteWhiteListUserAddContext(ctx context.Context, id string, members []string) error {
 	if len(members) == 0 {
 		return RCErrorNew(1002, "Paramer 'members' is required")
 	}
@@ -546,15 +597,13 @@ func (rc *RongCloud) GroupMuteWhiteListUserAdd(id string, members []string) erro
 		return RCErrorNew(1002, "Paramer 'id' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/group/user/ban/whitelist/add." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/group/user/ban/whitelist/add")
 	for _, item := range members {
 		req.Param("userId", item)
 	}
 	req.Param("groupId", id)
 
-	_, err := rc.do(req)
+	_, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 	}
@@ -569,6 +618,11 @@ func (rc *RongCloud) GroupMuteWhiteListUserAdd(id string, members []string) erro
 *@return error
  */
 func (rc *RongCloud) GroupMuteWhiteListUserRemove(id string, members []string) error {
+	return rc.GroupMuteWhiteListUserRemoveContext(context.Background(), id, members)
+}
+
+// GroupMuteWhiteListUserRemoveContext 同 GroupMuteWhiteListUserRemove，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) GroupMuteWhiteListUserRemoveContext(ctx context.Context, id string, members []string) error {
 	if len(members) == 0 {
 		return RCErrorNew(1002, "Paramer 'members' is required")
 	}
@@ -577,16 +631,14 @@ func (rc *RongCloud) GroupMuteWhiteListUserRemove(id string, members []string) e
 		return RCErrorNew(1002, "Paramer 'id' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/group/user/ban/whitelist/rollback." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/group/user/ban/whitelist/rollback")
 
 	for _, item := range members {
 		req.Param("userId", item)
 	}
 	req.Param("groupId", id)
 
-	_, err := rc.do(req)
+	_, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 	}
@@ -600,17 +652,20 @@ func (rc *RongCloud) GroupMuteWhiteListUserRemove(id string, members []string) e
 *@return error
  */
 func (rc *RongCloud) GroupMuteWhiteListUserGetList(id string) ([]string, error) {
+	return rc.GroupMuteWhiteListUserGetListContext(context.Background(), id)
+}
+
+// GroupMuteWhiteListUserGetListContext 同 GroupMuteWhiteListUserGetList，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) GroupMuteWhiteListUserGetListContext(ctx context.Context, id string) ([]string, error) {
 	if id == "" {
 		return []string{}, RCErrorNew(1002, "Paramer 'id' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/group/user/ban/whitelist/query." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/group/user/ban/whitelist/query")
 
 	req.Param("groupId", id)
 
-	resp, err := rc.do(req)
+	resp, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 		return []string{}, err
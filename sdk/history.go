@@ -0,0 +1,528 @@
+package sdk
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// HISTORY_DATE_FORMAT 历史消息日志的日期格式，精确到小时
+const HISTORY_DATE_FORMAT = "2006010215"
+
+// DEFAULT_HISTORY_DOWNLOAD_TIMEOUT 默认日志文件下载超时时间，ctx 未设置截止时间时生效
+const DEFAULT_HISTORY_DOWNLOAD_TIMEOUT = 10 * time.Minute
+
+// HistoryLocation 历史消息日志使用的时区（北京时间）
+var HistoryLocation = time.FixedZone("CST", 8*60*60)
+
+// HistoryRecord 历史消息日志中的一条消息
+type HistoryRecord struct {
+	AppID      string
+	FromUserID string
+	TargetID   string
+	TargetType int // 会话类型，1 单聊、2 讨论组、3 群聊、4 聊天室、5 客服、6 系统通知、7 公众服务、8 公众服务
+	GroupID    string
+	ObjectName string
+	Content    json.RawMessage // 原始消息内容
+	DateTime   time.Time
+	MsgUID     string
+	Source     string
+	BusChannel string
+	// Message 按 ObjectName 解码后的消息，见 MsgDecoder.DecodeContent
+	Message interface{}
+	// DecodeErr 已知消息类型解码失败的原因，此时 Message 为原始 json
+	DecodeErr error
+}
+
+// historyLine 历史消息日志每行的 json 内容
+type historyLine struct {
+	AppID      string          `json:"appId"`
+	FromUserID string          `json:"fromUserId"`
+	TargetID   string          `json:"targetId"`
+	TargetType int             `json:"targetType"`
+	GroupID    string          `json:"GroupId"`
+	ClassName  string          `json:"classname"`
+	Content    json.RawMessage `json:"content"`
+	DateTime   string          `json:"dateTime"`
+	MsgUID     string          `json:"msgUID"`
+	Source     string          `json:"source"`
+	BusChannel string          `json:"busChannel"`
+}
+
+// HistoryReader 逐条读取历史消息日志，支持 zip、gzip 压缩及未压缩的日志文件
+/*
+	for reader.Next() {
+		record := reader.Record()
+	}
+	if err := reader.Err(); err != nil {
+	}
+*/
+type HistoryReader struct {
+	r       *bufio.Reader
+	closers []io.Closer
+	decoder MsgDecoder
+	record  *HistoryRecord
+	line    int
+	err     error
+}
+
+// NewHistoryReader 从已下载的日志文件创建 HistoryReader，自动识别 zip、gzip 压缩。zip 文件会先写入临时文件
+// options 中只有 WithHistoryDecoder 生效
+func NewHistoryReader(r io.Reader, options ...HistoryOption) (*HistoryReader, error) {
+	reader, err := openHistoryReader(r)
+	if err != nil {
+		return nil, err
+	}
+	reader.decoder = modifyHistoryOptions(options).decoder
+	return reader, nil
+}
+
+// openHistoryReader 按文件头识别压缩格式
+func openHistoryReader(r io.Reader) (*HistoryReader, error) {
+	if f, ok := r.(*os.File); ok {
+		return openHistoryFile(f, false)
+	}
+	br := bufio.NewReader(r)
+	magic, _ := br.Peek(4)
+	if isGzip(magic) {
+		gr, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, err
+		}
+		return newHistoryReader(gr, []io.Closer{gr})
+	}
+	if !isZip(magic) {
+		return newHistoryReader(br, nil)
+	}
+	f, err := ioutil.TempFile("", "rongcloud-history-*")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.Copy(f, br); err != nil {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+		return nil, err
+	}
+	return openHistoryFile(f, true)
+}
+
+// openHistoryFile 按文件头识别压缩格式，remove 为 true 时 Close 后删除文件
+func openHistoryFile(f *os.File, remove bool) (*HistoryReader, error) {
+	// 按添加顺序的逆序关闭，先关闭文件再删除
+	var closers []io.Closer
+	if remove {
+		closers = append(closers, removeFile(f.Name()))
+	}
+	closers = append(closers, f)
+	fail := func(err error) (*HistoryReader, error) {
+		for i := len(closers) - 1; i >= 0; i-- {
+			_ = closers[i].Close()
+		}
+		return nil, err
+	}
+
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return fail(err)
+	}
+	magic := make([]byte, 4)
+	n, _ := io.ReadFull(f, magic)
+	magic = magic[:n]
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return fail(err)
+	}
+
+	switch {
+	case isZip(magic):
+		info, err := f.Stat()
+		if err != nil {
+			return fail(err)
+		}
+		zr, err := zip.NewReader(f, info.Size())
+		if err != nil {
+			return fail(err)
+		}
+		// 依次读取压缩包内的所有文件，文件之间补充换行
+		readers := make([]io.Reader, 0, len(zr.File)*2)
+		for _, zf := range zr.File {
+			if zf.FileInfo().IsDir() {
+				continue
+			}
+			rc, err := zf.Open()
+			if err != nil {
+				return fail(err)
+			}
+			closers = append(closers, rc)
+			readers = append(readers, rc, strings.NewReader("\n"))
+		}
+		return newHistoryReader(io.MultiReader(readers...), closers)
+	case isGzip(magic):
+		gr, err := gzip.NewReader(f)
+		if err != nil {
+			return fail(err)
+		}
+		return newHistoryReader(gr, append(closers, gr))
+	default:
+		return newHistoryReader(f, closers)
+	}
+}
+
+func isZip(magic []byte) bool {
+	return bytes.HasPrefix(magic, []byte("PK\x03\x04"))
+}
+
+func isGzip(magic []byte) bool {
+	return bytes.HasPrefix(magic, []byte{0x1f, 0x8b})
+}
+
+func newHistoryReader(r io.Reader, closers []io.Closer) (*HistoryReader, error) {
+	return &HistoryReader{r: bufio.NewReaderSize(r, 64*1024), closers: closers}, nil
+}
+
+// Next 读取下一条消息，没有更多消息或出错时返回 false，出错原因通过 Err 获取
+func (r *HistoryReader) Next() bool {
+	if r.err != nil {
+		return false
+	}
+	for {
+		line, err := r.r.ReadBytes('\n')
+		if len(line) > 0 {
+			r.line++
+			record, perr := parseHistoryLine(line, r.decoder)
+			if perr != nil {
+				r.err = fmt.Errorf("history line %d: %w", r.line, perr)
+				return false
+			}
+			if record != nil {
+				r.record = record
+				return true
+			}
+		}
+		if err != nil {
+			if err != io.EOF {
+				r.err = err
+			}
+			r.record = nil
+			return false
+		}
+	}
+}
+
+// Record 获取 Next 读取到的消息
+func (r *HistoryReader) Record() *HistoryRecord {
+	return r.record
+}
+
+// Err 获取读取过程中的错误
+func (r *HistoryReader) Err() error {
+	return r.err
+}
+
+// Close 关闭日志文件，并删除下载产生的临时文件
+func (r *HistoryReader) Close() error {
+	var err error
+	for i := len(r.closers) - 1; i >= 0; i-- {
+		if cerr := r.closers[i].Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}
+	r.closers = nil
+	return err
+}
+
+// parseHistoryLine 解析日志中的一行，格式为 "时间 json"，不含 json 的行返回 nil
+func parseHistoryLine(line []byte, decoder MsgDecoder) (*HistoryRecord, error) {
+	i := bytes.IndexByte(line, '{')
+	if i < 0 {
+		return nil, nil
+	}
+	var l historyLine
+	if err := json.Unmarshal(bytes.TrimSpace(line[i:]), &l); err != nil {
+		return nil, err
+	}
+	record := &HistoryRecord{
+		AppID:      l.AppID,
+		FromUserID: l.FromUserID,
+		TargetID:   l.TargetID,
+		TargetType: l.TargetType,
+		GroupID:    l.GroupID,
+		ObjectName: l.ClassName,
+		Content:    l.Content,
+		MsgUID:     l.MsgUID,
+		Source:     l.Source,
+		BusChannel: l.BusChannel,
+	}
+	if l.DateTime != "" {
+		t, err := time.ParseInLocation("2006-01-02 15:04:05", l.DateTime, HistoryLocation)
+		if err != nil {
+			return nil, err
+		}
+		record.DateTime = t
+	}
+	record.Message, record.DecodeErr = decoder.DecodeContent(record.ObjectName, record.Content)
+	return record, nil
+}
+
+// HistoryCheckpoint 记录 HistoryWalk 已处理完成的小时，中断后可从下一个小时继续
+type HistoryCheckpoint interface {
+	// Load 获取最后处理完成的小时，格式为 2018030210，没有记录时返回空字符串
+	Load() (string, error)
+	// Save 保存最后处理完成的小时
+	Save(date string) error
+}
+
+// FileCheckpoint 使用本地文件保存的 HistoryCheckpoint
+type FileCheckpoint string
+
+// Load 实现 HistoryCheckpoint，文件不存在时返回空字符串
+func (c FileCheckpoint) Load() (string, error) {
+	b, err := ioutil.ReadFile(string(c))
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	return strings.TrimSpace(string(b)), err
+}
+
+// Save 实现 HistoryCheckpoint，先写入临时文件并同步到磁盘再重命名，避免中断时文件内容不完整
+func (c FileCheckpoint) Save(date string) error {
+	tmp := string(c) + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	_, err = f.WriteString(date)
+	if err == nil {
+		err = f.Sync()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp, string(c))
+}
+
+// historyOptions HistoryDownload、HistoryWalk 可选参数
+type historyOptions struct {
+	remove     bool
+	checkpoint HistoryCheckpoint
+	tempDir    string
+	decoder    MsgDecoder
+	// downloadTimeout ctx 未设置截止时间时日志文件的下载超时时间
+	downloadTimeout time.Duration
+}
+
+// HistoryOption HistoryDownload、HistoryWalk、NewHistoryReader 可选参数
+type HistoryOption func(*historyOptions)
+
+// WithHistoryRemove 下载成功后调用 HistoryRemove 删除融云服务器上的日志文件。HistoryWalk 在该小时的消息全部处理成功后才删除
+func WithHistoryRemove() HistoryOption {
+	return func(o *historyOptions) {
+		o.remove = true
+	}
+}
+
+// WithHistoryCheckpoint 设置 HistoryWalk 的断点记录，已处理完成的小时会被跳过
+func WithHistoryCheckpoint(checkpoint HistoryCheckpoint) HistoryOption {
+	return func(o *historyOptions) {
+		o.checkpoint = checkpoint
+	}
+}
+
+// WithHistoryTempDir 设置日志文件下载的临时目录，默认为系统临时目录
+func WithHistoryTempDir(dir string) HistoryOption {
+	return func(o *historyOptions) {
+		o.tempDir = dir
+	}
+}
+
+// WithHistoryDecoder 设置消息内容的解码方式，如 MsgDecoder{Strict: true} 时内容包含未定义的字段记录到 HistoryRecord.DecodeErr
+func WithHistoryDecoder(decoder MsgDecoder) HistoryOption {
+	return func(o *historyOptions) {
+		o.decoder = decoder
+	}
+}
+
+// WithHistoryDownloadTimeout 设置 ctx 未设置截止时间时日志文件的下载超时时间，默认 DEFAULT_HISTORY_DOWNLOAD_TIMEOUT
+func WithHistoryDownloadTimeout(timeout time.Duration) HistoryOption {
+	return func(o *historyOptions) {
+		o.downloadTimeout = timeout
+	}
+}
+
+func modifyHistoryOptions(options []HistoryOption) historyOptions {
+	o := historyOptions{downloadTimeout: DEFAULT_HISTORY_DOWNLOAD_TIMEOUT}
+	for _, option := range options {
+		option(&o)
+	}
+	return o
+}
+
+// HistoryDownload 下载指定小时的历史消息日志并返回 HistoryReader，使用完毕后需调用 Close
+// 日志文件下载完成后才开始解析，该小时没有消息时返回的 HistoryReader 不包含任何消息
+/*
+*@param date:精确到小时，例如: 2018030210 表示获取 2018 年 3 月 2 日 10 点至 11 点产生的数据
+*
+*@return *HistoryReader error
+ */
+func (rc *RongCloud) HistoryDownload(date string, options ...HistoryOption) (*HistoryReader, error) {
+	return rc.HistoryDownloadContext(context.Background(), date, options...)
+}
+
+// HistoryDownloadContext 同 HistoryDownload，可通过 ctx 取消下载或设置截止时间
+func (rc *RongCloud) HistoryDownloadContext(ctx context.Context, date string, options ...HistoryOption) (*HistoryReader, error) {
+	if date == "" {
+		return nil, RCErrorNew(1002, "Paramer 'date' is required")
+	}
+	return rc.historyDownload(ctx, date, modifyHistoryOptions(options))
+}
+
+func (rc *RongCloud) historyDownload(ctx context.Context, date string, o historyOptions) (*HistoryReader, error) {
+	history, err := rc.HistoryGetContext(ctx, date)
+	if err != nil {
+		return nil, err
+	}
+	if history.URL == "" {
+		return newHistoryReader(strings.NewReader(""), nil)
+	}
+
+	f, err := rc.downloadFile(ctx, history.URL, o.tempDir, o.downloadTimeout)
+	if err != nil {
+		return nil, err
+	}
+	if o.remove {
+		if err := rc.HistoryRemoveContext(ctx, date); err != nil {
+			_ = f.Close()
+			_ = os.Remove(f.Name())
+			return nil, err
+		}
+	}
+	reader, err := openHistoryFile(f, true)
+	if err != nil {
+		return nil, err
+	}
+	reader.decoder = o.decoder
+	return reader, nil
+}
+
+// downloadFile 下载文件到临时目录，ctx 未设置截止时间时最长下载 timeout
+func (rc *RongCloud) downloadFile(ctx context.Context, url, dir string, timeout time.Duration) (*os.File, error) {
+	if _, ok := ctx.Deadline(); !ok && timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("User-Agent", USERAGENT)
+	resp, err := rc.GetHTTPExecutor().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("download history %s: http status %d", filepath.Base(req.URL.Path), resp.StatusCode)
+	}
+
+	f, err := ioutil.TempFile(dir, "rongcloud-history-*")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.Copy(f, resp.Body); err != nil {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+		return nil, err
+	}
+	return f, nil
+}
+
+// HistoryFunc 处理 HistoryWalk 读取到的消息，返回 error 时停止遍历，该小时不会记录到断点
+type HistoryFunc func(date string, record *HistoryRecord) error
+
+// HistoryWalk 按小时遍历 [start, end) 时间范围内的历史消息日志，时间按北京时间取整到小时
+// 每个小时的消息全部处理成功后记录断点（WithHistoryCheckpoint），再次调用时从断点的下一个小时继续
+/*
+*@param start:开始时间，包含
+*@param end:结束时间，不包含
+*@param fn:消息处理函数
+*
+*@return error
+ */
+func (rc *RongCloud) HistoryWalk(start, end time.Time, fn HistoryFunc, options ...HistoryOption) error {
+	return rc.HistoryWalkContext(context.Background(), start, end, fn, options...)
+}
+
+// HistoryWalkContext 同 HistoryWalk，可通过 ctx 中断遍历
+func (rc *RongCloud) HistoryWalkContext(ctx context.Context, start, end time.Time, fn HistoryFunc, options ...HistoryOption) error {
+	o := modifyHistoryOptions(options)
+	var done string
+	if o.checkpoint != nil {
+		var err error
+		if done, err = o.checkpoint.Load(); err != nil {
+			return err
+		}
+	}
+
+	end = end.In(HistoryLocation)
+	for hour := start.In(HistoryLocation).Truncate(time.Hour); hour.Before(end); hour = hour.Add(time.Hour) {
+		date := hour.Format(HISTORY_DATE_FORMAT)
+		if done != "" && date <= done {
+			continue
+		}
+		if err := rc.walkHistoryHour(ctx, date, fn, o); err != nil {
+			return err
+		}
+		// 删除成功后才记录断点，删除失败时下次调用会重新处理该小时
+		if o.remove {
+			if err := rc.HistoryRemoveContext(ctx, date); err != nil {
+				return err
+			}
+		}
+		if o.checkpoint != nil {
+			if err := o.checkpoint.Save(date); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (rc *RongCloud) walkHistoryHour(ctx context.Context, date string, fn HistoryFunc, o historyOptions) error {
+	// 处理成功后才删除，下载时不删除
+	o.remove = false
+	reader, err := rc.historyDownload(ctx, date, o)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	for reader.Next() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn(date, reader.Record()); err != nil {
+			return err
+		}
+	}
+	return reader.Err()
+}
+
+// removeFile 关闭时删除文件
+type removeFile string
+
+func (f removeFile) Close() error {
+	return os.Remove(string(f))
+}
@@ -0,0 +1,290 @@
+package sdk
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const historyLog = `2018-03-02 10:00:01 {"appId":"appKey","fromUserId":"u1","targetId":"u2","targetType":1,"GroupId":"","classname":"RC:TxtMsg","content":{"content":"hello","extra":""},"dateTime":"2018-03-02 10:00:01.123","msgUID":"UID1","source":"Android"}
+2018-03-02 10:00:02 {"appId":"appKey","fromUserId":"u1","targetId":"g1","targetType":3,"GroupId":"g1","classname":"App:Custom","content":{"foo":"bar"},"dateTime":"2018-03-02 10:00:02","msgUID":"UID2","source":"iOS"}
+
+`
+
+func zipHistory(t *testing.T) []byte {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, err := zw.Create("2018030210.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = w.Write([]byte(historyLog))
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func gzipHistory(t *testing.T) []byte {
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	_, _ = gw.Write([]byte(historyLog))
+	if err := gw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestNewHistoryReader(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"plain", []byte(historyLog)},
+		{"zip", zipHistory(t)},
+		{"gzip", gzipHistory(t)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader, err := NewHistoryReader(bytes.NewReader(tt.data))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer reader.Close()
+
+			var records []*HistoryRecord
+			for reader.Next() {
+				records = append(records, reader.Record())
+			}
+			if err := reader.Err(); err != nil {
+				t.Fatal(err)
+			}
+			if len(records) != 2 {
+				t.Fatalf("got %d records", len(records))
+			}
+			txt, ok := records[0].Message.(*TXTMsg)
+			if !ok || txt.Content != "hello" || records[0].FromUserID != "u1" || records[0].MsgUID != "UID1" {
+				t.Errorf("records[0] = %+v", records[0])
+			}
+			want := time.Date(2018, 3, 2, 10, 0, 1, 123*int(time.Millisecond), HistoryLocation)
+			if !records[0].DateTime.Equal(want) {
+				t.Errorf("DateTime = %v, want %v", records[0].DateTime, want)
+			}
+			if records[1].GroupID != "g1" || records[1].TargetType != 3 || string(records[1].Message.(json.RawMessage)) != `{"foo":"bar"}` {
+				t.Errorf("records[1] = %+v", records[1])
+			}
+		})
+	}
+}
+
+func TestHistoryReader_InvalidLine(t *testing.T) {
+	reader, _ := NewHistoryReader(strings.NewReader("2018-03-02 10:00:01 {invalid\n"))
+	if reader.Next() {
+		t.Fatal("Next should return false")
+	}
+	if reader.Err() == nil || !strings.Contains(reader.Err().Error(), "line 1") {
+		t.Errorf("Err = %v", reader.Err())
+	}
+}
+
+func TestNewHistoryReader_Decoder(t *testing.T) {
+	line := `2018-03-02 10:00:01 {"classname":"RC:TxtMsg","content":{"content":"hello","unknown":1}}` + "\n"
+	for _, strict := range []bool{false, true} {
+		reader, err := NewHistoryReader(strings.NewReader(line), WithHistoryDecoder(MsgDecoder{Strict: strict}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reader.Next() {
+			t.Fatalf("strict %v: %v", strict, reader.Err())
+		}
+		// 严格模式下解码失败记录到 DecodeErr，不中断读取
+		record := reader.Record()
+		if _, ok := record.Message.(*TXTMsg); ok == strict || (record.DecodeErr != nil) != strict {
+			t.Errorf("strict %v: Message = %#v, DecodeErr = %v", strict, record.Message, record.DecodeErr)
+		}
+	}
+}
+
+// historyServer 模拟 /message/history 接口及日志文件下载
+type historyServer struct {
+	*httptest.Server
+	mu      sync.Mutex
+	files   map[string][]byte
+	removed []string
+	// failRemove 为 true 时删除接口返回错误
+	failRemove bool
+}
+
+func newHistoryServer(files map[string][]byte) *historyServer {
+	s := &historyServer{files: files}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		date := r.Form.Get("date")
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		switch r.URL.Path {
+		case "/message/history.json":
+			url := ""
+			if _, ok := s.files[date]; ok {
+				url = s.URL + "/files/" + date + ".zip"
+			}
+			_, _ = fmt.Fprintf(w, `{"code":200,"url":%q}`, url)
+		case "/message/history/delete.json":
+			if s.failRemove {
+				_, _ = w.Write([]byte(`{"code":1000,"errorMessage":"internal error"}`))
+				return
+			}
+			s.removed = append(s.removed, date)
+			_, _ = w.Write([]byte(`{"code":200}`))
+		default:
+			data, ok := s.files[strings.TrimSuffix(filepath.Base(r.URL.Path), ".zip")]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write(data)
+		}
+	}))
+	return s
+}
+
+func TestHistoryDownload(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	server := newHistoryServer(map[string][]byte{"2018030210": zipHistory(t)})
+	defer server.Close()
+	rc := New("appKey", "appSecret", WithRongCloudURI(server.URL))
+
+	reader, err := rc.HistoryDownload("2018030210", WithHistoryRemove(), WithHistoryTempDir(dir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Close()
+	n := 0
+	for reader.Next() {
+		n++
+	}
+	if n != 2 || reader.Err() != nil {
+		t.Errorf("got %d records, err = %v", n, reader.Err())
+	}
+	if len(server.removed) != 1 || server.removed[0] != "2018030210" {
+		t.Errorf("removed = %v", server.removed)
+	}
+}
+
+func TestHistoryDownload_Timeout(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	stop := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/message/history.json" {
+			_, _ = fmt.Fprintf(w, `{"code":200,"url":"http://%s/files/2018030210.zip"}`, r.Host)
+			return
+		}
+		// 下载时只返回部分内容后停止响应
+		w.Header().Set("Content-Length", "1024")
+		_, _ = w.Write([]byte("PK"))
+		w.(http.Flusher).Flush()
+		select {
+		case <-stop:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(stop)
+	rc := New("appKey", "appSecret", WithRongCloudURI(server.URL))
+
+	start := time.Now()
+	_, err := rc.HistoryDownload("2018030210", WithHistoryDownloadTimeout(50*time.Millisecond), WithHistoryTempDir(dir))
+	if err == nil || time.Since(start) > 5*time.Second {
+		t.Errorf("err = %v, elapsed %v", err, time.Since(start))
+	}
+}
+
+func TestHistoryWalk(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	server := newHistoryServer(map[string][]byte{
+		"2018030210": zipHistory(t),
+		"2018030212": zipHistory(t),
+	})
+	defer server.Close()
+	rc := New("appKey", "appSecret", WithRongCloudURI(server.URL))
+	checkpoint := FileCheckpoint(filepath.Join(dir, "checkpoint"))
+
+	start := time.Date(2018, 3, 2, 10, 30, 0, 0, HistoryLocation)
+	end := time.Date(2018, 3, 2, 13, 0, 0, 0, HistoryLocation)
+
+	// 处理 12 点的消息时失败，断点停留在 11 点
+	var dates []string
+	err := rc.HistoryWalkContext(context.Background(), start, end, func(date string, record *HistoryRecord) error {
+		if date == "2018030212" {
+			return fmt.Errorf("failed")
+		}
+		dates = append(dates, date)
+		return nil
+	}, WithHistoryCheckpoint(checkpoint), WithHistoryRemove())
+	if err == nil || err.Error() != "failed" {
+		t.Fatalf("err = %v", err)
+	}
+	if done, _ := checkpoint.Load(); done != "2018030211" {
+		t.Errorf("checkpoint = %q", done)
+	}
+	if len(dates) != 2 || len(server.removed) != 2 {
+		t.Errorf("dates = %v, removed = %v", dates, server.removed)
+	}
+
+	// 从断点继续
+	dates = nil
+	err = rc.HistoryWalk(start, end, func(date string, record *HistoryRecord) error {
+		dates = append(dates, date)
+		return nil
+	}, WithHistoryCheckpoint(checkpoint))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dates) != 2 || dates[0] != "2018030212" {
+		t.Errorf("dates = %v", dates)
+	}
+	if done, _ := checkpoint.Load(); done != "2018030212" {
+		t.Errorf("checkpoint = %q", done)
+	}
+}
+
+func TestHistoryWalk_RemoveFailed(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	server := newHistoryServer(map[string][]byte{"2018030210": zipHistory(t)})
+	server.failRemove = true
+	defer server.Close()
+	rc := New("appKey", "appSecret", WithRongCloudURI(server.URL))
+	checkpoint := FileCheckpoint(filepath.Join(dir, "checkpoint"))
+
+	start := time.Date(2018, 3, 2, 10, 0, 0, 0, HistoryLocation)
+	end := start.Add(time.Hour)
+	walk := func(date string, record *HistoryRecord) error { return nil }
+	if err := rc.HistoryWalk(start, end, walk, WithHistoryCheckpoint(checkpoint), WithHistoryRemove()); err == nil {
+		t.Fatal("expected remove error")
+	}
+	// 删除失败时不记录断点，下次调用重新处理并删除
+	if done, _ := checkpoint.Load(); done != "" {
+		t.Errorf("checkpoint = %q", done)
+	}
+	server.failRemove = false
+	if err := rc.HistoryWalk(start, end, walk, WithHistoryCheckpoint(checkpoint), WithHistoryRemove()); err != nil {
+		t.Fatal(err)
+	}
+	if len(server.removed) != 1 {
+		t.Errorf("removed = %v", server.removed)
+	}
+}
@@ -1,24 +1,112 @@
 package sdk
 
 import (
+	"bytes"
 	"compress/gzip"
+	"context"
 	"encoding/json"
-	"github.com/astaxie/beego/httplib"
+	"errors"
+	"io"
 	"io/ioutil"
 	"net"
+	"net/http"
 	"net/url"
 	"os"
 	"syscall"
+	"time"
 )
 
-func (rc *RongCloud) do(b *httplib.BeegoHTTPRequest) (body []byte, err error) {
-	return rc.httpRequest(b)
+// HTTPExecutor 发送 http 请求并返回响应，*http.Client 即实现了该接口
+// 默认使用基于 net/http 的实现，可通过 WithHTTPExecutor 替换，用于测试、自定义 http 客户端（如增加服务网格请求头）或录制请求等场景
+type HTTPExecutor interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// request 融云 API 请求
+// path 为不含域名及后缀的接口路径，如 /message/private/publish，API 地址和签名在发送时填充
+type request struct {
+	path   string
+	params url.Values
+	body   []byte
+	header http.Header
+}
+
+// newRequest 创建融云 API 请求
+func newRequest(path string) *request {
+	return &request{
+		path:   path,
+		params: url.Values{},
+	}
+}
+
+// Param 增加表单参数，同名参数可重复添加
+func (r *request) Param(key, value string) *request {
+	r.params.Add(key, value)
+	return r
+}
+
+// rateLimitWeight 限流时本次请求计为的调用次数，见 rateLimitWeights
+func (r *request) rateLimitWeight() int {
+	if key, ok := rateLimitWeights[r.path]; ok && len(r.params[key]) > 0 {
+		return len(r.params[key])
+	}
+	return 1
+}
+
+// JSONBody 设置 json 格式的请求体，设置后表单参数不再发送
+func (r *request) JSONBody(obj interface{}) (*request, error) {
+	body, err := json.Marshal(obj)
+	if err != nil {
+		return r, err
+	}
+	r.body = body
+	return r, nil
+}
+
+// newHTTPRequest 根据 API 地址生成 http.Request
+func (r *request) newHTTPRequest(ctx context.Context, uri string) (*http.Request, error) {
+	var body io.Reader
+	contentType := "application/x-www-form-urlencoded"
+	if r.body != nil {
+		body = bytes.NewReader(r.body)
+		contentType = "application/json"
+	} else {
+		body = bytes.NewBufferString(r.params.Encode())
+	}
+
+	req, err := http.NewRequest(http.MethodPost, uri+r.path+"."+ReqType, body)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range r.header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", contentType)
+	return req.WithContext(ctx), nil
+}
+
+func (rc *RongCloud) do(ctx context.Context, r *request) (body []byte, err error) {
+	ctx, span := rc.startSpan(ctx, r)
+	defer func() {
+		endSpan(span, err)
+	}()
+
+	if len(rc.interceptors) == 0 {
+		return rc.doRetry(ctx, r)
+	}
+	call := &Call{
+		Path:   r.path,
+		Params: r.params,
+		Body:   r.body,
+		Header: http.Header{},
+	}
+	return chainInterceptors(rc.interceptors, rc.invoke)(ctx, call)
 }
 
 // 需要切换域名的网络错误
 func isNetError(err error) bool {
-	netErr, ok := err.(net.Error)
-	if !ok {
+	var netErr net.Error
+	if !errors.As(err, &netErr) {
 		return false
 	}
 	// 超时
@@ -26,8 +114,7 @@ func isNetError(err error) bool {
 		return true
 	}
 
-	var opErr *net.OpError
-	opErr, ok = netErr.(*net.OpError)
+	opErr, ok := netErr.(*net.OpError)
 	if !ok {
 		//  url 错误
 		urlErr, ok := netErr.(*url.Error)
@@ -57,35 +144,100 @@ func isNetError(err error) bool {
 	return false
 }
 
-func (rc *RongCloud) httpRequest(b *httplib.BeegoHTTPRequest) (body []byte, err error) {
-	// 使用全局 httpClient，解决 http 打开端口过多问题
-	b.SetTransport(rc.globalTransport)
+// httpRequest 发送一次请求，返回 http 状态码供重试判断
+func (rc *RongCloud) httpRequest(ctx context.Context, r *request) (body []byte, statusCode int, err error) {
+	var requestID string
+	defer func() {
+		if err != nil {
+			err = newError(r.path, statusCode, requestID, err)
+		}
+	}()
+
+	// 限流等待不计入请求超时时间
+	if rc.rateLimiter != nil {
+		if err := rc.rateLimiter.WaitN(ctx, rc.AppKey(), r.path, r.rateLimitWeight()); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	// parent 调用方的 ctx，用于区分调用方取消及 SDK 自身的请求超时
+	parent := ctx
+	if rc.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, rc.timeout*time.Second)
+		defer cancel()
+	}
+
+	if rc.metrics != nil {
+		start := time.Now()
+		defer func() {
+			rc.metrics.ObserveRequest(r.path, statusCode, responseCode(err), time.Since(start))
+		}()
+	}
+
+	e := rc.currentEndpoint()
+	span := spanFromContext(ctx)
+	span.SetAttribute(TraceAttrHost, e.uri)
+	defer func() {
+		span.SetAttribute(TraceAttrStatusCode, statusCode)
+	}()
+
+	req, err := r.newHTTPRequest(ctx, e.uri)
+	if err != nil {
+		return nil, 0, err
+	}
+	rc.fillHeader(req)
 
-	resp, err := b.DoRequest()
+	resp, err := rc.GetHTTPExecutor().Do(req)
 	if err != nil {
-		if isNetError(err) {
-			rc.ChangeURI()
+		// 需要切换域名的网络错误，调用方取消或超时不代表地址不可用
+		if isNetError(err) && parent.Err() == nil {
+			rc.reportEndpoint(e, err)
 		}
-		return nil, err
+		return nil, 0, err
+	}
+	requestID = resp.Header.Get("X-Request-ID")
+	if resp.StatusCode >= 500 && resp.StatusCode < 600 {
+		rc.reportEndpoint(e, errors.New(resp.Status))
+	} else {
+		rc.reportEndpoint(e, nil)
 	}
 	if resp.Body == nil {
-		return nil, nil
+		return nil, resp.StatusCode, nil
 	}
 	defer resp.Body.Close()
-	rc.checkStatusCode(resp)
 	if resp.Header.Get("Content-Encoding") == "gzip" {
 		reader, err := gzip.NewReader(resp.Body)
 		if err != nil {
-			return nil, err
+			return nil, resp.StatusCode, err
 		}
 		body, err = ioutil.ReadAll(reader)
 	} else {
 		body, err = ioutil.ReadAll(resp.Body)
 	}
+	if err != nil {
+		return body, resp.StatusCode, err
+	}
 	if err = checkHTTPResponseCode(body); err != nil {
-		return nil, err
+		return body, resp.StatusCode, err
+	}
+	return body, resp.StatusCode, nil
+}
+
+// responseCode 获取请求结果对应的融云返回码，无法获取时返回 0
+func responseCode(err error) int {
+	if err == nil {
+		return 200
+	}
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Code
+	}
+	var code CodeResult
+	if errors.As(err, &code) {
+		return code.Code
 	}
-	return body, err
+	return 0
 }
 
 func checkHTTPResponseCode(rep []byte) error {
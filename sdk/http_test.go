@@ -0,0 +1,161 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRongCloud_doContext(t *testing.T) {
+	block := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-block:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(block)
+
+	rc := New("appKey", "appSecret", WithRongCloudURI(server.URL))
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := rc.UserUpdateContext(ctx, "u01", "name", "http://portrait")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if time.Since(start) > 5*time.Second {
+		t.Error("request was not aborted by ctx")
+	}
+}
+
+type executorFunc func(req *http.Request) (*http.Response, error)
+
+func (f executorFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestWithHTTPExecutor(t *testing.T) {
+	var got *http.Request
+	var body []byte
+	executor := executorFunc(func(req *http.Request) (*http.Response, error) {
+		got = req
+		body, _ = ioutil.ReadAll(req.Body)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Body:       ioutil.NopCloser(bytes.NewBufferString(`{"code":200,"token":"t01","userId":"u01"}`)),
+		}, nil
+	})
+
+	rc := New("appKey", "appSecret", WithRongCloudURI("http://api.test.com"), WithHTTPExecutor(executor))
+	user, err := rc.UserRegister("u01", "name", "http://portrait")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.Token != "t01" {
+		t.Errorf("invalid token: %s", user.Token)
+	}
+
+	if got.URL.String() != "http://api.test.com/user/getToken.json" {
+		t.Errorf("invalid url: %s", got.URL)
+	}
+	if got.Header.Get("Content-Type") != "application/x-www-form-urlencoded" {
+		t.Errorf("invalid content type: %s", got.Header.Get("Content-Type"))
+	}
+	if string(body) != "name=name&portraitUri=http%3A%2F%2Fportrait&userId=u01" {
+		t.Errorf("invalid body: %s", body)
+	}
+	if got.Header.Get("App-Key") != "appKey" {
+		t.Errorf("invalid app key: %s", got.Header.Get("App-Key"))
+	}
+	h := sha1.New()
+	h.Write([]byte("appSecret" + got.Header.Get("Nonce") + got.Header.Get("Timestamp")))
+	if got.Header.Get("Signature") != fmt.Sprintf("%x", h.Sum(nil)) {
+		t.Error("invalid signature")
+	}
+}
+
+func TestRongCloud_SetHTTPExecutor(t *testing.T) {
+	var got *http.Request
+	var body []byte
+	rc := New("appKey", "appSecret", WithRongCloudURI("http://api.test.com"))
+	rc.SetHTTPExecutor(executorFunc(func(req *http.Request) (*http.Response, error) {
+		got = req
+		body, _ = ioutil.ReadAll(req.Body)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Body:       ioutil.NopCloser(bytes.NewBufferString(`{"code":1004,"errorMessage":"signature error"}`)),
+		}, nil
+	}))
+
+	err := rc.TagSet(Tag{UserID: "u01", Tags: []string{"a"}})
+	var code CodeResult
+	if !errors.As(err, &code) || code.Code != 1004 {
+		t.Errorf("expected code 1004, got %v", err)
+	}
+	if got.Header.Get("Content-Type") != "application/json" {
+		t.Errorf("invalid content type: %s", got.Header.Get("Content-Type"))
+	}
+	if string(body) != `{"userId":"u01","tags":["a"]}` {
+		t.Errorf("invalid body: %s", body)
+	}
+}
+
+// truncatedBody 读取部分内容后返回错误
+type truncatedBody struct {
+	data []byte
+}
+
+func (b *truncatedBody) Read(p []byte) (int, error) {
+	if len(b.data) == 0 {
+		return 0, io.ErrUnexpectedEOF
+	}
+	n := copy(p, b.data)
+	b.data = b.data[n:]
+	return n, nil
+}
+
+func (b *truncatedBody) Close() error {
+	return nil
+}
+
+func TestRongCloud_httpRequestReadError(t *testing.T) {
+	rc := New("appKey", "appSecret", WithRongCloudURI("http://api.test.com"), WithHTTPExecutor(executorFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Body:       &truncatedBody{data: []byte(`{"code":200}`)},
+		}, nil
+	})))
+	if err := rc.TagSet(Tag{UserID: "u01", Tags: []string{"a"}}); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("expected io.ErrUnexpectedEOF, got %v", err)
+	}
+}
+
+func TestRongCloud_GetHTTPExecutor(t *testing.T) {
+	rc := New("appKey", "appSecret")
+	executor := rc.GetHTTPExecutor()
+	if client, ok := executor.(*http.Client); !ok || client.Transport != rc.GetHttpTransport() {
+		t.Fatalf("default executor = %#v", executor)
+	}
+	if rc.GetHTTPExecutor() != executor {
+		t.Error("default executor should be reused")
+	}
+	transport := &http.Transport{}
+	rc.SetHttpTransport(transport)
+	if client := rc.GetHTTPExecutor().(*http.Client); client.Transport != transport {
+		t.Error("SetHttpTransport should replace the default executor")
+	}
+}
@@ -0,0 +1,52 @@
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Call 一次 API 调用的信息，拦截器可在调用前修改请求，在调用后读取结果
+type Call struct {
+	Path   string      // 不含域名及后缀的接口路径，如 /message/private/publish
+	Params url.Values  // 表单参数，JSON 请求时为空
+	Body   []byte      // JSON 请求体，表单请求时为 nil
+	Header http.Header // 附加的请求头，签名相关的请求头在发送时填充，不能被覆盖
+
+	Response []byte        // 响应内容，调用完成后填充
+	Duration time.Duration // 调用耗时（包含重试），调用完成后填充
+	Err      error         // 调用结果，调用完成后填充
+}
+
+// Invoker 执行 API 调用
+type Invoker func(ctx context.Context, call *Call) ([]byte, error)
+
+// Interceptor API 调用拦截器，用于日志、请求头注入、监控、故障注入等
+// 拦截器通过调用 invoker 继续执行后续的拦截器及请求，不调用 invoker 直接返回即可中断调用
+type Interceptor func(ctx context.Context, call *Call, invoker Invoker) ([]byte, error)
+
+// chainInterceptors 将拦截器串联为一个 Invoker，先添加的拦截器先执行
+func chainInterceptors(interceptors []Interceptor, invoker Invoker) Invoker {
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, next := interceptors[i], invoker
+		invoker = func(ctx context.Context, call *Call) ([]byte, error) {
+			return interceptor(ctx, call, next)
+		}
+	}
+	return invoker
+}
+
+// invoke 拦截器链最内层，发送请求并填充调用结果
+func (rc *RongCloud) invoke(ctx context.Context, call *Call) ([]byte, error) {
+	r := &request{
+		path:   call.Path,
+		params: call.Params,
+		body:   call.Body,
+		header: call.Header,
+	}
+	start := time.Now()
+	body, err := rc.doRetry(ctx, r)
+	call.Response, call.Duration, call.Err = body, time.Since(start), err
+	return body, err
+}
@@ -0,0 +1,75 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWithInterceptors(t *testing.T) {
+	var header, userID string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Get("X-Proxy-Auth")
+		userID = r.FormValue("userId")
+		_, _ = w.Write([]byte(`{"code":1002,"errorMessage":"invalid"}`))
+	}))
+	defer server.Close()
+
+	var order []string
+	var got Call
+	rc := New("appKey", "appSecret",
+		WithRongCloudURI(server.URL),
+		WithInterceptors(
+			func(ctx context.Context, call *Call, invoker Invoker) ([]byte, error) {
+				order = append(order, "first")
+				body, err := invoker(ctx, call)
+				got = *call
+				return body, err
+			},
+			func(ctx context.Context, call *Call, invoker Invoker) ([]byte, error) {
+				order = append(order, "second")
+				call.Header.Set("X-Proxy-Auth", "token")
+				call.Params.Set("userId", "u02")
+				return invoker(ctx, call)
+			},
+		),
+	)
+
+	err := rc.UserUpdate("u01", "name", "")
+	var code CodeResult
+	if !errors.As(err, &code) || code.Code != 1002 {
+		t.Fatalf("expected code 1002, got %v", err)
+	}
+	if len(order) != 2 || order[0] != "first" || order[1] != "second" {
+		t.Errorf("invalid order: %v", order)
+	}
+	if header != "token" || userID != "u02" {
+		t.Errorf("call was not modified: %s, %s", header, userID)
+	}
+	if got.Path != "/user/refresh" || got.Err != err || string(got.Response) != `{"code":1002,"errorMessage":"invalid"}` || got.Duration <= 0 {
+		t.Errorf("invalid call: %+v", got)
+	}
+}
+
+func TestWithInterceptors_shortCircuit(t *testing.T) {
+	injected := errors.New("injected fault")
+	rc := New("appKey", "appSecret",
+		WithHTTPExecutor(executorFunc(func(req *http.Request) (*http.Response, error) {
+			t.Fatal("request should not be sent")
+			return nil, nil
+		})),
+		WithInterceptors(func(ctx context.Context, call *Call, invoker Invoker) ([]byte, error) {
+			if call.Path == "/message/private/publish" {
+				return nil, injected
+			}
+			return invoker(ctx, call)
+		}),
+	)
+
+	err := rc.PrivateSend("u01", []string{"u02"}, "RC:TxtMsg", &TXTMsg{Content: "hello"}, "", "", 1, 0, 1, 0, 0)
+	if err != injected {
+		t.Errorf("expected injected fault, got %v", err)
+	}
+}
@@ -9,18 +9,19 @@
 package sdk
 
 import (
+	"context"
 	"encoding/json"
 	"strconv"
-	"time"
-
-	"github.com/astaxie/beego/httplib"
 )
 
-// rcMsg rcMsg接口
-type rcMsg interface {
+// RCMsg 消息内容接口，融云内置消息及自定义消息需实现该接口
+type RCMsg interface {
 	ToString() (string, error)
 }
 
+// rcMsg 同 RCMsg
+type rcMsg = RCMsg
+
 // MsgUserInfo 融云内置消息用户信息
 type MsgUserInfo struct {
 	ID       string `json:"id"`
@@ -185,9 +186,9 @@ type BroadcastRecallContent struct {
 // ChatRoomKVNotiMessage 聊天室属性通知消息
 type ChatRoomKVNotiMessage struct {
 	Type  int    `json:"type"`
-	Key   string `json:"string"`
-	Value string `json:"string"`
-	Extra string `json:"string"`
+	Key   string `json:"key"`
+	Value string `json:"value"`
+	Extra string `json:"extra"`
 }
 
 // ToString ChatRoomKVNotiMessage
@@ -467,16 +468,73 @@ func modifyMsgOptions(options []MsgOption) msgOptions {
 	return defaultMsgOptions
 }
 
+// MsgOptions MsgOption 设置的扩展参数，用于序列化 MsgOption，如持久化待发送的消息
+type MsgOptions struct {
+	IsMentioned      int    `json:"isMentioned,omitempty"`
+	ContentAvailable int    `json:"contentAvailable,omitempty"`
+	VerifyBlacklist  int    `json:"verifyBlacklist,omitempty"`
+	Expansion        bool   `json:"expansion,omitempty"`
+	DisablePush      bool   `json:"disablePush,omitempty"`
+	PushExt          string `json:"pushExt,omitempty"`
+	PushContent      string `json:"pushContent,omitempty"`
+	PushData         string `json:"pushData,omitempty"`
+	BusChannel       string `json:"busChannel,omitempty"`
+	IsAdmin          int    `json:"isAdmin,omitempty"`
+	IsDelete         int    `json:"isDelete,omitempty"`
+}
+
+// MsgOptionsOf 获取 options 设置后的扩展参数
+func MsgOptionsOf(options []MsgOption) MsgOptions {
+	o := modifyMsgOptions(options)
+	return MsgOptions{
+		IsMentioned:      o.isMentioned,
+		ContentAvailable: o.contentAvailable,
+		VerifyBlacklist:  o.verifyBlacklist,
+		Expansion:        o.expansion,
+		DisablePush:      o.disablePush,
+		PushExt:          o.pushExt,
+		PushContent:      o.pushContent,
+		PushData:         o.pushData,
+		BusChannel:       o.busChannel,
+		IsAdmin:          o.isAdmin,
+		IsDelete:         o.isDelete,
+	}
+}
+
+// Options 转换为与原 MsgOption 效果相同的 MsgOption
+func (o MsgOptions) Options() []MsgOption {
+	return []MsgOption{func(options *msgOptions) {
+		*options = msgOptions{
+			isMentioned:      o.IsMentioned,
+			contentAvailable: o.ContentAvailable,
+			verifyBlacklist:  o.VerifyBlacklist,
+			expansion:        o.Expansion,
+			disablePush:      o.DisablePush,
+			pushExt:          o.PushExt,
+			pushContent:      o.PushContent,
+			pushData:         o.PushData,
+			busChannel:       o.BusChannel,
+			isAdmin:          o.IsAdmin,
+			isDelete:         o.IsDelete,
+		}
+	}}
+}
+
 /**
  * @name: MessageBroadcastRecall
  * @test:
- * @msg:广播消息撤回
+ * @msg:广播消息撤回，与广播消息使用同一接口，启用客户端限流时计入推送及广播的限额
  * @param string userId
  * @param string objectName
  * @param BroadcastRecallContent content
  * @return: error
  */
 func (rc *RongCloud) MessageBroadcastRecall(userId string, objectName string, content BroadcastRecallContent) error {
+	return rc.MessageBroadcastRecallContext(context.Background(), userId, objectName, content)
+}
+
+// MessageBroadcastRecallContext 同 MessageBroadcastRecall，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) MessageBroadcastRecallContext(ctx context.Context, userId string, objectName string, content BroadcastRecallContent) error {
 	if userId == "" {
 		return RCErrorNew(1002, "Paramer 'userId' is required")
 	}
@@ -485,9 +543,7 @@ func (rc *RongCloud) MessageBroadcastRecall(userId string, objectName string, co
 		return RCErrorNew(1002, "Paramer 'objectName' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/message/broadcast." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/message/broadcast")
 	req.Param("fromUserId", userId)
 	req.Param("objectName", objectName)
 
@@ -497,7 +553,7 @@ func (rc *RongCloud) MessageBroadcastRecall(userId string, objectName string, co
 	}
 	req.Param("content", msg)
 
-	_, err = rc.do(req)
+	_, err = rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 	}
@@ -518,6 +574,12 @@ func (rc *RongCloud) MessageBroadcastRecall(userId string, objectName string, co
  * @return: error
  */
 func (rc *RongCloud) ChatRoomRecall(userId string, targetId string, messageId string, sentTime int,
+	options ...MsgOption) error {
+	return rc.ChatRoomRecallContext(context.Background(), userId, targetId, messageId, sentTime, options...)
+}
+
+// ChatRoomRecallContext 同 ChatRoomRecall，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) ChatRoomRecallContext(ctx context.Context, userId string, targetId string, messageId string, sentTime int,
 	options ...MsgOption) error {
 	if userId == "" {
 		return RCErrorNew(1002, "Paramer 'userId' is required")
@@ -537,9 +599,7 @@ func (rc *RongCloud) ChatRoomRecall(userId string, targetId string, messageId st
 
 	extraOptins := modifyMsgOptions(options)
 
-	req := httplib.Post(rc.rongCloudURI + "/message/recall." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/message/recall")
 
 	req.Param("fromUserId", userId)
 	req.Param("conversationType", strconv.Itoa(4))
@@ -554,7 +614,7 @@ func (rc *RongCloud) ChatRoomRecall(userId string, targetId string, messageId st
 		req.Param("busChannel", extraOptins.busChannel)
 	}
 
-	_, err := rc.do(req)
+	_, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 	}
@@ -575,6 +635,12 @@ func (rc *RongCloud) ChatRoomRecall(userId string, targetId string, messageId st
  * @return: error
  */
 func (rc *RongCloud) SystemRecall(userId string, targetId string, messageId string, sentTime int,
+	options ...MsgOption) error {
+	return rc.SystemRecallContext(context.Background(), userId, targetId, messageId, sentTime, options...)
+}
+
+// SystemRecallContext 同 SystemRecall，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) SystemRecallContext(ctx context.Context, userId string, targetId string, messageId string, sentTime int,
 	options ...MsgOption) error {
 	if userId == "" {
 		return RCErrorNew(1002, "Paramer 'userId' is required")
@@ -594,9 +660,7 @@ func (rc *RongCloud) SystemRecall(userId string, targetId string, messageId stri
 
 	extraOptins := modifyMsgOptions(options)
 
-	req := httplib.Post(rc.rongCloudURI + "/message/recall." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/message/recall")
 
 	req.Param("fromUserId", userId)
 	req.Param("conversationType", strconv.Itoa(6))
@@ -611,7 +675,7 @@ func (rc *RongCloud) SystemRecall(userId string, targetId string, messageId stri
 		req.Param("busChannel", extraOptins.busChannel)
 	}
 
-	_, err := rc.do(req)
+	_, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 	}
@@ -622,7 +686,7 @@ func (rc *RongCloud) SystemRecall(userId string, targetId string, messageId stri
 /*
  *@param  senderID:发送人用户 ID。
  *@param  targetID:接收用户 ID。可以实现向多人发送消息，每次上限为 1000 人。
- *@param  objectName:发送的消息类型。
+ *@param  objectName:发送的消息类型，为空时根据 msg 的类型推断，见 RegisterMsgType。
  *@param  msg:消息内容。
  *@param  pushContent:定义显示的 Push 内容，如果 objectName 为融云内置消息类型时，则发送后用户一定会收到 Push 信息。如果为自定义消息，则 pushContent 为自定义消息显示的 Push 内容，如果不传则用户不会收到 Push 通知。
  *@param  pushData:针对 iOS 平台为 Push 通知时附加到 payload 中，Android 客户端收到推送消息时对应字段名为 pushData。
@@ -636,6 +700,15 @@ func (rc *RongCloud) SystemRecall(userId string, targetId string, messageId stri
  *@return error
  */
 func (rc *RongCloud) PrivateSend(senderID string, targetID []string, objectName string, msg rcMsg,
+	pushContent, pushData string, count, verifyBlacklist, isPersisted, isIncludeSender, contentAvailable int,
+	options ...MsgOption) error {
+	return rc.PrivateSendContext(context.Background(), senderID, targetID, objectName, msg,
+		pushContent, pushData, count, verifyBlacklist, isPersisted, isIncludeSender,
+		contentAvailable, options...)
+}
+
+// PrivateSendContext 同 PrivateSend，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) PrivateSendContext(ctx context.Context, senderID string, targetID []string, objectName string, msg rcMsg,
 	pushContent, pushData string, count, verifyBlacklist, isPersisted, isIncludeSender, contentAvailable int,
 	options ...MsgOption) error {
 	if senderID == "" {
@@ -648,13 +721,15 @@ func (rc *RongCloud) PrivateSend(senderID string, targetID []string, objectName
 
 	extraOptins := modifyMsgOptions(options)
 
-	req := httplib.Post(rc.rongCloudURI + "/message/private/publish." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/message/private/publish")
 	req.Param("fromUserId", senderID)
 	for _, v := range targetID {
 		req.Param("toUserId", v)
 	}
+	objectName, err := resolveObjectName(objectName, msg)
+	if err != nil {
+		return err
+	}
 	req.Param("objectName", objectName)
 
 	msgr, err := msg.ToString()
@@ -678,7 +753,7 @@ func (rc *RongCloud) PrivateSend(senderID string, targetID []string, objectName
 		req.Param("busChannel", extraOptins.busChannel)
 	}
 
-	_, err = rc.do(req)
+	_, err = rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 	}
@@ -688,13 +763,21 @@ func (rc *RongCloud) PrivateSend(senderID string, targetID []string, objectName
 // 私聊状态消息发送
 // senderID: 发送人用户 ID。
 // targetID: 接收用户 ID，支持向多人发送消息，每次上限为 1000 人。
-// objectName: 消息类型
+// objectName: 消息类型，为空时根据 msg 的类型推断，见 RegisterMsgType
 // msg: 所发送消息的内容
 // verifyBlacklist: 是否过滤发送人黑名单列表，0 表示为不过滤、 1 表示为过滤，默认为 0 不过滤。
 // isIncludeSender: 发送用户自己是否接收消息，0 表示为不接收，1 表示为接收，默认为 0 不接收。
 func (rc *RongCloud) PrivateStatusSend(senderID string, targetID []string, objectName string, msg rcMsg,
 	verifyBlacklist int, isIncludeSender int,
 	options ...MsgOption) error {
+	return rc.PrivateStatusSendContext(context.Background(), senderID, targetID, objectName, msg,
+		verifyBlacklist, isIncludeSender, options...)
+}
+
+// PrivateStatusSendContext 同 PrivateStatusSend，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) PrivateStatusSendContext(ctx context.Context, senderID string, targetID []string, objectName string, msg rcMsg,
+	verifyBlacklist int, isIncludeSender int,
+	options ...MsgOption) error {
 
 	if senderID == "" {
 		return RCErrorNew(1002, "Paramer 'senderID' is required")
@@ -706,13 +789,15 @@ func (rc *RongCloud) PrivateStatusSend(senderID string, targetID []string, objec
 
 	extraOptins := modifyMsgOptions(options)
 
-	req := httplib.Post(rc.rongCloudURI + "/statusmessage/private/publish." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/statusmessage/private/publish")
 	req.Param("fromUserId", senderID)
 	for _, v := range targetID {
 		req.Param("toUserId", v)
 	}
+	objectName, err := resolveObjectName(objectName, msg)
+	if err != nil {
+		return err
+	}
 	req.Param("objectName", objectName)
 
 	msgr, err := msg.ToString()
@@ -726,7 +811,7 @@ func (rc *RongCloud) PrivateStatusSend(senderID string, targetID []string, objec
 		req.Param("busChannel", extraOptins.busChannel)
 	}
 
-	_, err = rc.do(req)
+	_, err = rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 	}
@@ -746,6 +831,12 @@ func (rc *RongCloud) PrivateStatusSend(senderID string, targetID []string, objec
 *@return error
  */
 func (rc *RongCloud) PrivateRecall(senderID, targetID, uID string, sentTime int,
+	options ...MsgOption) error {
+	return rc.PrivateRecallContext(context.Background(), senderID, targetID, uID, sentTime, options...)
+}
+
+// PrivateRecallContext 同 PrivateRecall，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) PrivateRecallContext(ctx context.Context, senderID, targetID, uID string, sentTime int,
 	options ...MsgOption) error {
 	if senderID == "" {
 		return RCErrorNew(1002, "Paramer 'senderID' is required")
@@ -757,9 +848,7 @@ func (rc *RongCloud) PrivateRecall(senderID, targetID, uID string, sentTime int,
 
 	extraOptins := modifyMsgOptions(options)
 
-	req := httplib.Post(rc.rongCloudURI + "/message/recall." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/message/recall")
 	req.Param("fromUserId", senderID)
 	req.Param("targetId", targetID)
 	req.Param("messageUID", uID)
@@ -773,7 +862,7 @@ func (rc *RongCloud) PrivateRecall(senderID, targetID, uID string, sentTime int,
 		req.Param("busChannel", extraOptins.busChannel)
 	}
 
-	_, err := rc.do(req)
+	_, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 	}
@@ -791,6 +880,13 @@ func (rc *RongCloud) PrivateRecall(senderID, targetID, uID string, sentTime int,
  *@return error
  */
 func (rc *RongCloud) PrivateSendTemplate(senderID, objectName string, template TXTMsg, content []TemplateMsgContent,
+	options ...MsgOption) error {
+	return rc.PrivateSendTemplateContext(context.Background(), senderID, objectName, template,
+		content, options...)
+}
+
+// PrivateSendTemplateContext 同 PrivateSendTemplate，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) PrivateSendTemplateContext(ctx context.Context, senderID, objectName string, template TXTMsg, content []TemplateMsgContent,
 	options ...MsgOption) error {
 	if senderID == "" {
 		return RCErrorNew(1002, "Paramer 'senderID' is required")
@@ -798,9 +894,7 @@ func (rc *RongCloud) PrivateSendTemplate(senderID, objectName string, template T
 
 	extraOptins := modifyMsgOptions(options)
 
-	req := httplib.Post(rc.rongCloudURI + "/message/private/publish_template." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/message/private/publish_template")
 
 	var toUserIDs, push, pushData []string
 	var values []map[string]string
@@ -841,7 +935,7 @@ func (rc *RongCloud) PrivateSendTemplate(senderID, objectName string, template T
 		return err
 	}
 
-	_, err = rc.do(req)
+	_, err = rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 	}
@@ -852,7 +946,7 @@ func (rc *RongCloud) PrivateSendTemplate(senderID, objectName string, template T
 /*
  *@param  senderID:发送人用户 ID 。
  *@param  targetID:接收群ID.
- *@param  objectName:消息类型。
+ *@param  objectName:消息类型，为空时根据 msg 的类型推断，见 RegisterMsgType。
  *@param  userID:群定向消群定向消息功能，向群中指定的一个或多个用户发送消息，群中其他用户无法收到该消息，当 targetID 为一个群组时此参数有效。注：如果开通了“单群聊消息云存储”功能，群定向消息不会存储到云端，向群中部分用户发送消息阅读状态回执时可使用此功能。（可选）
  *@param  msg:发送消息内容
  *@param  pushContent:定义显示的 Push 内容，如果 objectName 为融云内置消息类型时，则发送后用户一定会收到 Push 信息. 如果为自定义消息，则 pushContent 为自定义消息显示的 Push 内容，如果不传则用户不会收到 Push 通知。
@@ -864,6 +958,14 @@ func (rc *RongCloud) PrivateSendTemplate(senderID, objectName string, template T
  *@return error
  */
 func (rc *RongCloud) GroupSend(senderID string, targetID, userID []string, objectName string, msg rcMsg,
+	pushContent string, pushData string, isPersisted, isIncludeSender int,
+	options ...MsgOption) error {
+	return rc.GroupSendContext(context.Background(), senderID, targetID, userID, objectName, msg,
+		pushContent, pushData, isPersisted, isIncludeSender, options...)
+}
+
+// GroupSendContext 同 GroupSend，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) GroupSendContext(ctx context.Context, senderID string, targetID, userID []string, objectName string, msg rcMsg,
 	pushContent string, pushData string, isPersisted, isIncludeSender int,
 	options ...MsgOption) error {
 	if senderID == "" {
@@ -876,13 +978,15 @@ func (rc *RongCloud) GroupSend(senderID string, targetID, userID []string, objec
 
 	extraOptins := modifyMsgOptions(options)
 
-	req := httplib.Post(rc.rongCloudURI + "/message/group/publish." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/message/group/publish")
 	req.Param("fromUserId", senderID)
 	for _, v := range targetID {
 		req.Param("toGroupId", v)
 	}
+	objectName, err := resolveObjectName(objectName, msg)
+	if err != nil {
+		return err
+	}
 	req.Param("objectName", objectName)
 	msgr, err := msg.ToString()
 	if err != nil {
@@ -910,7 +1014,7 @@ func (rc *RongCloud) GroupSend(senderID string, targetID, userID []string, objec
 		req.Param("busChannel", extraOptins.busChannel)
 	}
 
-	_, err = rc.do(req)
+	_, err = rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 	}
@@ -920,13 +1024,21 @@ func (rc *RongCloud) GroupSend(senderID string, targetID, userID []string, objec
 // 群聊状态消息发送
 // senderID: 发送人用户 ID。
 // toGroupIds: 接收群ID，提供多个本参数可以实现向多群发送消息，最多不超过 3 个群组。
-// objectName: 消息类型
+// objectName: 消息类型，为空时根据 msg 的类型推断，见 RegisterMsgType
 // msg: 所发送消息的内容
 // verifyBlacklist: 是否过滤发送人黑名单列表，0 表示为不过滤、 1 表示为过滤，默认为 0 不过滤。
 // isIncludeSender: 发送用户自己是否接收消息，0 表示为不接收，1 表示为接收，默认为 0 不接收。
 func (rc *RongCloud) GroupStatusSend(senderID string, toGroupIds []string, objectName string, msg rcMsg,
 	verifyBlacklist int, isIncludeSender int,
 	options ...MsgOption) error {
+	return rc.GroupStatusSendContext(context.Background(), senderID, toGroupIds, objectName, msg,
+		verifyBlacklist, isIncludeSender, options...)
+}
+
+// GroupStatusSendContext 同 GroupStatusSend，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) GroupStatusSendContext(ctx context.Context, senderID string, toGroupIds []string, objectName string, msg rcMsg,
+	verifyBlacklist int, isIncludeSender int,
+	options ...MsgOption) error {
 
 	if senderID == "" {
 		return RCErrorNew(1002, "Paramer 'senderID' is required")
@@ -938,13 +1050,15 @@ func (rc *RongCloud) GroupStatusSend(senderID string, toGroupIds []string, objec
 
 	extraOptins := modifyMsgOptions(options)
 
-	req := httplib.Post(rc.rongCloudURI + "/statusmessage/group/publish." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/statusmessage/group/publish")
 	req.Param("fromUserId", senderID)
 	for _, v := range toGroupIds {
 		req.Param("toGroupId", v)
 	}
+	objectName, err := resolveObjectName(objectName, msg)
+	if err != nil {
+		return err
+	}
 	req.Param("objectName", objectName)
 
 	msgr, err := msg.ToString()
@@ -958,7 +1072,7 @@ func (rc *RongCloud) GroupStatusSend(senderID string, toGroupIds []string, objec
 		req.Param("busChannel", extraOptins.busChannel)
 	}
 
-	_, err = rc.do(req)
+	_, err = rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 	}
@@ -977,6 +1091,12 @@ func (rc *RongCloud) GroupStatusSend(senderID string, toGroupIds []string, objec
 *@return error
  */
 func (rc *RongCloud) GroupRecall(senderID, targetID, uID string, sentTime int,
+	options ...MsgOption) error {
+	return rc.GroupRecallContext(context.Background(), senderID, targetID, uID, sentTime, options...)
+}
+
+// GroupRecallContext 同 GroupRecall，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) GroupRecallContext(ctx context.Context, senderID, targetID, uID string, sentTime int,
 	options ...MsgOption) error {
 	if senderID == "" {
 		return RCErrorNew(1002, "Paramer 'senderID' is required")
@@ -988,9 +1108,7 @@ func (rc *RongCloud) GroupRecall(senderID, targetID, uID string, sentTime int,
 
 	extraOptins := modifyMsgOptions(options)
 
-	req := httplib.Post(rc.rongCloudURI + "/message/recall." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/message/recall")
 	req.Param("fromUserId", senderID)
 	req.Param("targetId", targetID)
 	req.Param("messageUID", uID)
@@ -1004,7 +1122,7 @@ func (rc *RongCloud) GroupRecall(senderID, targetID, uID string, sentTime int,
 		req.Param("busChannel", extraOptins.busChannel)
 	}
 
-	_, err := rc.do(req)
+	_, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 	}
@@ -1027,6 +1145,15 @@ func (rc *RongCloud) GroupRecall(senderID, targetID, uID string, sentTime int,
 *@return error
  */
 func (rc *RongCloud) GroupSendMention(senderID string, targetID []string, objectName string, msg MentionMsgContent,
+	pushContent, pushData string, isPersisted, isIncludeSender, isMentioned, contentAvailable int,
+	options ...MsgOption) error {
+	return rc.GroupSendMentionContext(context.Background(), senderID, targetID, objectName, msg,
+		pushContent, pushData, isPersisted, isIncludeSender, isMentioned, contentAvailable,
+		options...)
+}
+
+// GroupSendMentionContext 同 GroupSendMention，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) GroupSendMentionContext(ctx context.Context, senderID string, targetID []string, objectName string, msg MentionMsgContent,
 	pushContent, pushData string, isPersisted, isIncludeSender, isMentioned, contentAvailable int,
 	options ...MsgOption) error {
 	if senderID == "" {
@@ -1039,9 +1166,7 @@ func (rc *RongCloud) GroupSendMention(senderID string, targetID []string, object
 
 	extraOptins := modifyMsgOptions(options)
 
-	req := httplib.Post(rc.rongCloudURI + "/message/group/publish." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/message/group/publish")
 	req.Param("fromUserId", senderID)
 	for _, v := range targetID {
 		req.Param("toGroupId", v)
@@ -1068,7 +1193,7 @@ func (rc *RongCloud) GroupSendMention(senderID string, targetID []string, object
 		req.Param("busChannel", extraOptins.busChannel)
 	}
 
-	_, err = rc.do(req)
+	_, err = rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 	}
@@ -1079,12 +1204,17 @@ func (rc *RongCloud) GroupSendMention(senderID string, targetID []string, object
 /*
 *@param  senderID:发送人用户 ID 。
 *@param  targetID:接收聊天室ID, 建议最多不超过 10 个聊天室。
-*@param  objectName:消息类型
+*@param  objectName:消息类型，为空时根据 msg 的类型推断，见 RegisterMsgType
 *@param  msg:发送消息内容
 *
 *@return error
  */
 func (rc *RongCloud) ChatRoomSend(senderID string, targetID []string, objectName string, msg rcMsg) error {
+	return rc.ChatRoomSendContext(context.Background(), senderID, targetID, objectName, msg)
+}
+
+// ChatRoomSendContext 同 ChatRoomSend，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) ChatRoomSendContext(ctx context.Context, senderID string, targetID []string, objectName string, msg rcMsg) error {
 	if senderID == "" {
 		return RCErrorNew(1002, "Paramer 'senderID' is required")
 	}
@@ -1093,13 +1223,15 @@ func (rc *RongCloud) ChatRoomSend(senderID string, targetID []string, objectName
 		return RCErrorNew(1002, "Paramer 'senderID' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/message/chatroom/publish." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/message/chatroom/publish")
 	req.Param("fromUserId", senderID)
 	for _, v := range targetID {
 		req.Param("toChatroomId", v)
 	}
+	objectName, err := resolveObjectName(objectName, msg)
+	if err != nil {
+		return err
+	}
 	req.Param("objectName", objectName)
 	msgr, err := msg.ToString()
 	if err != nil {
@@ -1107,7 +1239,7 @@ func (rc *RongCloud) ChatRoomSend(senderID string, targetID []string, objectName
 	}
 	req.Param("content", msgr)
 
-	_, err = rc.do(req)
+	_, err = rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 	}
@@ -1117,20 +1249,27 @@ func (rc *RongCloud) ChatRoomSend(senderID string, targetID []string, objectName
 // ChatRoomBroadcast 向应用内所有聊天室广播消息方法，此功能需开通 专属服务（以一个用户身份向群组发送消息，单条消息最大 128k.每秒钟最多发送 20 条消息。）
 /*
 *@param  senderID:发送人用户 ID 。
-*@param  objectName:消息类型
+*@param  objectName:消息类型，为空时根据 msg 的类型推断，见 RegisterMsgType
 *@param  msg:发送消息内容
 *
 *@return error
  */
 func (rc *RongCloud) ChatRoomBroadcast(senderID, objectName string, msg rcMsg) error {
+	return rc.ChatRoomBroadcastContext(context.Background(), senderID, objectName, msg)
+}
+
+// ChatRoomBroadcastContext 同 ChatRoomBroadcast，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) ChatRoomBroadcastContext(ctx context.Context, senderID, objectName string, msg rcMsg) error {
 	if senderID == "" {
 		return RCErrorNew(1002, "Paramer 'senderID' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/message/chatroom/broadcast." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/message/chatroom/broadcast")
 	req.Param("fromUserId", senderID)
+	objectName, err := resolveObjectName(objectName, msg)
+	if err != nil {
+		return err
+	}
 	req.Param("objectName", objectName)
 	msgr, err := msg.ToString()
 	if err != nil {
@@ -1138,7 +1277,7 @@ func (rc *RongCloud) ChatRoomBroadcast(senderID, objectName string, msg rcMsg) e
 	}
 	req.Param("content", msgr)
 
-	_, err = rc.do(req)
+	_, err = rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 	}
@@ -1151,6 +1290,11 @@ func (rc *RongCloud) ChatRoomBroadcast(senderID, objectName string, msg rcMsg) e
 // @param objectName  消息类型，
 // @param content  发送消息内容
 func (rc *RongCloud) OnlineBroadcast(fromUserId string, objectName string, content string) ([]byte, error) {
+	return rc.OnlineBroadcastContext(context.Background(), fromUserId, objectName, content)
+}
+
+// OnlineBroadcastContext 同 OnlineBroadcast，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) OnlineBroadcastContext(ctx context.Context, fromUserId string, objectName string, content string) ([]byte, error) {
 
 	if fromUserId == "" {
 		return nil, RCErrorNew(1002, "Paramer 'fromUserId' is required")
@@ -1162,14 +1306,12 @@ func (rc *RongCloud) OnlineBroadcast(fromUserId string, objectName string, conte
 		return nil, RCErrorNew(1002, "Paramer 'content' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/message/online/broadcast." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/message/online/broadcast")
 	req.Param("fromUserId", fromUserId)
 	req.Param("objectName", objectName)
 	req.Param("content", content)
 
-	code, err := rc.do(req)
+	code, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 	}
@@ -1181,7 +1323,7 @@ func (rc *RongCloud) OnlineBroadcast(fromUserId string, objectName string, conte
 /*
 *@param  senderID:发送人用户 ID。
 *@param  targetID:接收用户 ID, 上限为 100 人。
-*@param  objectName:发送的消息类型。
+*@param  objectName:发送的消息类型，为空时根据 msg 的类型推断，见 RegisterMsgType。
 *@param  msg:消息。
 *@param  pushContent:定义显示的 Push 内容，如果 objectName 为融云内置消息类型时，则发送后用户一定会收到 Push 信息。如果为自定义消息，则 pushContent 为自定义消息显示的 Push 内容，如果不传则用户不会收到 Push 通知。
 *@param  pushData:针对 iOS 平台为 Push 通知时附加到 payload 中，Android 客户端收到推送消息时对应字段名为 pushData。
@@ -1195,6 +1337,14 @@ func (rc *RongCloud) OnlineBroadcast(fromUserId string, objectName string, conte
 func (rc *RongCloud) SystemSend(senderID string, targetID []string, objectName string, msg rcMsg,
 	pushContent, pushData string, count, isPersisted int,
 	options ...MsgOption) error {
+	return rc.SystemSendContext(context.Background(), senderID, targetID, objectName, msg,
+		pushContent, pushData, count, isPersisted, options...)
+}
+
+// SystemSendContext 同 SystemSend，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) SystemSendContext(ctx context.Context, senderID string, targetID []string, objectName string, msg rcMsg,
+	pushContent, pushData string, count, isPersisted int,
+	options ...MsgOption) error {
 
 	if senderID == "" {
 		return RCErrorNew(1002, "Paramer 'senderID' is required")
@@ -1206,13 +1356,15 @@ func (rc *RongCloud) SystemSend(senderID string, targetID []string, objectName s
 
 	extraOptins := modifyMsgOptions(options)
 
-	req := httplib.Post(rc.rongCloudURI + "/message/system/publish." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/message/system/publish")
 	req.Param("fromUserId", senderID)
 	for _, v := range targetID {
 		req.Param("toUserId", v)
 	}
+	objectName, err := resolveObjectName(objectName, msg)
+	if err != nil {
+		return err
+	}
 	req.Param("objectName", objectName)
 	msgr, err := msg.ToString()
 	if err != nil {
@@ -1233,7 +1385,7 @@ func (rc *RongCloud) SystemSend(senderID string, targetID []string, objectName s
 		req.Param("busChannel", extraOptins.busChannel)
 	}
 
-	_, err = rc.do(req)
+	_, err = rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 	}
@@ -1243,12 +1395,18 @@ func (rc *RongCloud) SystemSend(senderID string, targetID []string, objectName s
 // SystemBroadcast 给应用内所有用户发送消息方法，每小时最多发 2 次，每天最多发送 3 次（以一个用户身份向群组发送消息，单条消息最大 128k.每秒钟最多发送 20 条消息。）
 /*
 *@param  senderID:发送人用户 ID 。
-*@param  objectName:消息类型
+*@param  objectName:消息类型，为空时根据 msg 的类型推断，见 RegisterMsgType
 *@param  msg:发送消息内容
 *
 *@return error
  */
 func (rc *RongCloud) SystemBroadcast(senderID, objectName string, msg rcMsg,
+	options ...MsgOption) error {
+	return rc.SystemBroadcastContext(context.Background(), senderID, objectName, msg, options...)
+}
+
+// SystemBroadcastContext 同 SystemBroadcast，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) SystemBroadcastContext(ctx context.Context, senderID, objectName string, msg rcMsg,
 	options ...MsgOption) error {
 	if senderID == "" {
 		return RCErrorNew(1002, "Paramer 'senderID' is required")
@@ -1256,10 +1414,12 @@ func (rc *RongCloud) SystemBroadcast(senderID, objectName string, msg rcMsg,
 
 	extraOptins := modifyMsgOptions(options)
 
-	req := httplib.Post(rc.rongCloudURI + "/message/broadcast." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/message/broadcast")
 	req.Param("fromUserId", senderID)
+	objectName, err := resolveObjectName(objectName, msg)
+	if err != nil {
+		return err
+	}
 	req.Param("objectName", objectName)
 	msgr, err := msg.ToString()
 	if err != nil {
@@ -1278,7 +1438,7 @@ func (rc *RongCloud) SystemBroadcast(senderID, objectName string, msg rcMsg,
 		req.Param("pushExt", extraOptins.pushExt)
 	}
 
-	_, err = rc.do(req)
+	_, err = rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 	}
@@ -1295,6 +1455,13 @@ func (rc *RongCloud) SystemBroadcast(senderID, objectName string, msg rcMsg,
 *@return error
  */
 func (rc *RongCloud) SystemSendTemplate(senderID, objectName string, template TXTMsg, content []TemplateMsgContent,
+	options ...MsgOption) error {
+	return rc.SystemSendTemplateContext(context.Background(), senderID, objectName, template,
+		content, options...)
+}
+
+// SystemSendTemplateContext 同 SystemSendTemplate，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) SystemSendTemplateContext(ctx context.Context, senderID, objectName string, template TXTMsg, content []TemplateMsgContent,
 	options ...MsgOption) error {
 	if senderID == "" {
 		return RCErrorNew(1002, "Paramer 'senderID' is required")
@@ -1302,9 +1469,7 @@ func (rc *RongCloud) SystemSendTemplate(senderID, objectName string, template TX
 
 	extraOptins := modifyMsgOptions(options)
 
-	req := httplib.Post(rc.rongCloudURI + "/message/system/publish_template." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/message/system/publish_template")
 
 	var toUserIDs, push, pushData []string
 	var values []map[string]string
@@ -1341,7 +1506,7 @@ func (rc *RongCloud) SystemSendTemplate(senderID, objectName string, template TX
 
 	_, _ = req.JSONBody(param)
 
-	_, err = rc.do(req)
+	_, err = rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 	}
@@ -1355,12 +1520,15 @@ func (rc *RongCloud) SystemSendTemplate(senderID, objectName string, template TX
 *@return History error
  */
 func (rc *RongCloud) HistoryGet(date string) (History, error) {
-	req := httplib.Post(rc.rongCloudURI + "/message/history." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	return rc.HistoryGetContext(context.Background(), date)
+}
+
+// HistoryGetContext 同 HistoryGet，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) HistoryGetContext(ctx context.Context, date string) (History, error) {
+	req := newRequest("/message/history")
 	req.Param("date", date)
 
-	resp, err := rc.do(req)
+	resp, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 		return History{}, err
@@ -1379,15 +1547,18 @@ func (rc *RongCloud) HistoryGet(date string) (History, error) {
 *@return error
  */
 func (rc *RongCloud) HistoryRemove(date string) error {
+	return rc.HistoryRemoveContext(context.Background(), date)
+}
+
+// HistoryRemoveContext 同 HistoryRemove，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) HistoryRemoveContext(ctx context.Context, date string) error {
 	if date == "" {
 		return RCErrorNew(1002, "Paramer 'date' is required")
 	}
-	req := httplib.Post(rc.rongCloudURI + "/message/history/delete." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/message/history/delete")
 	req.Param("date", date)
 
-	_, err := rc.do(req)
+	_, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 	}
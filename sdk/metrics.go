@@ -0,0 +1,170 @@
+package sdk
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultLatencyBuckets 默认的请求耗时直方图分桶，单位秒
+var DefaultLatencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// MetricsCollector 收集 API 调用指标，实现需要并发安全，且不应阻塞
+type MetricsCollector interface {
+	// ObserveRequest 记录一次 http 请求（重试时每次请求都会记录）
+	// statusCode 为 0 表示未收到 http 响应，code 为融云返回码，无法解析时为 0
+	ObserveRequest(path string, statusCode, code int, duration time.Duration)
+	// ObserveFailover 记录一次 API 地址切换
+	ObserveFailover(from, to, reason string)
+}
+
+// Metrics 内存中的指标统计，实现了 MetricsCollector，
+// 同时是一个输出 Prometheus 文本格式指标的 http.Handler，可直接挂载到 http.ServeMux
+type Metrics struct {
+	buckets   []float64
+	lock      sync.Mutex
+	requests  map[requestLabels]uint64
+	latencies map[string]*histogram
+	failovers map[[2]string]uint64
+}
+
+type requestLabels struct {
+	path       string
+	statusCode int
+	code       int
+}
+
+type histogram struct {
+	counts []uint64 // 每个分桶的数量，不累加
+	sum    float64
+	count  uint64
+}
+
+// NewMetrics 创建指标统计，buckets 为空时使用 DefaultLatencyBuckets
+func NewMetrics(buckets ...float64) *Metrics {
+	if len(buckets) == 0 {
+		buckets = DefaultLatencyBuckets
+	}
+	buckets = append([]float64(nil), buckets...)
+	sort.Float64s(buckets)
+	return &Metrics{
+		buckets:   buckets,
+		requests:  map[requestLabels]uint64{},
+		latencies: map[string]*histogram{},
+		failovers: map[[2]string]uint64{},
+	}
+}
+
+// ObserveRequest 实现 MetricsCollector
+func (m *Metrics) ObserveRequest(path string, statusCode, code int, duration time.Duration) {
+	seconds := duration.Seconds()
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.requests[requestLabels{path, statusCode, code}]++
+	h, ok := m.latencies[path]
+	if !ok {
+		h = &histogram{counts: make([]uint64, len(m.buckets))}
+		m.latencies[path] = h
+	}
+	for i, bound := range m.buckets {
+		if seconds <= bound {
+			h.counts[i]++
+			break
+		}
+	}
+	h.sum += seconds
+	h.count++
+}
+
+// ObserveFailover 实现 MetricsCollector
+func (m *Metrics) ObserveFailover(from, to, reason string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.failovers[[2]string{from, to}]++
+}
+
+// ServeHTTP 以 Prometheus 文本格式输出指标
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_ = m.WritePrometheus(w)
+}
+
+// WritePrometheus 以 Prometheus 文本格式输出指标
+func (m *Metrics) WritePrometheus(w io.Writer) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	bw := bufio.NewWriter(w)
+
+	requests := make([]requestLabels, 0, len(m.requests))
+	for k := range m.requests {
+		requests = append(requests, k)
+	}
+	sort.Slice(requests, func(i, j int) bool {
+		a, b := requests[i], requests[j]
+		if a.path != b.path {
+			return a.path < b.path
+		}
+		if a.statusCode != b.statusCode {
+			return a.statusCode < b.statusCode
+		}
+		return a.code < b.code
+	})
+	fmt.Fprintln(bw, "# HELP rongcloud_requests_total Total number of RongCloud API requests.")
+	fmt.Fprintln(bw, "# TYPE rongcloud_requests_total counter")
+	for _, k := range requests {
+		fmt.Fprintf(bw, "rongcloud_requests_total{endpoint=%s,status=\"%d\",code=\"%d\"} %d\n",
+			quoteLabel(k.path), k.statusCode, k.code, m.requests[k])
+	}
+
+	paths := make([]string, 0, len(m.latencies))
+	for k := range m.latencies {
+		paths = append(paths, k)
+	}
+	sort.Strings(paths)
+	fmt.Fprintln(bw, "# HELP rongcloud_request_duration_seconds RongCloud API request latency.")
+	fmt.Fprintln(bw, "# TYPE rongcloud_request_duration_seconds histogram")
+	for _, path := range paths {
+		h := m.latencies[path]
+		var cumulative uint64
+		for i, bound := range m.buckets {
+			cumulative += h.counts[i]
+			fmt.Fprintf(bw, "rongcloud_request_duration_seconds_bucket{endpoint=%s,le=\"%s\"} %d\n",
+				quoteLabel(path), strconv.FormatFloat(bound, 'g', -1, 64), cumulative)
+		}
+		fmt.Fprintf(bw, "rongcloud_request_duration_seconds_bucket{endpoint=%s,le=\"+Inf\"} %d\n", quoteLabel(path), h.count)
+		fmt.Fprintf(bw, "rongcloud_request_duration_seconds_sum{endpoint=%s} %s\n",
+			quoteLabel(path), strconv.FormatFloat(h.sum, 'g', -1, 64))
+		fmt.Fprintf(bw, "rongcloud_request_duration_seconds_count{endpoint=%s} %d\n", quoteLabel(path), h.count)
+	}
+
+	failovers := make([][2]string, 0, len(m.failovers))
+	for k := range m.failovers {
+		failovers = append(failovers, k)
+	}
+	sort.Slice(failovers, func(i, j int) bool {
+		if failovers[i][0] != failovers[j][0] {
+			return failovers[i][0] < failovers[j][0]
+		}
+		return failovers[i][1] < failovers[j][1]
+	})
+	fmt.Fprintln(bw, "# HELP rongcloud_failovers_total Total number of RongCloud API endpoint switches.")
+	fmt.Fprintln(bw, "# TYPE rongcloud_failovers_total counter")
+	for _, k := range failovers {
+		fmt.Fprintf(bw, "rongcloud_failovers_total{from=%s,to=%s} %d\n", quoteLabel(k[0]), quoteLabel(k[1]), m.failovers[k])
+	}
+
+	return bw.Flush()
+}
+
+// quoteLabel 按 Prometheus 文本格式转义标签值
+func quoteLabel(v string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(v) + `"`
+}
@@ -0,0 +1,68 @@
+package sdk
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMetrics_WritePrometheus(t *testing.T) {
+	m := NewMetrics(0.1, 1)
+	m.ObserveRequest("/message/private/publish", 200, 200, 50*time.Millisecond)
+	m.ObserveRequest("/message/private/publish", 200, 1002, 500*time.Millisecond)
+	m.ObserveRequest("/message/private/publish", 0, 0, 2*time.Second)
+	m.ObserveFailover("http://api1", "http://api2", "failover")
+
+	var b strings.Builder
+	if err := m.WritePrometheus(&b); err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{
+		`rongcloud_requests_total{endpoint="/message/private/publish",status="0",code="0"} 1`,
+		`rongcloud_requests_total{endpoint="/message/private/publish",status="200",code="200"} 1`,
+		`rongcloud_requests_total{endpoint="/message/private/publish",status="200",code="1002"} 1`,
+		`rongcloud_request_duration_seconds_bucket{endpoint="/message/private/publish",le="0.1"} 1`,
+		`rongcloud_request_duration_seconds_bucket{endpoint="/message/private/publish",le="1"} 2`,
+		`rongcloud_request_duration_seconds_bucket{endpoint="/message/private/publish",le="+Inf"} 3`,
+		`rongcloud_request_duration_seconds_sum{endpoint="/message/private/publish"} 2.55`,
+		`rongcloud_request_duration_seconds_count{endpoint="/message/private/publish"} 3`,
+		`rongcloud_failovers_total{from="http://api1",to="http://api2"} 1`,
+	} {
+		if !strings.Contains(b.String(), line+"\n") {
+			t.Errorf("missing %s in:\n%s", line, b.String())
+		}
+	}
+}
+
+func TestWithMetrics(t *testing.T) {
+	bad := newStatusServer(http.StatusServiceUnavailable)
+	defer bad.Close()
+	good := newStatusServer(http.StatusOK)
+	defer good.Close()
+
+	m := NewMetrics()
+	rc := New("appKey", "appSecret",
+		WithEndpoints(bad.URL, good.URL),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond}),
+		WithMetrics(m),
+	)
+	if _, err := rc.BlockGetList(); err != nil {
+		t.Fatal(err)
+	}
+
+	w := httptest.NewRecorder()
+	m.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body := w.Body.String()
+	for _, line := range []string{
+		`rongcloud_requests_total{endpoint="/user/block/query",status="200",code="200"} 1`,
+		`rongcloud_requests_total{endpoint="/user/block/query",status="503",code="1000"} 1`,
+		`rongcloud_request_duration_seconds_count{endpoint="/user/block/query"} 2`,
+		`rongcloud_failovers_total{from="` + bad.URL + `",to="` + good.URL + `"} 1`,
+	} {
+		if !strings.Contains(body, line+"\n") {
+			t.Errorf("missing %s in:\n%s", line, body)
+		}
+	}
+}
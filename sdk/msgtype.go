@@ -0,0 +1,210 @@
+package sdk
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sync"
+)
+
+// msgTypes objectName 与消息结构体的对应关系，包含融云内置消息及 RegisterMsgType 注册的自定义消息
+var msgTypes = newMsgRegistry()
+
+// builtinMsgTypes 融云内置消息，不能被 RegisterMsgType 修改
+var builtinMsgTypes = map[string]RCMsg{
+	"RC:TxtMsg":        &TXTMsg{},
+	"RC:ImgMsg":        &ImgMsg{},
+	"RC:InfoNtf":       &InfoNtf{},
+	"RC:VcMsg":         &VCMsg{},
+	"RC:HQVCMsg":       &HQVCMsg{},
+	"RC:ImgTextMsg":    &IMGTextMsg{},
+	"RC:FileMsg":       &FileMsg{},
+	"RC:LBSMsg":        &LBSMsg{},
+	"RC:ProfileNtf":    &ProfileNtf{},
+	"RC:CmdNtf":        &CMDNtf{},
+	"RC:CmdMsg":        &CMDMsg{},
+	"RC:ContactNtf":    &ContactNtf{},
+	"RC:GrpNtf":        &GrpNtf{},
+	"RC:DizNtf":        &DizNtf{},
+	"RC:chrmKVNotiMsg": &ChatRoomKVNotiMessage{},
+}
+
+func init() {
+	for objectName, msg := range builtinMsgTypes {
+		msgTypes.register(objectName, msg)
+	}
+}
+
+// msgRegistry 可并发读写的 objectName 与消息结构体类型的双向映射
+type msgRegistry struct {
+	lock   sync.RWMutex
+	byName map[string]reflect.Type // objectName 对应的结构体类型（非指针）
+	byType map[reflect.Type]string
+}
+
+func newMsgRegistry() *msgRegistry {
+	return &msgRegistry{
+		byName: map[string]reflect.Type{},
+		byType: map[reflect.Type]string{},
+	}
+}
+
+func (r *msgRegistry) register(objectName string, msg RCMsg) error {
+	if objectName == "" {
+		return RCErrorNew(1002, "Paramer 'objectName' is required")
+	}
+	typ := msgType(msg)
+	if typ == nil {
+		return RCErrorNew(1002, "Paramer 'msg' must be a pointer to struct")
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if t, ok := r.byName[objectName]; ok && t != typ {
+		return RCErrorNew(1002, "objectName '"+objectName+"' is already registered to "+t.String())
+	}
+	if name, ok := r.byType[typ]; ok && name != objectName {
+		return RCErrorNew(1002, typ.String()+" is already registered as '"+name+"'")
+	}
+	r.byName[objectName] = typ
+	r.byType[typ] = objectName
+	return nil
+}
+
+// objectName 消息结构体类型注册的 objectName
+func (r *msgRegistry) objectName(msg RCMsg) (string, bool) {
+	typ := msgType(msg)
+	if typ == nil {
+		return "", false
+	}
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	name, ok := r.byType[typ]
+	return name, ok
+}
+
+// new 创建 objectName 对应的消息结构体，返回指针
+func (r *msgRegistry) new(objectName string) (RCMsg, bool) {
+	r.lock.RLock()
+	typ, ok := r.byName[objectName]
+	r.lock.RUnlock()
+	if !ok {
+		return nil, false
+	}
+	msg, ok := reflect.New(typ).Interface().(RCMsg)
+	return msg, ok
+}
+
+// msgType 消息的结构体类型，msg 不是结构体指针时返回 nil
+func msgType(msg RCMsg) reflect.Type {
+	if msg == nil {
+		return nil
+	}
+	typ := reflect.TypeOf(msg)
+	if typ.Kind() != reflect.Ptr || typ.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	return typ.Elem()
+}
+
+// RegisterMsgType 注册自定义消息的 objectName，msg 为消息结构体指针，如 RegisterMsgType("App:Custom", &CustomMsg{})
+// 注册后发送消息时 objectName 可为空，由 msg 的类型推断，MsgDecoder（及 DecodeMsg、DecodeMsgContent）也会将该类型的消息解码为对应结构体。
+// objectName 或结构体已注册为其他类型时返回错误，融云内置消息不能被重新注册
+func RegisterMsgType(objectName string, msg RCMsg) error {
+	return msgTypes.register(objectName, msg)
+}
+
+// ObjectNameOf 获取消息结构体注册的 objectName，如 &TXTMsg{} 返回 "RC:TxtMsg"
+func ObjectNameOf(msg RCMsg) (string, bool) {
+	return msgTypes.objectName(msg)
+}
+
+// resolveObjectName 发送消息时确定 objectName：为空时由 msg 的类型推断；
+// objectName 及 msg 的类型均已注册但不对应（如以 "RC:ImgMsg" 发送 TXTMsg）时返回错误
+func resolveObjectName(objectName string, msg RCMsg) (string, error) {
+	name, ok := ObjectNameOf(msg)
+	if objectName == "" {
+		if !ok {
+			return "", RCErrorNew(1002, "Paramer 'objectName' is required")
+		}
+		return name, nil
+	}
+	if ok && name != objectName {
+		if _, registered := msgTypes.new(objectName); registered {
+			return "", RCErrorNew(1002, "Paramer 'objectName' '"+objectName+"' does not match msg type '"+name+"'")
+		}
+	}
+	return objectName, nil
+}
+
+// ErrUnknownMsgType 消息的 objectName 不是内置消息且未通过 RegisterMsgType 注册
+var ErrUnknownMsgType = errors.New("rongcloud: unknown message type")
+
+// MsgDecoder 按 objectName 将消息内容解码为对应的消息结构体，支持内置消息及 RegisterMsgType 注册的自定义消息
+type MsgDecoder struct {
+	// Strict 为 true 时内容中包含消息结构体未定义的字段返回错误，默认忽略未定义的字段
+	Strict bool
+}
+
+// Decode 解码消息内容，返回消息结构体指针，如 *TXTMsg、*ImgMsg
+// 未知的消息类型返回 ErrUnknownMsgType。content 为 json 字符串（即内容被再次编码为字符串）时会先还原
+func (d MsgDecoder) Decode(objectName string, content []byte) (RCMsg, error) {
+	msg, ok := msgTypes.new(objectName)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownMsgType, objectName)
+	}
+	content = unquoteContent(content)
+	var err error
+	if d.Strict {
+		dec := json.NewDecoder(bytes.NewReader(content))
+		dec.DisallowUnknownFields()
+		if err = dec.Decode(msg); err == nil && dec.Decode(&json.RawMessage{}) != io.EOF {
+			err = errors.New("invalid data after top-level value")
+		}
+	} else {
+		err = json.Unmarshal(content, msg)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("rongcloud: decode %s: %w", objectName, err)
+	}
+	return msg, nil
+}
+
+// DecodeContent 同 Decode，未知的消息类型返回 json.RawMessage；解码失败时返回 json.RawMessage 及错误原因
+// 用于历史消息日志、消息路由回调等需要保留未知类型消息的场景
+func (d MsgDecoder) DecodeContent(objectName string, content []byte) (interface{}, error) {
+	msg, err := d.Decode(objectName, content)
+	if errors.Is(err, ErrUnknownMsgType) {
+		return json.RawMessage(unquoteContent(content)), nil
+	}
+	if err != nil {
+		return json.RawMessage(unquoteContent(content)), err
+	}
+	return msg, nil
+}
+
+// DecodeMsg 同 MsgDecoder{}.Decode，忽略内容中未定义的字段
+func DecodeMsg(objectName string, content []byte) (RCMsg, error) {
+	return MsgDecoder{}.Decode(objectName, content)
+}
+
+// unquoteContent 内容为 json 字符串时还原为字符串的值
+func unquoteContent(content []byte) []byte {
+	if len(content) > 0 && content[0] == '"' {
+		var s string
+		if err := json.Unmarshal(content, &s); err == nil {
+			return []byte(s)
+		}
+	}
+	return content
+}
+
+// DecodeMsgContent 按 objectName 将消息内容解码为对应的消息结构体，如 *TXTMsg、*ImgMsg
+// 未知的消息类型返回 json.RawMessage；解码失败时返回 json.RawMessage 及错误原因。
+// content 为 json 字符串（即内容被再次编码为字符串）时会先还原。同 MsgDecoder{}.DecodeContent
+func DecodeMsgContent(objectName string, content []byte) (interface{}, error) {
+	return MsgDecoder{}.DecodeContent(objectName, content)
+}
@@ -0,0 +1,147 @@
+package sdk
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rongcloud/server-sdk-go/v3/sdk/sdktest"
+)
+
+// orderMsg 测试用自定义消息
+type orderMsg struct {
+	OrderID string `json:"orderId"`
+}
+
+func (msg *orderMsg) ToString() (string, error) {
+	b, err := json.Marshal(msg)
+	return string(b), err
+}
+
+func TestRegisterMsgType(t *testing.T) {
+	for msg, want := range map[RCMsg]string{
+		&TXTMsg{}:  "RC:TxtMsg",
+		&ImgMsg{}:  "RC:ImgMsg",
+		&VCMsg{}:   "RC:VcMsg",
+		&HQVCMsg{}: "RC:HQVCMsg",
+		&FileMsg{}: "RC:FileMsg",
+		&LBSMsg{}:  "RC:LBSMsg",
+		&GrpNtf{}:  "RC:GrpNtf",
+	} {
+		if name, ok := ObjectNameOf(msg); !ok || name != want {
+			t.Errorf("ObjectNameOf(%T) = %q, want %q", msg, name, want)
+		}
+	}
+	if _, ok := ObjectNameOf(RawMsg("{}")); ok {
+		t.Error("RawMsg should not be registered")
+	}
+
+	if err := RegisterMsgType("App:Order", &orderMsg{}); err != nil {
+		t.Fatal(err)
+	}
+	// 重复注册相同的类型不报错
+	if err := RegisterMsgType("App:Order", &orderMsg{}); err != nil {
+		t.Error(err)
+	}
+	if name, _ := ObjectNameOf(&orderMsg{}); name != "App:Order" {
+		t.Errorf("ObjectNameOf = %q", name)
+	}
+	for _, tc := range []struct {
+		objectName string
+		msg        RCMsg
+	}{
+		{"RC:TxtMsg", &orderMsg{}},
+		{"App:Other", &orderMsg{}},
+		{"App:Text", &TXTMsg{}},
+		{"", &orderMsg{}},
+		{"App:Raw", RawMsg("{}")},
+	} {
+		if err := RegisterMsgType(tc.objectName, tc.msg); !errors.Is(err, ErrValidation) {
+			t.Errorf("RegisterMsgType(%q, %T) = %v", tc.objectName, tc.msg, err)
+		}
+	}
+
+	msg, err := DecodeMsgContent("App:Order", []byte(`{"orderId":"o01"}`))
+	if order, ok := msg.(*orderMsg); err != nil || !ok || order.OrderID != "o01" {
+		t.Errorf("DecodeMsgContent = %#v, %v", msg, err)
+	}
+}
+
+func TestRongCloud_SendInferObjectName(t *testing.T) {
+	server := sdktest.NewServer("appKey", "appSecret")
+	defer server.Close()
+	rc := New(server.AppKey, server.AppSecret, WithRongCloudURI(server.URL))
+
+	if err := rc.PrivateSend("admin", []string{"u01"}, "", &ImgMsg{Content: "base64"}, "", "", 0, 0, 1, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := rc.SystemSend("admin", []string{"u01"}, "", &TXTMsg{Content: "hello"}, "", "", 0, 1); err != nil {
+		t.Fatal(err)
+	}
+	if m := server.Messages("/message/private/publish"); len(m) != 1 || m[0].ObjectName != "RC:ImgMsg" {
+		t.Errorf("private messages = %+v", m)
+	}
+	if m := server.Messages("/message/system/publish"); len(m) != 1 || m[0].ObjectName != "RC:TxtMsg" {
+		t.Errorf("system messages = %+v", m)
+	}
+
+	// 未注册的 objectName 可用于发送任意类型的消息
+	if err := rc.GroupSend("admin", []string{"g01"}, nil, "App:Text", &TXTMsg{Content: "hello"}, "", "", 1, 0); err != nil {
+		t.Error(err)
+	}
+	// objectName 与消息类型不对应
+	if err := rc.PrivateSend("admin", []string{"u01"}, "RC:ImgMsg", &TXTMsg{Content: "hello"}, "", "", 0, 0, 1, 0, 0); !errors.Is(err, ErrValidation) {
+		t.Errorf("mismatched objectName: %v", err)
+	}
+	if err := rc.ChatRoomSend("admin", []string{"c01"}, "", RawMsg("{}")); !errors.Is(err, ErrValidation) {
+		t.Errorf("unregistered type: %v", err)
+	}
+}
+
+func TestMsgDecoder(t *testing.T) {
+	for _, tc := range []struct {
+		objectName, content string
+		want                RCMsg
+	}{
+		{"RC:TxtMsg", `{"content":"hello","extra":"e"}`, &TXTMsg{Content: "hello", Extra: "e"}},
+		{"RC:CmdMsg", `{"name":"refresh","data":"d"}`, &CMDMsg{Name: "refresh", Data: "d"}},
+		{"RC:ContactNtf", `{"operation":"Request","sourceUserId":"u01"}`, &ContactNtf{Operation: "Request", SourceUserID: "u01"}},
+		{"RC:DizNtf", `{"type":1,"operation":"Add"}`, &DizNtf{Type: 1, Operation: "Add"}},
+		// 内容被再次编码为 json 字符串
+		{"RC:TxtMsg", `"{\"content\":\"hello\"}"`, &TXTMsg{Content: "hello"}},
+	} {
+		msg, err := MsgDecoder{Strict: true}.Decode(tc.objectName, []byte(tc.content))
+		if err != nil || !reflect.DeepEqual(msg, tc.want) {
+			t.Errorf("Decode(%s, %s) = %#v, %v", tc.objectName, tc.content, msg, err)
+		}
+	}
+
+	// 宽松模式忽略未定义的字段，严格模式返回错误
+	content := []byte(`{"content":"hello","mentionedInfo":{"type":1}}`)
+	if msg, err := DecodeMsg("RC:TxtMsg", content); err != nil || msg.(*TXTMsg).Content != "hello" {
+		t.Errorf("lenient = %#v, %v", msg, err)
+	}
+	if _, err := (MsgDecoder{Strict: true}).Decode("RC:TxtMsg", content); err == nil || !strings.Contains(err.Error(), "mentionedInfo") {
+		t.Errorf("strict = %v", err)
+	}
+	if _, err := (MsgDecoder{Strict: true}).Decode("RC:TxtMsg", []byte(`{"content":"hello"} {}`)); err == nil {
+		t.Error("strict: expected error for trailing data")
+	}
+	if _, err := DecodeMsg("RC:TxtMsg", []byte(`{"content":1}`)); err == nil {
+		t.Error("expected error for mismatched field type")
+	}
+	if _, err := DecodeMsg("App:Unknown", []byte(`{}`)); !errors.Is(err, ErrUnknownMsgType) {
+		t.Errorf("unknown type = %v", err)
+	}
+
+	// 注册的自定义消息
+	if err := RegisterMsgType("App:Order", &orderMsg{}); err != nil {
+		t.Fatal(err)
+	}
+	msg, err := MsgDecoder{Strict: true}.Decode("App:Order", []byte(`{"orderId":"o01"}`))
+	if order, ok := msg.(*orderMsg); err != nil || !ok || order.OrderID != "o01" {
+		t.Errorf("custom = %#v, %v", msg, err)
+	}
+}
@@ -4,39 +4,121 @@ import (
 	"time"
 )
 
-type rongCloudOption func(*RongCloud)
+// RongCloudOption 创建 RongCloud 时的可选配置，见 New
+type RongCloudOption func(*RongCloud)
 
 // WithRongCloudSMSURI 设置融云 SMS URI
-func WithRongCloudSMSURI(rongCloudSMSURI string) rongCloudOption {
+func WithRongCloudSMSURI(rongCloudSMSURI string) RongCloudOption {
 	return func(o *RongCloud) {
 		o.rongCloudSMSURI = rongCloudSMSURI
 	}
 }
 
 // WithRongCloudURI 设置融云 URI
-func WithRongCloudURI(rongCloudURI string) rongCloudOption {
+func WithRongCloudURI(rongCloudURI string) RongCloudOption {
 	return func(o *RongCloud) {
 		o.rongCloudURI = rongCloudURI
 	}
 }
 
 // WithTimeout 设置超时时间，最小单位为秒
-func WithTimeout(t time.Duration) rongCloudOption {
+func WithTimeout(t time.Duration) RongCloudOption {
 	return func(o *RongCloud) {
 		o.timeout = t
 	}
 }
 
 // WithKeepAlive 连接保活时间，最小单位为秒
-func WithKeepAlive(t time.Duration) rongCloudOption {
+func WithKeepAlive(t time.Duration) RongCloudOption {
 	return func(o *RongCloud) {
 		o.keepAlive = t
 	}
 }
 
 // WithMaxIdleConnsPerHost 设置每个域名最大连接数
-func WithMaxIdleConnsPerHost(n int) rongCloudOption {
+func WithMaxIdleConnsPerHost(n int) RongCloudOption {
 	return func(o *RongCloud) {
 		o.maxIdleConnsPerHost = n
 	}
 }
+
+// WithHTTPExecutor 设置 http 请求的执行方式，默认使用 net/http
+func WithHTTPExecutor(executor HTTPExecutor) RongCloudOption {
+	return func(o *RongCloud) {
+		o.executor = executor
+	}
+}
+
+// WithRetryPolicy 设置请求失败后的重试策略，默认不重试
+func WithRetryPolicy(policy RetryPolicy) RongCloudOption {
+	return func(o *RongCloud) {
+		o.retryPolicy = &policy
+	}
+}
+
+// WithEndpoints 设置多个融云 API 地址，按顺序优先使用，地址不健康时自动切换到下一个
+func WithEndpoints(uris ...string) RongCloudOption {
+	return func(o *RongCloud) {
+		o.endpointURIs = uris
+	}
+}
+
+// WithFailoverPolicy 设置多 API 地址的故障切换策略
+func WithFailoverPolicy(policy FailoverPolicy) RongCloudOption {
+	return func(o *RongCloud) {
+		o.failoverPolicy = policy
+	}
+}
+
+// WithRateLimiter 设置客户端限流，默认不限流
+// 多个 RongCloud 对象可共用同一个 RateLimiter，相同 appKey 的对象共享限额
+func WithRateLimiter(limiter *RateLimiter) RongCloudOption {
+	return func(o *RongCloud) {
+		o.rateLimiter = limiter
+	}
+}
+
+// WithInterceptors 添加 API 调用拦截器，按添加顺序执行，先添加的拦截器在最外层
+func WithInterceptors(interceptors ...Interceptor) RongCloudOption {
+	return func(o *RongCloud) {
+		o.interceptors = append(o.interceptors, interceptors...)
+	}
+}
+
+// WithMetrics 设置指标收集，可使用 NewMetrics 创建的内置实现
+func WithMetrics(collector MetricsCollector) RongCloudOption {
+	return func(o *RongCloud) {
+		o.metrics = collector
+	}
+}
+
+// WithTracer 设置链路追踪，每次 API 调用创建一个 span，默认不追踪
+func WithTracer(tracer Tracer) RongCloudOption {
+	return func(o *RongCloud) {
+		if tracer == nil {
+			tracer = noopTracer{}
+		}
+		o.tracer = tracer
+	}
+}
+
+// WithPresenceCache 设置用户在线状态缓存，OnlineStatusCheck 优先查询缓存，未命中时再请求融云
+func WithPresenceCache(cache PresenceCache) RongCloudOption {
+	return func(o *RongCloud) {
+		o.presence = cache
+	}
+}
+
+// WithSecretGracePeriod 设置通过 SetCredentials 轮换 appSecret 后，旧 appSecret 仍可用于校验回调签名的时间，默认 5 分钟，为 0 时立即失效
+func WithSecretGracePeriod(d time.Duration) RongCloudOption {
+	return func(o *RongCloud) {
+		o.secretGracePeriod = d
+	}
+}
+
+// WithBatchParallelism 设置 PrivateSendBatch 等分批调用的并发请求数，默认 4
+func WithBatchParallelism(n int) RongCloudOption {
+	return func(o *RongCloud) {
+		o.batchParallelism = n
+	}
+}
@@ -6,7 +6,7 @@ import (
 )
 
 func TestWithRongCloudSMSURI(t *testing.T) {
-	rc := NewRongCloud("abc", "abc123", WithRongCloudSMSURI("sms.test.com"))
+	rc := New("abc", "abc123", WithRongCloudSMSURI("sms.test.com"))
 
 	if rc.rongCloudSMSURI != "sms.test.com" {
 		t.Error("invalid rong cloud sms uri")
@@ -16,7 +16,7 @@ func TestWithRongCloudSMSURI(t *testing.T) {
 }
 
 func TestWithRongCloudURI(t *testing.T) {
-	rc := NewRongCloud("abc", "abc123", WithRongCloudURI("api.test.com"))
+	rc := New("abc", "abc123", WithRongCloudURI("api.test.com"))
 
 	if rc.rongCloudURI != "api.test.com" {
 		t.Error("invalid rong cloud uri")
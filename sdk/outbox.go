@@ -0,0 +1,499 @@
+package sdk
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"sync"
+)
+
+const (
+	// OUTBOX_FILE outbox 目录中预写日志的文件名
+	OUTBOX_FILE = "outbox.wal"
+	// DEFAULT_OUTBOX_COMPACT_SIZE 没有未完成的消息且日志超过该大小时清空日志，1MB
+	DEFAULT_OUTBOX_COMPACT_SIZE = 1 << 20
+)
+
+// outbox 日志记录类型
+const (
+	outboxIntent byte = 1 // 发送意图，payload 为序列化的 SendMessage
+	outboxDone   byte = 2 // 发送完成，无 payload
+)
+
+// outboxHeaderSize 记录头长度：类型(1) + 序号(8) + payload 长度(4) + crc32(4)
+const outboxHeaderSize = 17
+
+// outboxMaxPayload 单条记录 payload 的最大长度，超过时视为损坏的记录
+const outboxMaxPayload = 64 << 20
+
+var (
+	// ErrOutboxClosed Outbox 已关闭
+	ErrOutboxClosed = errors.New("rongcloud: outbox is closed")
+	// ErrOutboxBroken 写入失败后无法恢复日志文件，Outbox 不再接受写入，需重新打开
+	ErrOutboxBroken = errors.New("rongcloud: outbox is broken")
+)
+
+// OutboxCodec 发送意图的序列化方式
+type OutboxCodec interface {
+	Marshal(msg *SendMessage) ([]byte, error)
+	Unmarshal(data []byte) (*SendMessage, error)
+}
+
+// JSONOutboxCodec 默认的 json 序列化方式，消息内容保存为 ToString 的结果，MsgOption 保存为 MsgOptions
+type JSONOutboxCodec struct {
+	// DecodeMsg 还原消息内容，为 nil 时已注册的消息类型使用 DecodeMsg 还原，其他类型还原为 RawMsg
+	DecodeMsg func(objectName, content string) (RCMsg, error)
+}
+
+// RawMsg 已序列化的消息内容，ToString 原样返回，用于发送无法还原为具体类型的消息
+type RawMsg string
+
+// ToString RawMsg
+func (msg RawMsg) ToString() (string, error) {
+	return string(msg), nil
+}
+
+// outboxRecord JSONOutboxCodec 的序列化格式
+type outboxRecord struct {
+	ID               string     `json:"id,omitempty"`
+	Kind             SendKind   `json:"kind"`
+	SenderID         string     `json:"senderId"`
+	TargetID         []string   `json:"targetId"`
+	UserID           []string   `json:"userId,omitempty"`
+	ObjectName       string     `json:"objectName"`
+	Content          string     `json:"content"`
+	PushContent      string     `json:"pushContent,omitempty"`
+	PushData         string     `json:"pushData,omitempty"`
+	Count            int        `json:"count,omitempty"`
+	VerifyBlacklist  int        `json:"verifyBlacklist,omitempty"`
+	IsPersisted      int        `json:"isPersisted,omitempty"`
+	IsIncludeSender  int        `json:"isIncludeSender,omitempty"`
+	ContentAvailable int        `json:"contentAvailable,omitempty"`
+	Options          MsgOptions `json:"options"`
+}
+
+// Marshal 实现 OutboxCodec
+func (c JSONOutboxCodec) Marshal(msg *SendMessage) ([]byte, error) {
+	objectName, err := resolveObjectName(msg.ObjectName, msg.Msg)
+	if err != nil {
+		return nil, err
+	}
+	content, err := msg.Msg.ToString()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(outboxRecord{
+		ID:               msg.ID,
+		Kind:             msg.Kind,
+		SenderID:         msg.SenderID,
+		TargetID:         msg.TargetID,
+		UserID:           msg.UserID,
+		ObjectName:       objectName,
+		Content:          content,
+		PushContent:      msg.PushContent,
+		PushData:         msg.PushData,
+		Count:            msg.Count,
+		VerifyBlacklist:  msg.VerifyBlacklist,
+		IsPersisted:      msg.IsPersisted,
+		IsIncludeSender:  msg.IsIncludeSender,
+		ContentAvailable: msg.ContentAvailable,
+		Options:          MsgOptionsOf(msg.Options),
+	})
+}
+
+// Unmarshal 实现 OutboxCodec
+func (c JSONOutboxCodec) Unmarshal(data []byte) (*SendMessage, error) {
+	var r outboxRecord
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, err
+	}
+	decode := c.DecodeMsg
+	if decode == nil {
+		decode = decodeOutboxMsg
+	}
+	msg, err := decode(r.ObjectName, r.Content)
+	if err != nil {
+		return nil, err
+	}
+	return &SendMessage{
+		ID:               r.ID,
+		Kind:             r.Kind,
+		SenderID:         r.SenderID,
+		TargetID:         r.TargetID,
+		UserID:           r.UserID,
+		ObjectName:       r.ObjectName,
+		Msg:              msg,
+		PushContent:      r.PushContent,
+		PushData:         r.PushData,
+		Count:            r.Count,
+		VerifyBlacklist:  r.VerifyBlacklist,
+		IsPersisted:      r.IsPersisted,
+		IsIncludeSender:  r.IsIncludeSender,
+		ContentAvailable: r.ContentAvailable,
+		Options:          r.Options.Options(),
+	}, nil
+}
+
+// decodeOutboxMsg 已注册的消息类型还原为对应的结构体，未知类型还原为 RawMsg，已知类型解码失败时返回错误
+func decodeOutboxMsg(objectName, content string) (RCMsg, error) {
+	msg, err := DecodeMsg(objectName, []byte(content))
+	if errors.Is(err, ErrUnknownMsgType) {
+		return RawMsg(content), nil
+	}
+	return msg, err
+}
+
+// OutboxEntry 未完成的发送意图
+type OutboxEntry struct {
+	Seq     uint64
+	Message *SendMessage
+}
+
+// Outbox 保证消息发送的本地预写日志，发送前记录发送意图，发送成功后标记完成，
+// 进程重启后可通过 Unfinished 或 Replay 重新发送未完成的消息。可被多个 goroutine 同时使用
+type Outbox struct {
+	dir         string
+	codec       OutboxCodec
+	sync        bool
+	compactSize int64
+
+	lock    sync.Mutex
+	file    *os.File
+	size    int64
+	seq     uint64
+	pending map[uint64][]byte // 未完成的发送意图及序列化后的内容
+	closed  bool
+	broken  error // 不为 nil 时日志文件可能包含不完整的记录，拒绝之后的写入
+}
+
+// OutboxOption Outbox 可选配置
+type OutboxOption func(*Outbox)
+
+// WithOutboxCodec 设置发送意图的序列化方式，默认为 JSONOutboxCodec
+func WithOutboxCodec(codec OutboxCodec) OutboxOption {
+	return func(o *Outbox) {
+		o.codec = codec
+	}
+}
+
+// WithOutboxSync 设置每次写入后是否调用 fsync，默认为 true；为 false 时写入更快，但系统崩溃时可能丢失最近的记录
+func WithOutboxSync(sync bool) OutboxOption {
+	return func(o *Outbox) {
+		o.sync = sync
+	}
+}
+
+// OpenOutbox 打开 dir 目录中的 outbox，目录不存在时创建
+// 打开时读取日志中未完成的发送意图并重写日志，日志末尾不完整的记录（如写入时进程崩溃）会被丢弃
+func OpenOutbox(dir string, options ...OutboxOption) (*Outbox, error) {
+	o := &Outbox{
+		dir:         dir,
+		codec:       JSONOutboxCodec{},
+		sync:        true,
+		compactSize: DEFAULT_OUTBOX_COMPACT_SIZE,
+		pending:     map[uint64][]byte{},
+	}
+	for _, option := range options {
+		option(o)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join(dir, OUTBOX_FILE)
+	f, err := os.Open(path)
+	switch {
+	case err == nil:
+		err = o.load(f)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("rongcloud: read outbox %s: %w", path, err)
+		}
+	case !os.IsNotExist(err):
+		return nil, err
+	}
+	// 未完成的发送意图需要能被还原
+	for seq, data := range o.pending {
+		if _, err := o.codec.Unmarshal(data); err != nil {
+			return nil, fmt.Errorf("rongcloud: decode outbox entry %d: %w", seq, err)
+		}
+	}
+	if err := o.rewrite(); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// load 读取日志，遇到不完整或校验失败的记录时停止
+func (o *Outbox) load(f io.Reader) error {
+	r := bufio.NewReader(f)
+	header := make([]byte, outboxHeaderSize)
+	for {
+		if _, err := io.ReadFull(r, header); err != nil {
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				return nil
+			}
+			return err
+		}
+		kind := header[0]
+		seq := binary.BigEndian.Uint64(header[1:9])
+		size := binary.BigEndian.Uint32(header[9:13])
+		if size > outboxMaxPayload {
+			return nil
+		}
+		payload := make([]byte, size)
+		if _, err := io.ReadFull(r, payload); err != nil {
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				return nil
+			}
+			return err
+		}
+		if checksum(kind, seq, payload) != binary.BigEndian.Uint32(header[13:17]) {
+			return nil
+		}
+
+		switch kind {
+		case outboxIntent:
+			o.pending[seq] = payload
+		case outboxDone:
+			delete(o.pending, seq)
+		}
+		if seq > o.seq {
+			o.seq = seq
+		}
+	}
+}
+
+// checksum 记录的 crc32 校验值
+func checksum(kind byte, seq uint64, payload []byte) uint32 {
+	var b [9]byte
+	b[0] = kind
+	binary.BigEndian.PutUint64(b[1:], seq)
+	return crc32.Update(crc32.ChecksumIEEE(b[:]), crc32.IEEETable, payload)
+}
+
+// encodeRecord 编码一条日志记录
+func encodeRecord(kind byte, seq uint64, payload []byte) []byte {
+	b := make([]byte, outboxHeaderSize+len(payload))
+	b[0] = kind
+	binary.BigEndian.PutUint64(b[1:9], seq)
+	binary.BigEndian.PutUint32(b[9:13], uint32(len(payload)))
+	binary.BigEndian.PutUint32(b[13:17], checksum(kind, seq, payload))
+	copy(b[outboxHeaderSize:], payload)
+	return b
+}
+
+// rewrite 只保留未完成的发送意图，写入临时文件后替换日志，并打开日志用于追加
+func (o *Outbox) rewrite() error {
+	path := filepath.Join(o.dir, OUTBOX_FILE)
+	tmp := path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	var size int64
+	w := bufio.NewWriter(f)
+	for _, seq := range o.pendingSeqs() {
+		n, _ := w.Write(encodeRecord(outboxIntent, seq, o.pending[seq]))
+		size += int64(n)
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return err
+	}
+
+	// 日志已被替换，之后失败时 o.file 指向已删除的旧文件，不能继续写入
+	if err := syncDir(o.dir); err != nil {
+		return o.fail(err)
+	}
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return o.fail(err)
+	}
+	if o.file != nil {
+		o.file.Close()
+	}
+	o.file, o.size = file, size
+	return nil
+}
+
+// syncDir 对目录调用 fsync，保证 rename 在系统崩溃后仍然生效；Windows 不支持对目录 fsync
+func syncDir(dir string) error {
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	if err := d.Sync(); err != nil {
+		d.Close()
+		return err
+	}
+	return d.Close()
+}
+
+// fail 标记 Outbox 不再接受写入，返回包装了 ErrOutboxBroken 的错误
+func (o *Outbox) fail(err error) error {
+	o.broken = fmt.Errorf("%w: %v", ErrOutboxBroken, err)
+	return o.broken
+}
+
+// pendingSeqs 未完成的发送意图序号，按先后顺序排列
+func (o *Outbox) pendingSeqs() []uint64 {
+	seqs := make([]uint64, 0, len(o.pending))
+	for seq := range o.pending {
+		seqs = append(seqs, seq)
+	}
+	sort.Slice(seqs, func(i, j int) bool { return seqs[i] < seqs[j] })
+	return seqs
+}
+
+// append 追加一条记录，调用方需持有锁
+// 写入不完整或 fsync 失败时将日志截断到写入前的位置，截断失败时标记 Outbox 不再接受写入
+func (o *Outbox) append(kind byte, seq uint64, payload []byte) error {
+	if o.closed {
+		return ErrOutboxClosed
+	}
+	if o.broken != nil {
+		return o.broken
+	}
+	n, err := o.file.Write(encodeRecord(kind, seq, payload))
+	if err == nil && o.sync {
+		err = o.file.Sync()
+	}
+	if err != nil {
+		if truncErr := o.file.Truncate(o.size); truncErr != nil {
+			o.fail(fmt.Errorf("%v (truncate: %v)", err, truncErr))
+		}
+		return err
+	}
+	o.size += int64(n)
+	return nil
+}
+
+// Add 记录发送意图，返回的序号用于发送成功后调用 Done
+func (o *Outbox) Add(msg *SendMessage) (uint64, error) {
+	if err := msg.validate(); err != nil {
+		return 0, err
+	}
+	payload, err := o.codec.Marshal(msg)
+	if err != nil {
+		return 0, err
+	}
+	if len(payload) > outboxMaxPayload {
+		return 0, RCErrorNew(1002, "Paramer 'msg' is too large")
+	}
+
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	seq := o.seq + 1
+	if err := o.append(outboxIntent, seq, payload); err != nil {
+		return 0, err
+	}
+	o.seq = seq
+	o.pending[seq] = payload
+	return seq, nil
+}
+
+// Done 标记发送意图已完成，之后不再重新发送
+func (o *Outbox) Done(seq uint64) error {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	if o.closed {
+		return ErrOutboxClosed
+	}
+	if _, ok := o.pending[seq]; !ok {
+		return nil
+	}
+	if err := o.append(outboxDone, seq, nil); err != nil {
+		return err
+	}
+	delete(o.pending, seq)
+	if len(o.pending) == 0 && o.size > o.compactSize {
+		return o.rewrite()
+	}
+	return nil
+}
+
+// Unfinished 未完成的发送意图，按记录的先后顺序排列
+func (o *Outbox) Unfinished() ([]OutboxEntry, error) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	entries := make([]OutboxEntry, 0, len(o.pending))
+	for _, seq := range o.pendingSeqs() {
+		msg, err := o.codec.Unmarshal(o.pending[seq])
+		if err != nil {
+			return nil, fmt.Errorf("rongcloud: decode outbox entry %d: %w", seq, err)
+		}
+		entries = append(entries, OutboxEntry{Seq: seq, Message: msg})
+	}
+	return entries, nil
+}
+
+// Send 记录发送意图后发送消息，发送成功时标记完成
+// 发送失败时保留发送意图，不会自动重试，需调用 Replay（或使用该 Outbox 创建 Dispatcher）重新发送；
+// 确定不再发送的消息（如参数错误）可通过 Unfinished 找到对应的序号后调用 Done 放弃
+func (o *Outbox) Send(ctx context.Context, client MessageClient, msg *SendMessage) error {
+	seq, err := o.Add(msg)
+	if err != nil {
+		return err
+	}
+	if err := msg.send(ctx, client); err != nil {
+		return err
+	}
+	return o.Done(seq)
+}
+
+// Replay 按先后顺序重新发送未完成的消息，发送成功的标记完成，返回第一个发送失败的错误
+// 进程可能在发送成功后、标记完成前退出，因此接收方可能收到重复消息
+func (o *Outbox) Replay(ctx context.Context, client MessageClient) error {
+	entries, err := o.Unfinished()
+	if err != nil {
+		return err
+	}
+	var first error
+	for _, e := range entries {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		err := e.Message.send(ctx, client)
+		if err == nil {
+			err = o.Done(e.Seq)
+		}
+		if err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+// Close 关闭日志文件
+func (o *Outbox) Close() error {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	if o.closed {
+		return nil
+	}
+	o.closed = true
+	return o.file.Close()
+}
@@ -0,0 +1,307 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestOutbox(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	o, err := OpenOutbox(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var seqs []uint64
+	for _, id := range []string{"u01", "u02", "u03"} {
+		msg := privateMessage(id)
+		msg.Options = []MsgOption{WithMsgBusChannel("b01"), WithMsgDisablePush(true)}
+		seq, err := o.Add(msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		seqs = append(seqs, seq)
+	}
+	if err := o.Done(seqs[1]); err != nil {
+		t.Fatal(err)
+	}
+	o.Close()
+
+	// 模拟写入记录时进程崩溃，日志末尾只有部分记录
+	f, err := os.OpenFile(filepath.Join(dir, OUTBOX_FILE), os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Write(encodeRecord(outboxDone, seqs[0], nil)[:10])
+	f.Close()
+
+	o, err = OpenOutbox(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer o.Close()
+	entries, err := o.Unfinished()
+	if err != nil || len(entries) != 2 || entries[0].Seq != seqs[0] || entries[1].Seq != seqs[2] {
+		t.Fatalf("Unfinished = %+v, %v", entries, err)
+	}
+	msg := entries[0].Message
+	if txt, ok := msg.Msg.(*TXTMsg); !ok || txt.Content != "hello" || msg.TargetID[0] != "u01" {
+		t.Errorf("message = %+v", msg)
+	}
+	if opts := MsgOptionsOf(msg.Options); opts.BusChannel != "b01" || !opts.DisablePush {
+		t.Errorf("options = %+v", opts)
+	}
+
+	// 序号在重新打开后继续递增
+	if seq, err := o.Add(privateMessage("u04")); err != nil || seq != seqs[2]+1 {
+		t.Errorf("Add = %d, %v", seq, err)
+	}
+	sender := &fakeSender{}
+	if err := o.Replay(context.Background(), sender); err != nil || len(sender.sent) != 3 {
+		t.Errorf("Replay = %v, sent %v", err, sender.sent)
+	}
+	if entries, _ := o.Unfinished(); len(entries) != 0 {
+		t.Errorf("after Replay: %d unfinished", len(entries))
+	}
+}
+
+func TestOutbox_Concurrent(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	o, err := OpenOutbox(dir, WithOutboxSync(false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			msg := privateMessage(fmt.Sprintf("u%02d", i))
+			// 偶数消息只记录发送意图，保留在 outbox 中
+			if i%2 == 0 {
+				o.Add(msg)
+				return
+			}
+			o.Send(context.Background(), &fakeSender{}, msg)
+		}(i)
+	}
+	wg.Wait()
+	o.Close()
+	if err := o.Done(1); err != ErrOutboxClosed {
+		t.Errorf("Done after Close = %v", err)
+	}
+
+	o, err = OpenOutbox(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer o.Close()
+	entries, _ := o.Unfinished()
+	if len(entries) != 25 {
+		t.Errorf("%d unfinished, want 25", len(entries))
+	}
+}
+
+func TestOutbox_Codec(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	codec := JSONOutboxCodec{DecodeMsg: func(objectName, content string) (RCMsg, error) {
+		return RawMsg(content), nil
+	}}
+	o, err := OpenOutbox(dir, WithOutboxCodec(codec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	o.Add(&SendMessage{Kind: SEND_SYSTEM, SenderID: "admin", TargetID: []string{"u01"}, ObjectName: "App:Custom", Msg: RawMsg(`{"k":"v"}`)})
+	o.Close()
+
+	o, err = OpenOutbox(dir, WithOutboxCodec(codec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer o.Close()
+	entries, _ := o.Unfinished()
+	if len(entries) != 1 || entries[0].Message.Msg != RawMsg(`{"k":"v"}`) {
+		t.Fatalf("Unfinished = %+v", entries)
+	}
+
+	// 未知消息类型默认还原为 RawMsg
+	data, _ := json.Marshal(outboxRecord{Kind: SEND_SYSTEM, ObjectName: "App:Custom", Content: `{"k":"v"}`})
+	if msg, err := (JSONOutboxCodec{}).Unmarshal(data); err != nil || msg.Msg != RawMsg(`{"k":"v"}`) {
+		t.Errorf("Unmarshal = %+v, %v", msg, err)
+	}
+	// 已知消息类型解码失败时返回错误
+	data, _ = json.Marshal(outboxRecord{Kind: SEND_SYSTEM, ObjectName: "RC:TxtMsg", Content: `{"content":1}`})
+	if msg, err := (JSONOutboxCodec{}).Unmarshal(data); err == nil {
+		t.Errorf("Unmarshal = %+v, expected error", msg)
+	}
+}
+
+func TestDispatcher_Outbox(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	o, err := OpenOutbox(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer o.Close()
+
+	// Shutdown 超时未发送的消息保留在 outbox 中
+	sender := &fakeSender{block: make(chan struct{})}
+	d, err := NewDispatcher(sender, WithDispatchWorkers(1), WithDispatchOutbox(o))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"u01", "u02"} {
+		if err := d.TryEnqueue(privateMessage(id)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if pending, _ := d.Shutdown(ctx); len(pending) != 2 {
+		t.Fatalf("%d pending", len(pending))
+	}
+	if entries, _ := o.Unfinished(); len(entries) != 2 {
+		t.Fatalf("%d unfinished", len(entries))
+	}
+
+	// 重新创建 Dispatcher 时发送未完成的消息
+	sender = &fakeSender{}
+	if d, err = NewDispatcher(sender, WithDispatchOutbox(o)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.Shutdown(context.Background()); err != nil || len(sender.sent) != 2 {
+		t.Errorf("Shutdown = %v, sent %v", err, sender.sent)
+	}
+	if entries, _ := o.Unfinished(); len(entries) != 0 {
+		t.Errorf("%d unfinished", len(entries))
+	}
+}
+
+func TestDispatcher_OutboxDoneError(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	o, err := OpenOutbox(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 发送期间 outbox 被关闭，标记完成失败的错误通过回调返回
+	sender := &fakeSender{block: make(chan struct{})}
+	results := make(chan DispatchResult, 1)
+	d, err := NewDispatcher(sender, WithDispatchOutbox(o), WithDispatchCallback(func(r DispatchResult) {
+		results <- r
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := d.TryEnqueue(privateMessage("u01")); err != nil {
+		t.Fatal(err)
+	}
+	o.Close()
+	close(sender.block)
+	if r := <-results; r.Err != nil || r.OutboxErr != ErrOutboxClosed {
+		t.Errorf("result = %+v", r)
+	}
+	_, _ = d.Shutdown(context.Background())
+}
+
+func TestOutbox_WriteError(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	o, err := OpenOutbox(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer o.Close()
+	if _, err := o.Add(privateMessage("u01")); err != nil {
+		t.Fatal(err)
+	}
+
+	// 日志文件不可写且无法截断时，之后的写入均返回 ErrOutboxBroken
+	o.lock.Lock()
+	file := o.file
+	if o.file, err = os.Open(filepath.Join(dir, OUTBOX_FILE)); err != nil {
+		t.Fatal(err)
+	}
+	o.lock.Unlock()
+	file.Close()
+	if _, err := o.Add(privateMessage("u02")); err == nil {
+		t.Fatal("Add: expected error")
+	}
+	if _, err := o.Add(privateMessage("u03")); !errors.Is(err, ErrOutboxBroken) {
+		t.Errorf("Add = %v, want ErrOutboxBroken", err)
+	}
+	if err := o.Done(1); !errors.Is(err, ErrOutboxBroken) {
+		t.Errorf("Done = %v, want ErrOutboxBroken", err)
+	}
+
+	reopened, err := OpenOutbox(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reopened.Close()
+	if entries, _ := reopened.Unfinished(); len(entries) != 1 || entries[0].Message.ID != "u01" {
+		t.Errorf("Unfinished = %+v", entries)
+	}
+}
+
+// failingCodec 打开 outbox 后可令 Unmarshal 失败
+type failingCodec struct {
+	JSONOutboxCodec
+	fail *bool
+}
+
+func (c failingCodec) Unmarshal(data []byte) (*SendMessage, error) {
+	if *c.fail {
+		return nil, errors.New("decode failed")
+	}
+	return c.JSONOutboxCodec.Unmarshal(data)
+}
+
+func TestDispatcher_OutboxRecover(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	fail := false
+	o, err := OpenOutbox(dir, WithOutboxCodec(failingCodec{fail: &fail}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer o.Close()
+	for _, id := range []string{"u01", "u02", "u03"} {
+		if _, err := o.Add(privateMessage(id)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// 未完成的消息无法还原时返回错误
+	fail = true
+	if d, err := NewDispatcher(&fakeSender{}, WithDispatchOutbox(o)); err == nil || d != nil {
+		t.Errorf("NewDispatcher = %v, %v", d, err)
+	}
+	fail = false
+
+	// 未完成的消息多于队列长度时不阻塞创建，Shutdown 超时后未入队的消息同样返回
+	sender := &fakeSender{block: make(chan struct{})}
+	d, err := NewDispatcher(sender, WithDispatchWorkers(1), WithDispatchQueueSize(1), WithDispatchOutbox(o))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if pending, err := d.Shutdown(ctx); err != context.DeadlineExceeded || len(pending) != 3 {
+		t.Errorf("Shutdown = %d pending, %v", len(pending), err)
+	}
+	if entries, _ := o.Unfinished(); len(entries) != 3 {
+		t.Errorf("%d unfinished", len(entries))
+	}
+}
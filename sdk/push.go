@@ -1,10 +1,8 @@
 package sdk
 
 import (
+	"context"
 	"encoding/json"
-	"time"
-
-	"github.com/astaxie/beego/httplib"
 )
 
 // PlatForm 广播类型
@@ -98,16 +96,19 @@ type Audience struct {
 *@return PushResult, error
  */
 func (rc *RongCloud) PushSend(sender Sender) (PushResult, error) {
-	req := httplib.Post(rc.rongCloudURI + "/push." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	return rc.PushSendContext(context.Background(), sender)
+}
+
+// PushSendContext 同 PushSend，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) PushSendContext(ctx context.Context, sender Sender) (PushResult, error) {
+	req := newRequest("/push")
 	req, err := req.JSONBody(sender)
 	if err != nil {
 		rc.urlError(err)
 		return PushResult{}, err
 	}
 
-	resp, err := rc.do(req)
+	resp, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 		return PushResult{}, err
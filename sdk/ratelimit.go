@@ -0,0 +1,173 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimit 时间窗口内的调用次数限制
+type RateLimit struct {
+	Limit  int           // 窗口内最多调用次数
+	Window time.Duration // 时间窗口
+}
+
+// RateLimitPolicy 客户端限流策略
+type RateLimitPolicy struct {
+	// Limits 覆盖默认限额，key 为不含域名及后缀的接口路径，如 /message/group/publish，value 为空时该接口不限流
+	// 共用限额的接口（/push 与 /message/broadcast）设置其中一个即对两者生效，同时设置时以 /push 为准
+	Limits map[string][]RateLimit
+	// FailFast 超出限额时立即返回错误码 1008，默认阻塞等待直到可以调用或 ctx 结束
+	FailFast bool
+}
+
+// defaultRateLimits 融云文档中说明的接口调用频率限制
+var defaultRateLimits = map[string][]RateLimit{
+	"/message/group/publish": {{Limit: 20, Window: time.Second}},
+	"/user/whitelist/add":    {{Limit: 100, Window: time.Second}},
+	"/user/whitelist/remove": {{Limit: 100, Window: time.Second}},
+	// 推送和广播消息合计，每小时最多 2 次，每天最多 3 次
+	// 广播消息撤回（MessageBroadcastRecall）同样调用 /message/broadcast，与服务端一致计入该限额
+	"/push":              {{Limit: 2, Window: time.Hour}, {Limit: 3, Window: 24 * time.Hour}},
+	"/message/broadcast": {{Limit: 2, Window: time.Hour}, {Limit: 3, Window: 24 * time.Hour}},
+}
+
+// rateLimitWeights 按目标数量计算调用次数的接口，value 为目标 ID 的参数名，如发送到 3 个群组计为 3 条群组消息
+var rateLimitWeights = map[string]string{
+	"/message/group/publish": "toGroupId",
+}
+
+// rateLimitGroups 共用限额的接口，按优先级排列
+var rateLimitGroups = map[string][]string{
+	"/push":              {"/push", "/message/broadcast"},
+	"/message/broadcast": {"/push", "/message/broadcast"},
+}
+
+// DefaultRateLimits 获取默认的接口限额
+func DefaultRateLimits() map[string][]RateLimit {
+	limits := make(map[string][]RateLimit, len(defaultRateLimits))
+	for k, v := range defaultRateLimits {
+		limits[k] = append([]RateLimit(nil), v...)
+	}
+	return limits
+}
+
+// RateLimiter 按应用和接口限流，同一个 RateLimiter 可以被多个 RongCloud 对象共用，相同 appKey 的对象共享限额
+type RateLimiter struct {
+	policy  RateLimitPolicy
+	lock    sync.Mutex
+	windows map[string][]*rateWindow
+}
+
+// rateWindow 滑动窗口，记录窗口内每次调用的时间
+type rateWindow struct {
+	RateLimit
+	calls []time.Time
+}
+
+// NewRateLimiter 创建限流器，未在 policy.Limits 中设置的接口使用默认限额
+func NewRateLimiter(policy RateLimitPolicy) *RateLimiter {
+	return &RateLimiter{
+		policy:  policy,
+		windows: map[string][]*rateWindow{},
+	}
+}
+
+// limits 获取接口的限额，共用限额的接口使用组内第一个设置了限额的接口
+func (l *RateLimiter) limits(path string) []RateLimit {
+	group, ok := rateLimitGroups[path]
+	if !ok {
+		group = []string{path}
+	}
+	for _, p := range group {
+		if limits, ok := l.policy.Limits[p]; ok {
+			return limits
+		}
+	}
+	return defaultRateLimits[path]
+}
+
+// reserve 尝试占用 n 次调用，返回需要等待的时间，为 0 时已占用成功
+// n 超过窗口限额时按限额计算，即需要等待窗口内的调用全部过期
+func (l *RateLimiter) reserve(appKey, path string, n int, now time.Time) time.Duration {
+	limits := l.limits(path)
+	if len(limits) == 0 {
+		return 0
+	}
+	key := path
+	if group, ok := rateLimitGroups[path]; ok {
+		key = strings.Join(group, ",")
+	}
+	key = appKey + ":" + key
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	windows, ok := l.windows[key]
+	if !ok {
+		for _, limit := range limits {
+			windows = append(windows, &rateWindow{RateLimit: limit})
+		}
+		l.windows[key] = windows
+	}
+
+	var wait time.Duration
+	for _, w := range windows {
+		// 移除窗口外的调用记录
+		i := 0
+		for i < len(w.calls) && now.Sub(w.calls[i]) >= w.Window {
+			i++
+		}
+		w.calls = w.calls[i:]
+		need := n
+		if need > w.Limit {
+			need = w.Limit
+		}
+		// 需要等待最早的 len(calls)+need-Limit 次调用过期
+		if over := len(w.calls) + need - w.Limit; over > 0 {
+			if d := w.calls[over-1].Add(w.Window).Sub(now); d > wait {
+				wait = d
+			}
+		}
+	}
+	if wait > 0 {
+		return wait
+	}
+	for _, w := range windows {
+		for i := 0; i < n; i++ {
+			w.calls = append(w.calls, now)
+		}
+	}
+	return 0
+}
+
+// Wait 等待直到可以调用接口，FailFast 时超出限额立即返回错误码 1008
+func (l *RateLimiter) Wait(ctx context.Context, appKey, path string) error {
+	return l.WaitN(ctx, appKey, path, 1)
+}
+
+// WaitN 同 Wait，一次占用 n 次调用，如一次发送到多个群组
+func (l *RateLimiter) WaitN(ctx context.Context, appKey, path string, n int) error {
+	if n < 1 {
+		n = 1
+	}
+	for {
+		wait := l.reserve(appKey, path, n, time.Now())
+		if wait == 0 {
+			return nil
+		}
+		if l.policy.FailFast {
+			return RCErrorNew(1008, fmt.Sprintf("Rate limit exceeded for '%s', retry after %s", path, wait))
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter_reserve(t *testing.T) {
+	l := NewRateLimiter(RateLimitPolicy{})
+	now := time.Now()
+	for i := 0; i < 2; i++ {
+		if wait := l.reserve("appKey", "/push", 1, now); wait != 0 {
+			t.Fatalf("call %d should be allowed, wait %v", i, wait)
+		}
+	}
+	// 推送和广播共享限额
+	if wait := l.reserve("appKey", "/message/broadcast", 1, now); wait != time.Hour {
+		t.Errorf("expected wait 1h, got %v", wait)
+	}
+	// 不同应用限额独立
+	if wait := l.reserve("appKey2", "/message/broadcast", 1, now); wait != 0 {
+		t.Errorf("other app should not be limited, wait %v", wait)
+	}
+	// 每天最多 3 次
+	if wait := l.reserve("appKey", "/push", 1, now.Add(time.Hour)); wait != 0 {
+		t.Errorf("third call should be allowed, wait %v", wait)
+	}
+	if wait := l.reserve("appKey", "/push", 1, now.Add(2*time.Hour)); wait != 22*time.Hour {
+		t.Errorf("expected wait 22h, got %v", wait)
+	}
+	// 未设置限额的接口不限流
+	for i := 0; i < 1000; i++ {
+		if wait := l.reserve("appKey", "/user/getToken", 1, now); wait != 0 {
+			t.Fatal("unlimited endpoint should not wait")
+		}
+	}
+}
+
+func TestRateLimiter_groupOverride(t *testing.T) {
+	// 只设置广播的限额时对推送同样生效，与先调用哪个接口无关
+	l := NewRateLimiter(RateLimitPolicy{Limits: map[string][]RateLimit{
+		"/message/broadcast": {{Limit: 5, Window: time.Hour}},
+	}})
+	now := time.Now()
+	for i := 0; i < 5; i++ {
+		path := "/push"
+		if i%2 == 1 {
+			path = "/message/broadcast"
+		}
+		if wait := l.reserve("appKey", path, 1, now); wait != 0 {
+			t.Fatalf("call %d should be allowed, wait %v", i, wait)
+		}
+	}
+	if wait := l.reserve("appKey", "/message/broadcast", 1, now); wait != time.Hour {
+		t.Errorf("expected wait 1h, got %v", wait)
+	}
+}
+
+func TestRateLimiter_weight(t *testing.T) {
+	// 群组消息按群组数量计算，每秒 20 条
+	l := NewRateLimiter(RateLimitPolicy{})
+	now := time.Now()
+	for i := 0; i < 6; i++ {
+		if wait := l.reserve("appKey", "/message/group/publish", 3, now.Add(time.Duration(i)*time.Millisecond)); wait != 0 {
+			t.Fatalf("call %d should be allowed, wait %v", i, wait)
+		}
+	}
+	// 已占用 18 条，再发送到 3 个群组需等待第 1 次调用过期
+	if wait := l.reserve("appKey", "/message/group/publish", 3, now.Add(10*time.Millisecond)); wait != 990*time.Millisecond {
+		t.Errorf("expected wait 990ms, got %v", wait)
+	}
+	if wait := l.reserve("appKey", "/message/group/publish", 2, now.Add(10*time.Millisecond)); wait != 0 {
+		t.Errorf("expected no wait, got %v", wait)
+	}
+
+	req := newRequest("/message/group/publish")
+	for _, id := range []string{"g1", "g2", "g3"} {
+		req.Param("toGroupId", id)
+	}
+	if n := req.rateLimitWeight(); n != 3 {
+		t.Errorf("weight = %d, want 3", n)
+	}
+	if n := newRequest("/push").rateLimitWeight(); n != 1 {
+		t.Errorf("weight = %d, want 1", n)
+	}
+}
+
+func TestRateLimiter_Wait(t *testing.T) {
+	l := NewRateLimiter(RateLimitPolicy{
+		Limits: map[string][]RateLimit{"/user/getToken": {{Limit: 1, Window: 50 * time.Millisecond}}},
+	})
+	ctx := context.Background()
+	if err := l.Wait(ctx, "appKey", "/user/getToken"); err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	if err := l.Wait(ctx, "appKey", "/user/getToken"); err != nil {
+		t.Fatal(err)
+	}
+	if time.Since(start) < 40*time.Millisecond {
+		t.Error("second call should be blocked")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if err := l.Wait(ctx, "appKey", "/user/getToken"); err != context.DeadlineExceeded {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestWithRateLimiter(t *testing.T) {
+	var count int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&count, 1)
+		_, _ = w.Write([]byte(`{"code":200}`))
+	}))
+	defer server.Close()
+
+	limiter := NewRateLimiter(RateLimitPolicy{FailFast: true})
+	rc1 := New("appKey", "appSecret", WithRongCloudURI(server.URL), WithRateLimiter(limiter))
+	rc2 := New("appKey", "appSecret", WithRongCloudURI(server.URL), WithRateLimiter(limiter))
+
+	msg := &TXTMsg{Content: "hello"}
+	if err := rc1.SystemBroadcast("u01", "RC:TxtMsg", msg); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := rc2.PushSend(Push{}); err != nil {
+		t.Fatal(err)
+	}
+	err := rc1.SystemBroadcast("u01", "RC:TxtMsg", msg)
+	var code CodeResult
+	if !errors.As(err, &code) || code.Code != 1008 {
+		t.Errorf("expected code 1008, got %v", err)
+	}
+	if count != 2 {
+		t.Errorf("expected 2 requests, got %d", count)
+	}
+}
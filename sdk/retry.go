@@ -0,0 +1,228 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"os"
+	"syscall"
+	"time"
+)
+
+const (
+	// DEFAULT_RETRY_BASE_DELAY 默认第一次重试前的等待时间
+	DEFAULT_RETRY_BASE_DELAY = 100 * time.Millisecond
+	// DEFAULT_RETRY_MAX_DELAY 默认单次重试最长等待时间
+	DEFAULT_RETRY_MAX_DELAY = 2 * time.Second
+)
+
+// RetryPolicy 请求失败后的重试策略
+type RetryPolicy struct {
+	// MaxAttempts 最多请求次数（包含第一次请求），小于等于 1 时不重试
+	MaxAttempts int
+	// BaseDelay 第一次重试前的等待时间，之后每次重试翻倍，为 0 时使用 DEFAULT_RETRY_BASE_DELAY
+	BaseDelay time.Duration
+	// MaxDelay 单次重试最长等待时间，为 0 时使用 DEFAULT_RETRY_MAX_DELAY
+	MaxDelay time.Duration
+	// Jitter 等待时间随机抖动比例，取值 0 ~ 1，如 0.2 表示在计算出的等待时间基础上随机减少 0 ~ 20%
+	Jitter float64
+	// RetryableCodes 可重试的融云错误码，为空时使用 defaultRetryableCodes
+	RetryableCodes []int
+	// Retryable 自定义可重试错误的判断，设置后 RetryableCodes 及默认的网络错误、5xx 判断不再生效
+	Retryable func(statusCode int, err error) bool
+	// Idempotent 覆盖接口是否可安全重复调用，key 为不含域名及后缀的接口路径，如 /message/private/publish
+	Idempotent map[string]bool
+}
+
+// defaultRetryableCodes 默认可重试的融云错误码
+var defaultRetryableCodes = []int{
+	1000, // 服务内部错误
+	1050, // 内部服务响应超时
+}
+
+// idempotentEndpoints 可安全重复调用的接口，重复调用不会产生额外的副作用
+// 不在列表中的接口（如消息发送、撤回、推送）只在请求确定未发出时才会重试。
+// /user/getToken 每次调用都会生成新 Token，重试可能使第一次请求返回的 Token 失效，因此不在列表中
+var idempotentEndpoints = map[string]bool{
+	"/user/refresh":                        true,
+	"/user/checkOnline":                    true,
+	"/user/block":                          true,
+	"/user/unblock":                        true,
+	"/user/block/query":                    true,
+	"/user/blacklist/add":                  true,
+	"/user/blacklist/remove":               true,
+	"/user/blacklist/query":                true,
+	"/user/whitelist/add":                  true,
+	"/user/whitelist/remove":               true,
+	"/user/whitelist/query":                true,
+	"/user/tag/set":                        true,
+	"/user/tag/batch/set":                  true,
+	"/user/tags/get":                       true,
+	"/group/sync":                          true,
+	"/group/refresh":                       true,
+	"/group/join":                          true,
+	"/group/quit":                          true,
+	"/group/dismiss":                       true,
+	"/group/user/query":                    true,
+	"/group/user/gag/add":                  true,
+	"/group/user/gag/rollback":             true,
+	"/group/user/gag/list":                 true,
+	"/group/ban/add":                       true,
+	"/group/ban/rollback":                  true,
+	"/group/ban/query":                     true,
+	"/group/user/ban/whitelist/add":        true,
+	"/group/user/ban/whitelist/rollback":   true,
+	"/group/user/ban/whitelist/query":      true,
+	"/chatroom/destroy":                    true,
+	"/chatroom/query":                      true,
+	"/chatroom/user/query":                 true,
+	"/chatroom/users/exist":                true,
+	"/chatroom/user/block/add":             true,
+	"/chatroom/user/block/rollback":        true,
+	"/chatroom/user/block/list":            true,
+	"/chatroom/user/ban/add":               true,
+	"/chatroom/user/ban/remove":            true,
+	"/chatroom/user/ban/query":             true,
+	"/chatroom/user/gag/add":               true,
+	"/chatroom/user/gag/rollback":          true,
+	"/chatroom/user/gag/list":              true,
+	"/chatroom/message/priority/add":       true,
+	"/chatroom/message/priority/remove":    true,
+	"/chatroom/message/priority/query":     true,
+	"/chatroom/message/stopDistribution":   true,
+	"/chatroom/message/resumeDistribution": true,
+	"/chatroom/keepalive/add":              true,
+	"/chatroom/keepalive/remove":           true,
+	"/chatroom/keepalive/query":            true,
+	"/chatroom/whitelist/add":              true,
+	"/chatroom/whitelist/delete":           true,
+	"/chatroom/whitelist/query":            true,
+	"/chatroom/user/whitelist/add":         true,
+	"/chatroom/user/whitelist/remove":      true,
+	"/chatroom/user/whitelist/query":       true,
+	"/chatroom/entry/set":                  true,
+	"/chatroom/entry/remove":               true,
+	"/chatroom/entry/query":                true,
+	"/conversation/notification/set":       true,
+	"/conversation/notification/get":       true,
+	"/sensitiveword/add":                   true,
+	"/sensitiveword/batch/delete":          true,
+	"/sensitiveword/list":                  true,
+	"/message/history":                     true,
+	"/message/history/delete":              true,
+}
+
+// isIdempotent 接口是否可安全重复调用
+func (p *RetryPolicy) isIdempotent(path string) bool {
+	if v, ok := p.Idempotent[path]; ok {
+		return v
+	}
+	return idempotentEndpoints[path]
+}
+
+// isRetryable 判断请求结果是否可重试
+// 不可安全重复调用的接口只在连接未建立（请求确定未发出）时重试
+func (p *RetryPolicy) isRetryable(path string, statusCode int, err error) bool {
+	if err == nil {
+		return false
+	}
+	if !p.isIdempotent(path) {
+		return isDialError(err)
+	}
+	return p.retryableError(statusCode, err)
+}
+
+// retryableError 不考虑接口是否可重复调用，判断错误是否可重试
+func (p *RetryPolicy) retryableError(statusCode int, err error) bool {
+	if p.Retryable != nil {
+		return p.Retryable(statusCode, err)
+	}
+	if isNetError(err) || (statusCode >= 500 && statusCode < 600) {
+		return true
+	}
+	if code := responseCode(err); code != 0 {
+		codes := p.RetryableCodes
+		if len(codes) == 0 {
+			codes = defaultRetryableCodes
+		}
+		for _, c := range codes {
+			if c == code {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// backoff 第 attempt 次重试前的等待时间，指数增长并增加随机抖动
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	base, max := p.BaseDelay, p.MaxDelay
+	if base <= 0 {
+		base = DEFAULT_RETRY_BASE_DELAY
+	}
+	if max <= 0 {
+		max = DEFAULT_RETRY_MAX_DELAY
+	}
+
+	delay := base
+	for i := 1; i < attempt && delay < max; i++ {
+		delay *= 2
+	}
+	if delay > max {
+		delay = max
+	}
+	if p.Jitter > 0 {
+		jitter := p.Jitter
+		if jitter > 1 {
+			jitter = 1
+		}
+		delay -= time.Duration(float64(delay) * jitter * rand.Float64())
+	}
+	return delay
+}
+
+// isDialError 是否为建立连接阶段的错误，此时请求一定没有发送到服务端
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	if !errors.As(err, &opErr) || opErr.Op != "dial" {
+		return false
+	}
+
+	switch t := opErr.Err.(type) {
+	case *net.DNSError:
+		return true
+	case *os.SyscallError:
+		if errno, ok := t.Err.(syscall.Errno); ok {
+			return errno == syscall.ECONNREFUSED
+		}
+	}
+	return false
+}
+
+// doRetry 按重试策略发送请求，每次重试前等待退避时间，域名切换后重试会发往新的地址
+func (rc *RongCloud) doRetry(ctx context.Context, r *request) (body []byte, err error) {
+	policy := rc.retryPolicy
+	if policy == nil || policy.MaxAttempts <= 1 {
+		body, _, err = rc.httpRequest(ctx, r)
+		return body, err
+	}
+
+	var statusCode int
+	for attempt := 1; ; attempt++ {
+		body, statusCode, err = rc.httpRequest(ctx, r)
+		if attempt >= policy.MaxAttempts || !policy.isRetryable(r.path, statusCode, err) {
+			return body, err
+		}
+
+		timer := time.NewTimer(policy.backoff(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			// 返回 ctx 的错误以便区分取消与服务端错误，同时保留最后一次请求的错误信息
+			return nil, fmt.Errorf("%w (last error: %v)", ctx.Err(), err)
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	p := RetryPolicy{BaseDelay: 10 * time.Millisecond, MaxDelay: 50 * time.Millisecond}
+	expected := []time.Duration{10, 20, 40, 50, 50}
+	for i, v := range expected {
+		if d := p.backoff(i + 1); d != v*time.Millisecond {
+			t.Errorf("attempt %d: expected %v, got %v", i+1, v*time.Millisecond, d)
+		}
+	}
+
+	p.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		if d := p.backoff(2); d < 10*time.Millisecond || d > 20*time.Millisecond {
+			t.Fatalf("invalid jitter delay: %v", d)
+		}
+	}
+}
+
+func TestRetryPolicy_isRetryable(t *testing.T) {
+	p := RetryPolicy{Idempotent: map[string]bool{"/user/refresh": false}}
+	if !p.isRetryable("/user/block/query", 502, RCErrorNew(1000, "")) {
+		t.Error("5xx of query should be retryable")
+	}
+	if !p.isRetryable("/user/block/query", 200, RCErrorNew(1050, "")) {
+		t.Error("code 1050 should be retryable")
+	}
+	if p.isRetryable("/user/block/query", 200, RCErrorNew(1002, "")) {
+		t.Error("code 1002 should not be retryable")
+	}
+	if p.isRetryable("/message/private/publish", 502, RCErrorNew(1000, "")) {
+		t.Error("5xx of message send should not be retryable")
+	}
+	if p.isRetryable("/user/refresh", 502, RCErrorNew(1000, "")) {
+		t.Error("idempotent override should be used")
+	}
+	if p.isRetryable("/user/getToken", 502, RCErrorNew(1000, "")) {
+		t.Error("5xx of getToken should not be retryable")
+	}
+}
+
+func TestWithRetryPolicy(t *testing.T) {
+	var count int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&count, 1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte(`{"code":200,"users":[]}`))
+	}))
+	defer server.Close()
+
+	rc := New("appKey", "appSecret",
+		WithRongCloudURI(server.URL),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}),
+	)
+	if _, err := rc.BlockGetList(); err != nil {
+		t.Fatal(err)
+	}
+	if count != 3 {
+		t.Errorf("expected 3 attempts, got %d", count)
+	}
+
+	atomic.StoreInt32(&count, 0)
+	err := rc.PrivateSend("u01", []string{"u02"}, "RC:TxtMsg", &TXTMsg{Content: "hello"}, "", "", 1, 0, 1, 0, 0)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if count != 1 {
+		t.Errorf("message send should not be retried, got %d attempts", count)
+	}
+}
+
+func TestWithRetryPolicy_canceled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	rc := New("appKey", "appSecret",
+		WithRongCloudURI(server.URL),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 3, BaseDelay: time.Second}),
+	)
+	// 等待重试期间 ctx 超时，返回 ctx 的错误
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := rc.BlockGetListContext(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestWithRetryPolicy_dialError(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ln.Addr().String()
+	_ = ln.Close()
+
+	var count int32
+	rc := New("appKey", "appSecret",
+		WithRongCloudURI("http://"+addr),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}),
+	)
+	rc.SetHTTPExecutor(executorFunc(func(req *http.Request) (*http.Response, error) {
+		atomic.AddInt32(&count, 1)
+		return http.DefaultClient.Do(req)
+	}))
+
+	err = rc.PrivateSend("u01", []string{"u02"}, "RC:TxtMsg", &TXTMsg{Content: "hello"}, "", "", 1, 0, 1, 0, 0)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if count != 3 {
+		t.Errorf("refused connection should be retried, got %d attempts", count)
+	}
+}
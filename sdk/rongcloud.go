@@ -40,16 +40,17 @@ package sdk
 
 import (
 	"crypto/sha1"
+	"crypto/subtle"
 	"fmt"
 	"io"
 	"math/rand"
 	"net"
 	"net/http"
 	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
-
-	"github.com/astaxie/beego/httplib"
 )
 
 const (
@@ -83,6 +84,9 @@ var (
 		count:               0,
 		changeUriDuration:   DEFAULT_CHANGE_URI_DURATION,
 		lastChageUriTime:    0,
+		tracer:              noopTracer{},
+		secretGracePeriod:   DEFAULT_SECRET_GRACE_PERIOD,
+		batchParallelism:    DEFAULT_BATCH_PARALLELISM,
 	}
 	rc   *RongCloud
 	once sync.Once
@@ -90,11 +94,21 @@ var (
 
 // RongCloud appKey appSecret extra
 type RongCloud struct {
-	appKey    string
-	appSecret string
+	// creds 当前凭证 *credentialState，可通过 SetCredentials 在运行时替换
+	creds     atomic.Value
+	credsLock sync.Mutex
 	*rongCloudExtra
-	uriLock         sync.Mutex
+	uriLock sync.Mutex
+	// httpLock 保护 globalTransport、httpClient 及 executor，可在请求进行中替换
+	httpLock        sync.RWMutex
 	globalTransport *http.Transport
+	httpClient      *http.Client // 使用 globalTransport 的默认执行方式
+	executor        HTTPExecutor
+	endpoints       []*endpoint
+	activeEndpoint  int
+	endpointReason  string
+	closed          chan struct{}
+	closeOnce       sync.Once
 }
 
 // rongCloudExtra rongCloud扩展增加自定义融云服务器地址,请求超时时间
@@ -107,120 +121,183 @@ type rongCloudExtra struct {
 	count               uint
 	changeUriDuration   int64
 	lastChageUriTime    int64
+	retryPolicy         *RetryPolicy
+	endpointURIs        []string
+	failoverPolicy      FailoverPolicy
+	rateLimiter         *RateLimiter
+	interceptors        []Interceptor
+	metrics             MetricsCollector
+	tracer              Tracer
+	presence            PresenceCache
+	secretGracePeriod   time.Duration
+	batchParallelism    int
 }
 
 // getSignature 本地生成签名
 // Signature (数据签名)计算方法：将系统分配的 App Secret、Nonce (随机数)、
 // Timestamp (时间戳)三个字符串按先后顺序拼接成一个字符串并进行 SHA1 哈希计算。如果调用的数据签名验证失败，接口调用会返回 HTTP 状态码 401。
-func (rc RongCloud) getSignature() (nonce, timestamp, signature string) {
+func (rc *RongCloud) getSignature(appSecret string) (nonce, timestamp, signature string) {
 	nonceInt := rand.Int()
 	nonce = strconv.Itoa(nonceInt)
 	timeInt64 := time.Now().Unix()
 	timestamp = strconv.FormatInt(timeInt64, 10)
-	h := sha1.New()
-	_, _ = io.WriteString(h, rc.appSecret+nonce+timestamp)
-	signature = fmt.Sprintf("%x", h.Sum(nil))
+	signature = sign(appSecret, nonce, timestamp)
 	return
 }
 
-// fillHeader 在 Http Header 增加API签名
-func (rc RongCloud) fillHeader(req *httplib.BeegoHTTPRequest) {
-	nonce, timestamp, signature := rc.getSignature()
-	req.Header("App-Key", rc.appKey)
-	req.Header("Nonce", nonce)
-	req.Header("Timestamp", timestamp)
-	req.Header("Signature", signature)
-	req.Header("Content-Type", "application/x-www-form-urlencoded")
-	req.Header("User-Agent", USERAGENT)
+// sign 将 App Secret、Nonce、Timestamp 按顺序拼接后计算 SHA1 签名
+func sign(appSecret, nonce, timestamp string) string {
+	h := sha1.New()
+	_, _ = io.WriteString(h, appSecret+nonce+timestamp)
+	return fmt.Sprintf("%x", h.Sum(nil))
 }
 
-// fillJSONHeader 在 Http Header Content-Type 设置为josn格式
-func fillJSONHeader(req *httplib.BeegoHTTPRequest) {
-	req.Header("Content-Type", "application/json")
+// VerifySignature 校验融云服务端回调（消息路由、在线状态、聊天室状态等）的签名，签名算法与 API 请求签名相同
+// 轮换 appSecret 后的宽限期内，使用旧 appSecret 生成的签名同样校验通过
+func (rc *RongCloud) VerifySignature(nonce, timestamp, signature string) bool {
+	creds := rc.credentials()
+	signature = strings.ToLower(signature)
+	if verify(creds.AppSecret, nonce, timestamp, signature) {
+		return true
+	}
+	return creds.previousSecret != "" && time.Now().Before(creds.previousExpires) &&
+		verify(creds.previousSecret, nonce, timestamp, signature)
+}
+
+// verify 比较签名，比较时间与签名内容无关
+func verify(appSecret, nonce, timestamp, signature string) bool {
+	expected := sign(appSecret, nonce, timestamp)
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(signature)) == 1
+}
+
+// AppKey 获取 appKey
+func (rc *RongCloud) AppKey() string {
+	return rc.credentials().AppKey
+}
+
+// fillHeader 在 Http Header 增加API签名，appKey 与签名使用同一份凭证
+func (rc *RongCloud) fillHeader(req *http.Request) {
+	creds := rc.credentials()
+	nonce, timestamp, signature := rc.getSignature(creds.AppSecret)
+	req.Header.Set("App-Key", creds.AppKey)
+	req.Header.Set("Nonce", nonce)
+	req.Header.Set("Timestamp", timestamp)
+	req.Header.Set("Signature", signature)
+	req.Header.Set("User-Agent", USERAGENT)
+}
+
+// New 创建 RongCloud 对象，每次调用都返回一个新的独立对象
+// 不同对象之间的 appKey、appSecret、http 连接、API 地址、超时时间及域名切换状态互不影响，适用于同一进程内使用多个应用的场景
+func New(appKey, appSecret string, options ...RongCloudOption) *RongCloud {
+	// 默认扩展配置
+	defaultRongCloud := defaultExtra
+	defaultRongCloud.lastChageUriTime = 0
+	client := &RongCloud{
+		rongCloudExtra: &defaultRongCloud,
+		closed:         make(chan struct{}),
+	}
+	client.creds.Store(&credentialState{Credentials: Credentials{AppKey: appKey, AppSecret: appSecret}})
+
+	for _, option := range options {
+		option(client)
+	}
+	client.initEndpoints(client.endpointURIs)
+	// 全局 httpClient，解决 http 打开端口过多问题
+	dialer := &net.Dialer{
+		Timeout:   client.timeout * time.Second,
+		KeepAlive: client.keepAlive * time.Second,
+	}
+
+	client.SetHttpTransport(&http.Transport{
+		DialContext:         dialer.DialContext,
+		MaxIdleConnsPerHost: client.maxIdleConnsPerHost,
+	})
+
+	// 探测使用 http 执行方式，需在其初始化之后启动
+	if client.failoverPolicy.ProbeInterval > 0 {
+		go client.probeEndpoints(client.failoverPolicy.ProbeInterval)
+	}
+	return client
 }
 
-// NewRongCloud 创建 RongCloud 对象
-func NewRongCloud(appKey, appSecret string, options ...rongCloudOption) *RongCloud {
+// NewRongCloud 创建全局 RongCloud 对象
+// 仅第一次调用时创建，之后的调用（即使 appKey、appSecret 不同）都返回同一个对象，可通过 GetRongCloud 获取。
+// 需要同时使用多个应用时请使用 New
+func NewRongCloud(appKey, appSecret string, options ...RongCloudOption) *RongCloud {
 	once.Do(func() {
-		// 默认扩展配置
-		defaultRongCloud := defaultExtra
-		defaultRongCloud.lastChageUriTime = 0
-		rc = &RongCloud{
-			appKey:         appKey,
-			appSecret:      appSecret,
-			rongCloudExtra: &defaultRongCloud,
-		}
-
-		for _, option := range options {
-			option(rc)
-		}
-		// 全局 httpClient，解决 http 打开端口过多问题
-		dialer := &net.Dialer{
-			Timeout:   rc.timeout * time.Second,
-			KeepAlive: rc.keepAlive * time.Second,
-		}
-
-		rc.globalTransport = &http.Transport{
-			DialContext:         dialer.DialContext,
-			MaxIdleConnsPerHost: rc.maxIdleConnsPerHost,
-		}
-	},
-	)
+		rc = New(appKey, appSecret, options...)
+	})
 
 	return rc
 }
 
-// GetRongCloud 获取 RongCloud 对象
+// GetRongCloud 获取由 NewRongCloud 创建的全局 RongCloud 对象，未创建时返回 nil
 func GetRongCloud() *RongCloud {
 	return rc
 }
 
 // 自定义 http 参数
 func (rc *RongCloud) SetHttpTransport(httpTransport *http.Transport) {
+	rc.httpLock.Lock()
+	defer rc.httpLock.Unlock()
 	rc.globalTransport = httpTransport
+	rc.httpClient = &http.Client{Transport: httpTransport}
 }
 
 func (rc *RongCloud) GetHttpTransport() *http.Transport {
+	rc.httpLock.RLock()
+	defer rc.httpLock.RUnlock()
 	return rc.globalTransport
 }
 
-// changeURI 自动切换 Api 服务器地址
-// 在 api、api2之间自动切换。无法切换其他域名。其他请使用 PrivateURI 设置
+// SetHTTPExecutor 自定义 http 请求的执行方式，设置后 SetHttpTransport 设置的 Transport 不再生效
+func (rc *RongCloud) SetHTTPExecutor(executor HTTPExecutor) {
+	rc.httpLock.Lock()
+	defer rc.httpLock.Unlock()
+	rc.executor = executor
+}
+
+// GetHTTPExecutor 获取 http 请求的执行方式，未设置时返回使用全局 Transport 的 http.Client
+func (rc *RongCloud) GetHTTPExecutor() HTTPExecutor {
+	rc.httpLock.RLock()
+	defer rc.httpLock.RUnlock()
+	if rc.executor != nil {
+		return rc.executor
+	}
+	return rc.httpClient
+}
+
+// ChangeURI 切换 Api 服务器地址
+// 当前地址进入冷却，切换到下一个健康的地址。只有一个地址时无法切换，多个地址请使用 WithEndpoints 设置
+// 距离上次切换（包括自动故障切换）不足 changeUriDuration 秒时不切换
 func (rc *RongCloud) ChangeURI() {
-	nowUnix := time.Now().Unix()
-	// 检查距离上次更换uri的时间间隔
 	rc.uriLock.Lock()
-	if (nowUnix - rc.lastChageUriTime) >= rc.changeUriDuration {
-		switch rc.rongCloudURI {
-		case RONGCLOUDURI:
-			rc.rongCloudURI = RONGCLOUDURI2
-		case RONGCLOUDURI2:
-			rc.rongCloudURI = RONGCLOUDURI
-		default:
-		}
-		rc.lastChageUriTime = nowUnix
+	defer rc.uriLock.Unlock()
+	now := time.Now()
+	if len(rc.endpoints) == 0 || now.Unix()-rc.lastChageUriTime < rc.changeUriDuration {
+		return
 	}
-	rc.uriLock.Unlock()
+	e := rc.endpoints[rc.activeEndpoint]
+	e.lastError = "ChangeURI called"
+	rc.coolDown(e, now)
 }
 
-// PrivateURI 私有云设置 Api 地址
+// PrivateURI 私有云设置 Api 地址，会替换原有的地址列表，多个地址请使用 WithEndpoints 设置
 func (rc *RongCloud) PrivateURI(uri, sms string) {
-	rc.rongCloudURI = uri
+	rc.uriLock.Lock()
 	rc.rongCloudSMSURI = sms
+	rc.uriLock.Unlock()
+	rc.initEndpoints([]string{uri})
+}
+
+// Close 停止后台任务（如 API 地址探测、凭证刷新），Close 后仍可继续调用 API
+func (rc *RongCloud) Close() {
+	rc.closeOnce.Do(func() {
+		close(rc.closed)
+	})
 }
 
 // urlError 判断是否为 url.Error
 func (rc *RongCloud) urlError(err error) {
 	// 方法已废弃
 }
-
-/**
-判断 http status code, 如果大于 500 就切换一次域名
-*/
-func (rc *RongCloud) checkStatusCode(resp *http.Response) {
-	if resp.StatusCode >= 500 && resp.StatusCode < 600 {
-		rc.ChangeURI()
-	}
-
-	return
-}
@@ -21,3 +21,28 @@ func TestGetRongCloud(t *testing.T) {
     rc := GetRongCloud()
     t.Log(rc)
 }
+
+func TestNew(t *testing.T) {
+    rc1 := New("key1", "secret1", WithRongCloudURI("http://api1.test.com"))
+    rc2 := New("key2", "secret2", WithTimeout(20))
+    if rc1 == rc2 {
+        t.Fatal("New should return a new client every time")
+    }
+    if rc1.AppKey() != "key1" || rc2.AppKey() != "key2" {
+        t.Errorf("invalid app key: %s, %s", rc1.AppKey(), rc2.AppKey())
+    }
+    if rc1.rongCloudURI != "http://api1.test.com" || rc2.rongCloudURI != RONGCLOUDURI {
+        t.Errorf("invalid rong cloud uri: %s, %s", rc1.rongCloudURI, rc2.rongCloudURI)
+    }
+    if rc1.timeout != DEFAULTTIMEOUT || rc2.timeout != 20 {
+        t.Errorf("invalid timeout: %d, %d", rc1.timeout, rc2.timeout)
+    }
+    if rc1.GetHttpTransport() == rc2.GetHttpTransport() {
+        t.Error("clients should not share http transport")
+    }
+
+    rc2.ChangeURI()
+    if rc1.rongCloudURI != "http://api1.test.com" || rc2.rongCloudURI != RONGCLOUDURI2 {
+        t.Errorf("failover state should be independent: %s, %s", rc1.rongCloudURI, rc2.rongCloudURI)
+    }
+}
@@ -0,0 +1,1046 @@
+// Code generated by gen.go; DO NOT EDIT.
+
+package sdkmock
+
+import (
+	"context"
+	"time"
+
+	"github.com/rongcloud/server-sdk-go/v3/sdk"
+)
+
+// Client sdk.Client 的 mock 实现，同时实现了 sdk.UserClient 等按领域拆分的接口
+type Client struct {
+	Mock
+}
+
+// NewClient 创建 Client
+func NewClient() *Client {
+	return &Client{}
+}
+
+var _ sdk.Client = (*Client)(nil)
+
+// sdk.UserClient 的方法
+
+func (m *Client) AddWhiteList(userId string, whiteList []string) (r0 error) {
+	m.called("AddWhiteList", []interface{}{userId, whiteList}, &r0)
+	return
+}
+
+func (m *Client) AddWhiteListContext(ctx context.Context, userId string, whiteList []string) (r0 error) {
+	m.called("AddWhiteListContext", []interface{}{ctx, userId, whiteList}, &r0)
+	return
+}
+
+func (m *Client) RemoveWhiteList(userId string, whiteList []string) (r0 error) {
+	m.called("RemoveWhiteList", []interface{}{userId, whiteList}, &r0)
+	return
+}
+
+func (m *Client) RemoveWhiteListContext(ctx context.Context, userId string, whiteList []string) (r0 error) {
+	m.called("RemoveWhiteListContext", []interface{}{ctx, userId, whiteList}, &r0)
+	return
+}
+
+func (m *Client) QueryWhiteList(userId string) (r0 sdk.WhiteList, r1 error) {
+	m.called("QueryWhiteList", []interface{}{userId}, &r0, &r1)
+	return
+}
+
+func (m *Client) QueryWhiteListContext(ctx context.Context, userId string) (r0 sdk.WhiteList, r1 error) {
+	m.called("QueryWhiteListContext", []interface{}{ctx, userId}, &r0, &r1)
+	return
+}
+
+func (m *Client) UserRegister(userID string, name string, portraitURI string) (r0 sdk.User, r1 error) {
+	m.called("UserRegister", []interface{}{userID, name, portraitURI}, &r0, &r1)
+	return
+}
+
+func (m *Client) UserRegisterContext(ctx context.Context, userID string, name string, portraitURI string) (r0 sdk.User, r1 error) {
+	m.called("UserRegisterContext", []interface{}{ctx, userID, name, portraitURI}, &r0, &r1)
+	return
+}
+
+func (m *Client) UserUpdate(userID string, name string, portraitURI string) (r0 error) {
+	m.called("UserUpdate", []interface{}{userID, name, portraitURI}, &r0)
+	return
+}
+
+func (m *Client) UserUpdateContext(ctx context.Context, userID string, name string, portraitURI string) (r0 error) {
+	m.called("UserUpdateContext", []interface{}{ctx, userID, name, portraitURI}, &r0)
+	return
+}
+
+func (m *Client) BlockAdd(id string, minute uint64) (r0 error) {
+	m.called("BlockAdd", []interface{}{id, minute}, &r0)
+	return
+}
+
+func (m *Client) BlockAddContext(ctx context.Context, id string, minute uint64) (r0 error) {
+	m.called("BlockAddContext", []interface{}{ctx, id, minute}, &r0)
+	return
+}
+
+func (m *Client) BlockRemove(id string) (r0 error) {
+	m.called("BlockRemove", []interface{}{id}, &r0)
+	return
+}
+
+func (m *Client) BlockRemoveContext(ctx context.Context, id string) (r0 error) {
+	m.called("BlockRemoveContext", []interface{}{ctx, id}, &r0)
+	return
+}
+
+func (m *Client) BlockGetList() (r0 sdk.BlockListResult, r1 error) {
+	m.called("BlockGetList", []interface{}{}, &r0, &r1)
+	return
+}
+
+func (m *Client) BlockGetListContext(ctx context.Context) (r0 sdk.BlockListResult, r1 error) {
+	m.called("BlockGetListContext", []interface{}{ctx}, &r0, &r1)
+	return
+}
+
+func (m *Client) BlacklistAdd(id string, blacklist []string) (r0 error) {
+	m.called("BlacklistAdd", []interface{}{id, blacklist}, &r0)
+	return
+}
+
+func (m *Client) BlacklistAddContext(ctx context.Context, id string, blacklist []string) (r0 error) {
+	m.called("BlacklistAddContext", []interface{}{ctx, id, blacklist}, &r0)
+	return
+}
+
+func (m *Client) BlacklistRemove(id string, blacklist []string) (r0 error) {
+	m.called("BlacklistRemove", []interface{}{id, blacklist}, &r0)
+	return
+}
+
+func (m *Client) BlacklistRemoveContext(ctx context.Context, id string, blacklist []string) (r0 error) {
+	m.called("BlacklistRemoveContext", []interface{}{ctx, id, blacklist}, &r0)
+	return
+}
+
+func (m *Client) BlacklistGet(id string) (r0 sdk.BlacklistResult, r1 error) {
+	m.called("BlacklistGet", []interface{}{id}, &r0, &r1)
+	return
+}
+
+func (m *Client) BlacklistGetContext(ctx context.Context, id string) (r0 sdk.BlacklistResult, r1 error) {
+	m.called("BlacklistGetContext", []interface{}{ctx, id}, &r0, &r1)
+	return
+}
+
+func (m *Client) OnlineStatusCheck(userID string) (r0 int, r1 error) {
+	m.called("OnlineStatusCheck", []interface{}{userID}, &r0, &r1)
+	return
+}
+
+func (m *Client) OnlineStatusCheckContext(ctx context.Context, userID string) (r0 int, r1 error) {
+	m.called("OnlineStatusCheckContext", []interface{}{ctx, userID}, &r0, &r1)
+	return
+}
+
+func (m *Client) TagSet(tag sdk.Tag) (r0 error) {
+	m.called("TagSet", []interface{}{tag}, &r0)
+	return
+}
+
+func (m *Client) TagSetContext(ctx context.Context, tag sdk.Tag) (r0 error) {
+	m.called("TagSetContext", []interface{}{ctx, tag}, &r0)
+	return
+}
+
+func (m *Client) TagBatchSet(tagBatch sdk.TagBatch) (r0 error) {
+	m.called("TagBatchSet", []interface{}{tagBatch}, &r0)
+	return
+}
+
+func (m *Client) TagBatchSetContext(ctx context.Context, tagBatch sdk.TagBatch) (r0 error) {
+	m.called("TagBatchSetContext", []interface{}{ctx, tagBatch}, &r0)
+	return
+}
+
+func (m *Client) TagGet(userIds []string) (r0 sdk.TagResult, r1 error) {
+	m.called("TagGet", []interface{}{userIds}, &r0, &r1)
+	return
+}
+
+func (m *Client) TagGetContext(ctx context.Context, userIds []string) (r0 sdk.TagResult, r1 error) {
+	m.called("TagGetContext", []interface{}{ctx, userIds}, &r0, &r1)
+	return
+}
+
+func (m *Client) TagGetBatch(userIds []string) (r0 sdk.TagResult, r1 sdk.BatchResult) {
+	m.called("TagGetBatch", []interface{}{userIds}, &r0, &r1)
+	return
+}
+
+func (m *Client) TagGetBatchContext(ctx context.Context, userIds []string) (r0 sdk.TagResult, r1 sdk.BatchResult) {
+	m.called("TagGetBatchContext", []interface{}{ctx, userIds}, &r0, &r1)
+	return
+}
+
+// sdk.GroupClient 的方法
+
+func (m *Client) GroupCreate(id string, name string, members []string) (r0 error) {
+	m.called("GroupCreate", []interface{}{id, name, members}, &r0)
+	return
+}
+
+func (m *Client) GroupCreateContext(ctx context.Context, id string, name string, members []string) (r0 error) {
+	m.called("GroupCreateContext", []interface{}{ctx, id, name, members}, &r0)
+	return
+}
+
+func (m *Client) GroupSync(id string, groups []sdk.Group) (r0 error) {
+	m.called("GroupSync", []interface{}{id, groups}, &r0)
+	return
+}
+
+func (m *Client) GroupSyncContext(ctx context.Context, id string, groups []sdk.Group) (r0 error) {
+	m.called("GroupSyncContext", []interface{}{ctx, id, groups}, &r0)
+	return
+}
+
+func (m *Client) GroupUpdate(id string, name string) (r0 error) {
+	m.called("GroupUpdate", []interface{}{id, name}, &r0)
+	return
+}
+
+func (m *Client) GroupUpdateContext(ctx context.Context, id string, name string) (r0 error) {
+	m.called("GroupUpdateContext", []interface{}{ctx, id, name}, &r0)
+	return
+}
+
+func (m *Client) GroupJoin(id string, name string, member string) (r0 error) {
+	m.called("GroupJoin", []interface{}{id, name, member}, &r0)
+	return
+}
+
+func (m *Client) GroupJoinContext(ctx context.Context, id string, name string, member string) (r0 error) {
+	m.called("GroupJoinContext", []interface{}{ctx, id, name, member}, &r0)
+	return
+}
+
+func (m *Client) GroupGet(id string) (r0 sdk.Group, r1 error) {
+	m.called("GroupGet", []interface{}{id}, &r0, &r1)
+	return
+}
+
+func (m *Client) GroupGetContext(ctx context.Context, id string) (r0 sdk.Group, r1 error) {
+	m.called("GroupGetContext", []interface{}{ctx, id}, &r0, &r1)
+	return
+}
+
+func (m *Client) GroupQuit(member string, id string) (r0 error) {
+	m.called("GroupQuit", []interface{}{member, id}, &r0)
+	return
+}
+
+func (m *Client) GroupQuitContext(ctx context.Context, member string, id string) (r0 error) {
+	m.called("GroupQuitContext", []interface{}{ctx, member, id}, &r0)
+	return
+}
+
+func (m *Client) GroupDismiss(id string, member string) (r0 error) {
+	m.called("GroupDismiss", []interface{}{id, member}, &r0)
+	return
+}
+
+func (m *Client) GroupDismissContext(ctx context.Context, id string, member string) (r0 error) {
+	m.called("GroupDismissContext", []interface{}{ctx, id, member}, &r0)
+	return
+}
+
+func (m *Client) GroupGagAdd(id string, members []string, minute int) (r0 error) {
+	m.called("GroupGagAdd", []interface{}{id, members, minute}, &r0)
+	return
+}
+
+func (m *Client) GroupGagAddContext(ctx context.Context, id string, members []string, minute int) (r0 error) {
+	m.called("GroupGagAddContext", []interface{}{ctx, id, members, minute}, &r0)
+	return
+}
+
+func (m *Client) GroupMuteMembersAdd(id string, members []string, minute int) (r0 error) {
+	m.called("GroupMuteMembersAdd", []interface{}{id, members, minute}, &r0)
+	return
+}
+
+func (m *Client) GroupMuteMembersAddContext(ctx context.Context, id string, members []string, minute int) (r0 error) {
+	m.called("GroupMuteMembersAddContext", []interface{}{ctx, id, members, minute}, &r0)
+	return
+}
+
+func (m *Client) GroupGagList(id string) (r0 sdk.Group, r1 error) {
+	m.called("GroupGagList", []interface{}{id}, &r0, &r1)
+	return
+}
+
+func (m *Client) GroupGagListContext(ctx context.Context, id string) (r0 sdk.Group, r1 error) {
+	m.called("GroupGagListContext", []interface{}{ctx, id}, &r0, &r1)
+	return
+}
+
+func (m *Client) GroupMuteMembersGetList(id string) (r0 sdk.Group, r1 error) {
+	m.called("GroupMuteMembersGetList", []interface{}{id}, &r0, &r1)
+	return
+}
+
+func (m *Client) GroupMuteMembersGetListContext(ctx context.Context, id string) (r0 sdk.Group, r1 error) {
+	m.called("GroupMuteMembersGetListContext", []interface{}{ctx, id}, &r0, &r1)
+	return
+}
+
+func (m *Client) GroupGagRemove(id string, members []string) (r0 error) {
+	m.called("GroupGagRemove", []interface{}{id, members}, &r0)
+	return
+}
+
+func (m *Client) GroupGagRemoveContext(ctx context.Context, id string, members []string) (r0 error) {
+	m.called("GroupGagRemoveContext", []interface{}{ctx, id, members}, &r0)
+	return
+}
+
+func (m *Client) GroupMuteMembersRemove(id string, members []string) (r0 error) {
+	m.called("GroupMuteMembersRemove", []interface{}{id, members}, &r0)
+	return
+}
+
+func (m *Client) GroupMuteMembersRemoveContext(ctx context.Context, id string, members []string) (r0 error) {
+	m.called("GroupMuteMembersRemoveContext", []interface{}{ctx, id, members}, &r0)
+	return
+}
+
+func (m *Client) GroupMuteAllMembersAdd(members []string) (r0 error) {
+	m.called("GroupMuteAllMembersAdd", []interface{}{members}, &r0)
+	return
+}
+
+func (m *Client) GroupMuteAllMembersAddContext(ctx context.Context, members []string) (r0 error) {
+	m.called("GroupMuteAllMembersAddContext", []interface{}{ctx, members}, &r0)
+	return
+}
+
+func (m *Client) GroupMuteAllMembersRemove(members []string) (r0 error) {
+	m.called("GroupMuteAllMembersRemove", []interface{}{members}, &r0)
+	return
+}
+
+func (m *Client) GroupMuteAllMembersRemoveContext(ctx context.Context, members []string) (r0 error) {
+	m.called("GroupMuteAllMembersRemoveContext", []interface{}{ctx, members}, &r0)
+	return
+}
+
+func (m *Client) GroupMuteAllMembersGetList(members []string) (r0 sdk.GroupInfo, r1 error) {
+	m.called("GroupMuteAllMembersGetList", []interface{}{members}, &r0, &r1)
+	return
+}
+
+func (m *Client) GroupMuteAllMembersGetListContext(ctx context.Context, members []string) (r0 sdk.GroupInfo, r1 error) {
+	m.called("GroupMuteAllMembersGetListContext", []interface{}{ctx, members}, &r0, &r1)
+	return
+}
+
+func (m *Client) GroupMuteWhiteListUserAdd(id string, members []string) (r0 error) {
+	m.called("GroupMuteWhiteListUserAdd", []interface{}{id, members}, &r0)
+	return
+}
+
+func (m *Client) GroupMuteWhiteListUserAddContext(ctx context.Context, id string, members []string) (r0 error) {
+	m.called("GroupMuteWhiteListUserAddContext", []interface{}{ctx, id, members}, &r0)
+	return
+}
+
+func (m *Client) GroupMuteWhiteListUserRemove(id string, members []string) (r0 error) {
+	m.called("GroupMuteWhiteListUserRemove", []interface{}{id, members}, &r0)
+	return
+}
+
+func (m *Client) GroupMuteWhiteListUserRemoveContext(ctx context.Context, id string, members []string) (r0 error) {
+	m.called("GroupMuteWhiteListUserRemoveContext", []interface{}{ctx, id, members}, &r0)
+	return
+}
+
+func (m *Client) GroupMuteWhiteListUserGetList(id string) (r0 []string, r1 error) {
+	m.called("GroupMuteWhiteListUserGetList", []interface{}{id}, &r0, &r1)
+	return
+}
+
+func (m *Client) GroupMuteWhiteListUserGetListContext(ctx context.Context, id string) (r0 []string, r1 error) {
+	m.called("GroupMuteWhiteListUserGetListContext", []interface{}{ctx, id}, &r0, &r1)
+	return
+}
+
+// sdk.ChatRoomClient 的方法
+
+func (m *Client) ChatRoomCreate(id string, name string) (r0 error) {
+	m.called("ChatRoomCreate", []interface{}{id, name}, &r0)
+	return
+}
+
+func (m *Client) ChatRoomCreateContext(ctx context.Context, id string, name string) (r0 error) {
+	m.called("ChatRoomCreateContext", []interface{}{ctx, id, name}, &r0)
+	return
+}
+
+func (m *Client) ChatRoomDestroy(id string) (r0 error) {
+	m.called("ChatRoomDestroy", []interface{}{id}, &r0)
+	return
+}
+
+func (m *Client) ChatRoomDestroyContext(ctx context.Context, id string) (r0 error) {
+	m.called("ChatRoomDestroyContext", []interface{}{ctx, id}, &r0)
+	return
+}
+
+func (m *Client) ChatRoomGet(id string, count int, order int) (r0 sdk.ChatRoomResult, r1 error) {
+	m.called("ChatRoomGet", []interface{}{id, count, order}, &r0, &r1)
+	return
+}
+
+func (m *Client) ChatRoomGetContext(ctx context.Context, id string, count int, order int) (r0 sdk.ChatRoomResult, r1 error) {
+	m.called("ChatRoomGetContext", []interface{}{ctx, id, count, order}, &r0, &r1)
+	return
+}
+
+func (m *Client) ChatRoomIsExist(id string, members []string) (r0 []sdk.ChatRoomUser, r1 error) {
+	m.called("ChatRoomIsExist", []interface{}{id, members}, &r0, &r1)
+	return
+}
+
+func (m *Client) ChatRoomIsExistContext(ctx context.Context, id string, members []string) (r0 []sdk.ChatRoomUser, r1 error) {
+	m.called("ChatRoomIsExistContext", []interface{}{ctx, id, members}, &r0, &r1)
+	return
+}
+
+func (m *Client) ChatRoomBlockAdd(id string, members []string, minute uint) (r0 error) {
+	m.called("ChatRoomBlockAdd", []interface{}{id, members, minute}, &r0)
+	return
+}
+
+func (m *Client) ChatRoomBlockAddContext(ctx context.Context, id string, members []string, minute uint) (r0 error) {
+	m.called("ChatRoomBlockAddContext", []interface{}{ctx, id, members, minute}, &r0)
+	return
+}
+
+func (m *Client) ChatRoomBlockRemove(id string, members []string) (r0 error) {
+	m.called("ChatRoomBlockRemove", []interface{}{id, members}, &r0)
+	return
+}
+
+func (m *Client) ChatRoomBlockRemoveContext(ctx context.Context, id string, members []string) (r0 error) {
+	m.called("ChatRoomBlockRemoveContext", []interface{}{ctx, id, members}, &r0)
+	return
+}
+
+func (m *Client) ChatRoomBlockGetList(id string) (r0 sdk.ChatRoomResult, r1 error) {
+	m.called("ChatRoomBlockGetList", []interface{}{id}, &r0, &r1)
+	return
+}
+
+func (m *Client) ChatRoomBlockGetListContext(ctx context.Context, id string) (r0 sdk.ChatRoomResult, r1 error) {
+	m.called("ChatRoomBlockGetListContext", []interface{}{ctx, id}, &r0, &r1)
+	return
+}
+
+func (m *Client) ChatRoomBanAdd(members []string, minute uint) (r0 error) {
+	m.called("ChatRoomBanAdd", []interface{}{members, minute}, &r0)
+	return
+}
+
+func (m *Client) ChatRoomBanAddContext(ctx context.Context, members []string, minute uint) (r0 error) {
+	m.called("ChatRoomBanAddContext", []interface{}{ctx, members, minute}, &r0)
+	return
+}
+
+func (m *Client) ChatRoomBanRemove(members []string) (r0 error) {
+	m.called("ChatRoomBanRemove", []interface{}{members}, &r0)
+	return
+}
+
+func (m *Client) ChatRoomBanRemoveContext(ctx context.Context, members []string) (r0 error) {
+	m.called("ChatRoomBanRemoveContext", []interface{}{ctx, members}, &r0)
+	return
+}
+
+func (m *Client) ChatRoomBanGetList() (r0 []sdk.ChatRoomUser, r1 error) {
+	m.called("ChatRoomBanGetList", []interface{}{}, &r0, &r1)
+	return
+}
+
+func (m *Client) ChatRoomBanGetListContext(ctx context.Context) (r0 []sdk.ChatRoomUser, r1 error) {
+	m.called("ChatRoomBanGetListContext", []interface{}{ctx}, &r0, &r1)
+	return
+}
+
+func (m *Client) ChatRoomGagAdd(id string, members []string, minute uint) (r0 error) {
+	m.called("ChatRoomGagAdd", []interface{}{id, members, minute}, &r0)
+	return
+}
+
+func (m *Client) ChatRoomGagAddContext(ctx context.Context, id string, members []string, minute uint) (r0 error) {
+	m.called("ChatRoomGagAddContext", []interface{}{ctx, id, members, minute}, &r0)
+	return
+}
+
+func (m *Client) ChatRoomGagRemove(id string, members []string) (r0 error) {
+	m.called("ChatRoomGagRemove", []interface{}{id, members}, &r0)
+	return
+}
+
+func (m *Client) ChatRoomGagRemoveContext(ctx context.Context, id string, members []string) (r0 error) {
+	m.called("ChatRoomGagRemoveContext", []interface{}{ctx, id, members}, &r0)
+	return
+}
+
+func (m *Client) ChatRoomGagGetList(id string) (r0 []sdk.ChatRoomUser, r1 error) {
+	m.called("ChatRoomGagGetList", []interface{}{id}, &r0, &r1)
+	return
+}
+
+func (m *Client) ChatRoomGagGetListContext(ctx context.Context, id string) (r0 []sdk.ChatRoomUser, r1 error) {
+	m.called("ChatRoomGagGetListContext", []interface{}{ctx, id}, &r0, &r1)
+	return
+}
+
+func (m *Client) ChatRoomDemotionAdd(objectNames []string) (r0 error) {
+	m.called("ChatRoomDemotionAdd", []interface{}{objectNames}, &r0)
+	return
+}
+
+func (m *Client) ChatRoomDemotionAddContext(ctx context.Context, objectNames []string) (r0 error) {
+	m.called("ChatRoomDemotionAddContext", []interface{}{ctx, objectNames}, &r0)
+	return
+}
+
+func (m *Client) ChatRoomDemotionRemove(objectNames []string) (r0 error) {
+	m.called("ChatRoomDemotionRemove", []interface{}{objectNames}, &r0)
+	return
+}
+
+func (m *Client) ChatRoomDemotionRemoveContext(ctx context.Context, objectNames []string) (r0 error) {
+	m.called("ChatRoomDemotionRemoveContext", []interface{}{ctx, objectNames}, &r0)
+	return
+}
+
+func (m *Client) ChatRoomDemotionGetList() (r0 []string, r1 error) {
+	m.called("ChatRoomDemotionGetList", []interface{}{}, &r0, &r1)
+	return
+}
+
+func (m *Client) ChatRoomDemotionGetListContext(ctx context.Context) (r0 []string, r1 error) {
+	m.called("ChatRoomDemotionGetListContext", []interface{}{ctx}, &r0, &r1)
+	return
+}
+
+func (m *Client) ChatRoomDistributionStop(id string) (r0 error) {
+	m.called("ChatRoomDistributionStop", []interface{}{id}, &r0)
+	return
+}
+
+func (m *Client) ChatRoomDistributionStopContext(ctx context.Context, id string) (r0 error) {
+	m.called("ChatRoomDistributionStopContext", []interface{}{ctx, id}, &r0)
+	return
+}
+
+func (m *Client) ChatRoomDistributionResume(id string) (r0 error) {
+	m.called("ChatRoomDistributionResume", []interface{}{id}, &r0)
+	return
+}
+
+func (m *Client) ChatRoomDistributionResumeContext(ctx context.Context, id string) (r0 error) {
+	m.called("ChatRoomDistributionResumeContext", []interface{}{ctx, id}, &r0)
+	return
+}
+
+func (m *Client) ChatRoomKeepAliveAdd(id string) (r0 error) {
+	m.called("ChatRoomKeepAliveAdd", []interface{}{id}, &r0)
+	return
+}
+
+func (m *Client) ChatRoomKeepAliveAddContext(ctx context.Context, id string) (r0 error) {
+	m.called("ChatRoomKeepAliveAddContext", []interface{}{ctx, id}, &r0)
+	return
+}
+
+func (m *Client) ChatRoomKeepAliveRemove(id string) (r0 error) {
+	m.called("ChatRoomKeepAliveRemove", []interface{}{id}, &r0)
+	return
+}
+
+func (m *Client) ChatRoomKeepAliveRemoveContext(ctx context.Context, id string) (r0 error) {
+	m.called("ChatRoomKeepAliveRemoveContext", []interface{}{ctx, id}, &r0)
+	return
+}
+
+func (m *Client) ChatRoomKeepAliveGetList() (r0 []string, r1 error) {
+	m.called("ChatRoomKeepAliveGetList", []interface{}{}, &r0, &r1)
+	return
+}
+
+func (m *Client) ChatRoomKeepAliveGetListContext(ctx context.Context) (r0 []string, r1 error) {
+	m.called("ChatRoomKeepAliveGetListContext", []interface{}{ctx}, &r0, &r1)
+	return
+}
+
+func (m *Client) ChatRoomWhitelistAdd(objectNames []string) (r0 error) {
+	m.called("ChatRoomWhitelistAdd", []interface{}{objectNames}, &r0)
+	return
+}
+
+func (m *Client) ChatRoomWhitelistAddContext(ctx context.Context, objectNames []string) (r0 error) {
+	m.called("ChatRoomWhitelistAddContext", []interface{}{ctx, objectNames}, &r0)
+	return
+}
+
+func (m *Client) ChatRoomWhitelistRemove(objectNames []string) (r0 error) {
+	m.called("ChatRoomWhitelistRemove", []interface{}{objectNames}, &r0)
+	return
+}
+
+func (m *Client) ChatRoomWhitelistRemoveContext(ctx context.Context, objectNames []string) (r0 error) {
+	m.called("ChatRoomWhitelistRemoveContext", []interface{}{ctx, objectNames}, &r0)
+	return
+}
+
+func (m *Client) ChatRoomWhitelistGetList() (r0 []string, r1 error) {
+	m.called("ChatRoomWhitelistGetList", []interface{}{}, &r0, &r1)
+	return
+}
+
+func (m *Client) ChatRoomWhitelistGetListContext(ctx context.Context) (r0 []string, r1 error) {
+	m.called("ChatRoomWhitelistGetListContext", []interface{}{ctx}, &r0, &r1)
+	return
+}
+
+func (m *Client) ChatRoomUserWhitelistAdd(id string, members []string) (r0 error) {
+	m.called("ChatRoomUserWhitelistAdd", []interface{}{id, members}, &r0)
+	return
+}
+
+func (m *Client) ChatRoomUserWhitelistAddContext(ctx context.Context, id string, members []string) (r0 error) {
+	m.called("ChatRoomUserWhitelistAddContext", []interface{}{ctx, id, members}, &r0)
+	return
+}
+
+func (m *Client) ChatRoomUserWhitelistRemove(id string, members []string) (r0 error) {
+	m.called("ChatRoomUserWhitelistRemove", []interface{}{id, members}, &r0)
+	return
+}
+
+func (m *Client) ChatRoomUserWhitelistRemoveContext(ctx context.Context, id string, members []string) (r0 error) {
+	m.called("ChatRoomUserWhitelistRemoveContext", []interface{}{ctx, id, members}, &r0)
+	return
+}
+
+func (m *Client) ChatRoomUserWhitelistGetList(id string) (r0 []string, r1 error) {
+	m.called("ChatRoomUserWhitelistGetList", []interface{}{id}, &r0, &r1)
+	return
+}
+
+func (m *Client) ChatRoomUserWhitelistGetListContext(ctx context.Context, id string) (r0 []string, r1 error) {
+	m.called("ChatRoomUserWhitelistGetListContext", []interface{}{ctx, id}, &r0, &r1)
+	return
+}
+
+func (m *Client) ChatRoomMuteMembersAdd(id string, members []string, minute uint) (r0 error) {
+	m.called("ChatRoomMuteMembersAdd", []interface{}{id, members, minute}, &r0)
+	return
+}
+
+func (m *Client) ChatRoomMuteMembersAddContext(ctx context.Context, id string, members []string, minute uint) (r0 error) {
+	m.called("ChatRoomMuteMembersAddContext", []interface{}{ctx, id, members, minute}, &r0)
+	return
+}
+
+func (m *Client) ChatRoomMuteMembersGetList(id string) (r0 []sdk.ChatRoomUser, r1 error) {
+	m.called("ChatRoomMuteMembersGetList", []interface{}{id}, &r0, &r1)
+	return
+}
+
+func (m *Client) ChatRoomMuteMembersGetListContext(ctx context.Context, id string) (r0 []sdk.ChatRoomUser, r1 error) {
+	m.called("ChatRoomMuteMembersGetListContext", []interface{}{ctx, id}, &r0, &r1)
+	return
+}
+
+func (m *Client) ChatRoomMuteMembersRemove(id string, members []string) (r0 error) {
+	m.called("ChatRoomMuteMembersRemove", []interface{}{id, members}, &r0)
+	return
+}
+
+func (m *Client) ChatRoomMuteMembersRemoveContext(ctx context.Context, id string, members []string) (r0 error) {
+	m.called("ChatRoomMuteMembersRemoveContext", []interface{}{ctx, id, members}, &r0)
+	return
+}
+
+func (m *Client) ChatRoomEntrySet(chatRoomID string, userID string, key string, value string, autoDelete bool) (r0 error) {
+	m.called("ChatRoomEntrySet", []interface{}{chatRoomID, userID, key, value, autoDelete}, &r0)
+	return
+}
+
+func (m *Client) ChatRoomEntrySetContext(ctx context.Context, chatRoomID string, userID string, key string, value string, autoDelete bool) (r0 error) {
+	m.called("ChatRoomEntrySetContext", []interface{}{ctx, chatRoomID, userID, key, value, autoDelete}, &r0)
+	return
+}
+
+func (m *Client) ChatRoomEntryRemove(chatRoomID string, userID string, key string) (r0 error) {
+	m.called("ChatRoomEntryRemove", []interface{}{chatRoomID, userID, key}, &r0)
+	return
+}
+
+func (m *Client) ChatRoomEntryRemoveContext(ctx context.Context, chatRoomID string, userID string, key string) (r0 error) {
+	m.called("ChatRoomEntryRemoveContext", []interface{}{ctx, chatRoomID, userID, key}, &r0)
+	return
+}
+
+func (m *Client) ChatRoomEntryQuery(chatRoomID string, keys string) (r0 []sdk.ChatRoomAttr, r1 error) {
+	m.called("ChatRoomEntryQuery", []interface{}{chatRoomID, keys}, &r0, &r1)
+	return
+}
+
+func (m *Client) ChatRoomEntryQueryContext(ctx context.Context, chatRoomID string, keys string) (r0 []sdk.ChatRoomAttr, r1 error) {
+	m.called("ChatRoomEntryQueryContext", []interface{}{ctx, chatRoomID, keys}, &r0, &r1)
+	return
+}
+
+func (m *Client) ChatRoomEntryQueryBatch(chatRoomID string, keys []string) (r0 []sdk.ChatRoomAttr, r1 sdk.BatchResult) {
+	m.called("ChatRoomEntryQueryBatch", []interface{}{chatRoomID, keys}, &r0, &r1)
+	return
+}
+
+func (m *Client) ChatRoomEntryQueryBatchContext(ctx context.Context, chatRoomID string, keys []string) (r0 []sdk.ChatRoomAttr, r1 sdk.BatchResult) {
+	m.called("ChatRoomEntryQueryBatchContext", []interface{}{ctx, chatRoomID, keys}, &r0, &r1)
+	return
+}
+
+func (m *Client) ChatRoomQuery(chatRoomID []string) (r0 []sdk.ChatRoom, r1 error) {
+	m.called("ChatRoomQuery", []interface{}{chatRoomID}, &r0, &r1)
+	return
+}
+
+func (m *Client) ChatRoomQueryContext(ctx context.Context, chatRoomID []string) (r0 []sdk.ChatRoom, r1 error) {
+	m.called("ChatRoomQueryContext", []interface{}{ctx, chatRoomID}, &r0, &r1)
+	return
+}
+
+// sdk.MessageClient 的方法
+
+func (m *Client) MessageBroadcastRecall(userId string, objectName string, content sdk.BroadcastRecallContent) (r0 error) {
+	m.called("MessageBroadcastRecall", []interface{}{userId, objectName, content}, &r0)
+	return
+}
+
+func (m *Client) MessageBroadcastRecallContext(ctx context.Context, userId string, objectName string, content sdk.BroadcastRecallContent) (r0 error) {
+	m.called("MessageBroadcastRecallContext", []interface{}{ctx, userId, objectName, content}, &r0)
+	return
+}
+
+func (m *Client) ChatRoomRecall(userId string, targetId string, messageId string, sentTime int, options ...sdk.MsgOption) (r0 error) {
+	m.called("ChatRoomRecall", []interface{}{userId, targetId, messageId, sentTime, options}, &r0)
+	return
+}
+
+func (m *Client) ChatRoomRecallContext(ctx context.Context, userId string, targetId string, messageId string, sentTime int, options ...sdk.MsgOption) (r0 error) {
+	m.called("ChatRoomRecallContext", []interface{}{ctx, userId, targetId, messageId, sentTime, options}, &r0)
+	return
+}
+
+func (m *Client) SystemRecall(userId string, targetId string, messageId string, sentTime int, options ...sdk.MsgOption) (r0 error) {
+	m.called("SystemRecall", []interface{}{userId, targetId, messageId, sentTime, options}, &r0)
+	return
+}
+
+func (m *Client) SystemRecallContext(ctx context.Context, userId string, targetId string, messageId string, sentTime int, options ...sdk.MsgOption) (r0 error) {
+	m.called("SystemRecallContext", []interface{}{ctx, userId, targetId, messageId, sentTime, options}, &r0)
+	return
+}
+
+func (m *Client) PrivateSend(senderID string, targetID []string, objectName string, msg sdk.RCMsg, pushContent string, pushData string, count int, verifyBlacklist int, isPersisted int, isIncludeSender int, contentAvailable int, options ...sdk.MsgOption) (r0 error) {
+	m.called("PrivateSend", []interface{}{senderID, targetID, objectName, msg, pushContent, pushData, count, verifyBlacklist, isPersisted, isIncludeSender, contentAvailable, options}, &r0)
+	return
+}
+
+func (m *Client) PrivateSendContext(ctx context.Context, senderID string, targetID []string, objectName string, msg sdk.RCMsg, pushContent string, pushData string, count int, verifyBlacklist int, isPersisted int, isIncludeSender int, contentAvailable int, options ...sdk.MsgOption) (r0 error) {
+	m.called("PrivateSendContext", []interface{}{ctx, senderID, targetID, objectName, msg, pushContent, pushData, count, verifyBlacklist, isPersisted, isIncludeSender, contentAvailable, options}, &r0)
+	return
+}
+
+func (m *Client) PrivateSendBatch(senderID string, targetID []string, objectName string, msg sdk.RCMsg, pushContent string, pushData string, count int, verifyBlacklist int, isPersisted int, isIncludeSender int, contentAvailable int, options ...sdk.MsgOption) (r0 sdk.BatchResult) {
+	m.called("PrivateSendBatch", []interface{}{senderID, targetID, objectName, msg, pushContent, pushData, count, verifyBlacklist, isPersisted, isIncludeSender, contentAvailable, options}, &r0)
+	return
+}
+
+func (m *Client) PrivateSendBatchContext(ctx context.Context, senderID string, targetID []string, objectName string, msg sdk.RCMsg, pushContent string, pushData string, count int, verifyBlacklist int, isPersisted int, isIncludeSender int, contentAvailable int, options ...sdk.MsgOption) (r0 sdk.BatchResult) {
+	m.called("PrivateSendBatchContext", []interface{}{ctx, senderID, targetID, objectName, msg, pushContent, pushData, count, verifyBlacklist, isPersisted, isIncludeSender, contentAvailable, options}, &r0)
+	return
+}
+
+func (m *Client) PrivateStatusSend(senderID string, targetID []string, objectName string, msg sdk.RCMsg, verifyBlacklist int, isIncludeSender int, options ...sdk.MsgOption) (r0 error) {
+	m.called("PrivateStatusSend", []interface{}{senderID, targetID, objectName, msg, verifyBlacklist, isIncludeSender, options}, &r0)
+	return
+}
+
+func (m *Client) PrivateStatusSendContext(ctx context.Context, senderID string, targetID []string, objectName string, msg sdk.RCMsg, verifyBlacklist int, isIncludeSender int, options ...sdk.MsgOption) (r0 error) {
+	m.called("PrivateStatusSendContext", []interface{}{ctx, senderID, targetID, objectName, msg, verifyBlacklist, isIncludeSender, options}, &r0)
+	return
+}
+
+func (m *Client) PrivateRecall(senderID string, targetID string, uID string, sentTime int, options ...sdk.MsgOption) (r0 error) {
+	m.called("PrivateRecall", []interface{}{senderID, targetID, uID, sentTime, options}, &r0)
+	return
+}
+
+func (m *Client) PrivateRecallContext(ctx context.Context, senderID string, targetID string, uID string, sentTime int, options ...sdk.MsgOption) (r0 error) {
+	m.called("PrivateRecallContext", []interface{}{ctx, senderID, targetID, uID, sentTime, options}, &r0)
+	return
+}
+
+func (m *Client) PrivateSendTemplate(senderID string, objectName string, template sdk.TXTMsg, content []sdk.TemplateMsgContent, options ...sdk.MsgOption) (r0 error) {
+	m.called("PrivateSendTemplate", []interface{}{senderID, objectName, template, content, options}, &r0)
+	return
+}
+
+func (m *Client) PrivateSendTemplateContext(ctx context.Context, senderID string, objectName string, template sdk.TXTMsg, content []sdk.TemplateMsgContent, options ...sdk.MsgOption) (r0 error) {
+	m.called("PrivateSendTemplateContext", []interface{}{ctx, senderID, objectName, template, content, options}, &r0)
+	return
+}
+
+func (m *Client) GroupSend(senderID string, targetID []string, userID []string, objectName string, msg sdk.RCMsg, pushContent string, pushData string, isPersisted int, isIncludeSender int, options ...sdk.MsgOption) (r0 error) {
+	m.called("GroupSend", []interface{}{senderID, targetID, userID, objectName, msg, pushContent, pushData, isPersisted, isIncludeSender, options}, &r0)
+	return
+}
+
+func (m *Client) GroupSendContext(ctx context.Context, senderID string, targetID []string, userID []string, objectName string, msg sdk.RCMsg, pushContent string, pushData string, isPersisted int, isIncludeSender int, options ...sdk.MsgOption) (r0 error) {
+	m.called("GroupSendContext", []interface{}{ctx, senderID, targetID, userID, objectName, msg, pushContent, pushData, isPersisted, isIncludeSender, options}, &r0)
+	return
+}
+
+func (m *Client) GroupSendBatch(senderID string, targetID []string, userID []string, objectName string, msg sdk.RCMsg, pushContent string, pushData string, isPersisted int, isIncludeSender int, options ...sdk.MsgOption) (r0 sdk.BatchResult) {
+	m.called("GroupSendBatch", []interface{}{senderID, targetID, userID, objectName, msg, pushContent, pushData, isPersisted, isIncludeSender, options}, &r0)
+	return
+}
+
+func (m *Client) GroupSendBatchContext(ctx context.Context, senderID string, targetID []string, userID []string, objectName string, msg sdk.RCMsg, pushContent string, pushData string, isPersisted int, isIncludeSender int, options ...sdk.MsgOption) (r0 sdk.BatchResult) {
+	m.called("GroupSendBatchContext", []interface{}{ctx, senderID, targetID, userID, objectName, msg, pushContent, pushData, isPersisted, isIncludeSender, options}, &r0)
+	return
+}
+
+func (m *Client) GroupStatusSend(senderID string, toGroupIds []string, objectName string, msg sdk.RCMsg, verifyBlacklist int, isIncludeSender int, options ...sdk.MsgOption) (r0 error) {
+	m.called("GroupStatusSend", []interface{}{senderID, toGroupIds, objectName, msg, verifyBlacklist, isIncludeSender, options}, &r0)
+	return
+}
+
+func (m *Client) GroupStatusSendContext(ctx context.Context, senderID string, toGroupIds []string, objectName string, msg sdk.RCMsg, verifyBlacklist int, isIncludeSender int, options ...sdk.MsgOption) (r0 error) {
+	m.called("GroupStatusSendContext", []interface{}{ctx, senderID, toGroupIds, objectName, msg, verifyBlacklist, isIncludeSender, options}, &r0)
+	return
+}
+
+func (m *Client) GroupRecall(senderID string, targetID string, uID string, sentTime int, options ...sdk.MsgOption) (r0 error) {
+	m.called("GroupRecall", []interface{}{senderID, targetID, uID, sentTime, options}, &r0)
+	return
+}
+
+func (m *Client) GroupRecallContext(ctx context.Context, senderID string, targetID string, uID string, sentTime int, options ...sdk.MsgOption) (r0 error) {
+	m.called("GroupRecallContext", []interface{}{ctx, senderID, targetID, uID, sentTime, options}, &r0)
+	return
+}
+
+func (m *Client) GroupSendMention(senderID string, targetID []string, objectName string, msg sdk.MentionMsgContent, pushContent string, pushData string, isPersisted int, isIncludeSender int, isMentioned int, contentAvailable int, options ...sdk.MsgOption) (r0 error) {
+	m.called("GroupSendMention", []interface{}{senderID, targetID, objectName, msg, pushContent, pushData, isPersisted, isIncludeSender, isMentioned, contentAvailable, options}, &r0)
+	return
+}
+
+func (m *Client) GroupSendMentionContext(ctx context.Context, senderID string, targetID []string, objectName string, msg sdk.MentionMsgContent, pushContent string, pushData string, isPersisted int, isIncludeSender int, isMentioned int, contentAvailable int, options ...sdk.MsgOption) (r0 error) {
+	m.called("GroupSendMentionContext", []interface{}{ctx, senderID, targetID, objectName, msg, pushContent, pushData, isPersisted, isIncludeSender, isMentioned, contentAvailable, options}, &r0)
+	return
+}
+
+func (m *Client) ChatRoomSend(senderID string, targetID []string, objectName string, msg sdk.RCMsg) (r0 error) {
+	m.called("ChatRoomSend", []interface{}{senderID, targetID, objectName, msg}, &r0)
+	return
+}
+
+func (m *Client) ChatRoomSendContext(ctx context.Context, senderID string, targetID []string, objectName string, msg sdk.RCMsg) (r0 error) {
+	m.called("ChatRoomSendContext", []interface{}{ctx, senderID, targetID, objectName, msg}, &r0)
+	return
+}
+
+func (m *Client) ChatRoomBroadcast(senderID string, objectName string, msg sdk.RCMsg) (r0 error) {
+	m.called("ChatRoomBroadcast", []interface{}{senderID, objectName, msg}, &r0)
+	return
+}
+
+func (m *Client) ChatRoomBroadcastContext(ctx context.Context, senderID string, objectName string, msg sdk.RCMsg) (r0 error) {
+	m.called("ChatRoomBroadcastContext", []interface{}{ctx, senderID, objectName, msg}, &r0)
+	return
+}
+
+func (m *Client) OnlineBroadcast(fromUserId string, objectName string, content string) (r0 []byte, r1 error) {
+	m.called("OnlineBroadcast", []interface{}{fromUserId, objectName, content}, &r0, &r1)
+	return
+}
+
+func (m *Client) OnlineBroadcastContext(ctx context.Context, fromUserId string, objectName string, content string) (r0 []byte, r1 error) {
+	m.called("OnlineBroadcastContext", []interface{}{ctx, fromUserId, objectName, content}, &r0, &r1)
+	return
+}
+
+func (m *Client) SystemSend(senderID string, targetID []string, objectName string, msg sdk.RCMsg, pushContent string, pushData string, count int, isPersisted int, options ...sdk.MsgOption) (r0 error) {
+	m.called("SystemSend", []interface{}{senderID, targetID, objectName, msg, pushContent, pushData, count, isPersisted, options}, &r0)
+	return
+}
+
+func (m *Client) SystemSendContext(ctx context.Context, senderID string, targetID []string, objectName string, msg sdk.RCMsg, pushContent string, pushData string, count int, isPersisted int, options ...sdk.MsgOption) (r0 error) {
+	m.called("SystemSendContext", []interface{}{ctx, senderID, targetID, objectName, msg, pushContent, pushData, count, isPersisted, options}, &r0)
+	return
+}
+
+func (m *Client) SystemSendBatch(senderID string, targetID []string, objectName string, msg sdk.RCMsg, pushContent string, pushData string, count int, isPersisted int, options ...sdk.MsgOption) (r0 sdk.BatchResult) {
+	m.called("SystemSendBatch", []interface{}{senderID, targetID, objectName, msg, pushContent, pushData, count, isPersisted, options}, &r0)
+	return
+}
+
+func (m *Client) SystemSendBatchContext(ctx context.Context, senderID string, targetID []string, objectName string, msg sdk.RCMsg, pushContent string, pushData string, count int, isPersisted int, options ...sdk.MsgOption) (r0 sdk.BatchResult) {
+	m.called("SystemSendBatchContext", []interface{}{ctx, senderID, targetID, objectName, msg, pushContent, pushData, count, isPersisted, options}, &r0)
+	return
+}
+
+func (m *Client) SystemBroadcast(senderID string, objectName string, msg sdk.RCMsg, options ...sdk.MsgOption) (r0 error) {
+	m.called("SystemBroadcast", []interface{}{senderID, objectName, msg, options}, &r0)
+	return
+}
+
+func (m *Client) SystemBroadcastContext(ctx context.Context, senderID string, objectName string, msg sdk.RCMsg, options ...sdk.MsgOption) (r0 error) {
+	m.called("SystemBroadcastContext", []interface{}{ctx, senderID, objectName, msg, options}, &r0)
+	return
+}
+
+func (m *Client) SystemSendTemplate(senderID string, objectName string, template sdk.TXTMsg, content []sdk.TemplateMsgContent, options ...sdk.MsgOption) (r0 error) {
+	m.called("SystemSendTemplate", []interface{}{senderID, objectName, template, content, options}, &r0)
+	return
+}
+
+func (m *Client) SystemSendTemplateContext(ctx context.Context, senderID string, objectName string, template sdk.TXTMsg, content []sdk.TemplateMsgContent, options ...sdk.MsgOption) (r0 error) {
+	m.called("SystemSendTemplateContext", []interface{}{ctx, senderID, objectName, template, content, options}, &r0)
+	return
+}
+
+func (m *Client) HistoryGet(date string) (r0 sdk.History, r1 error) {
+	m.called("HistoryGet", []interface{}{date}, &r0, &r1)
+	return
+}
+
+func (m *Client) HistoryGetContext(ctx context.Context, date string) (r0 sdk.History, r1 error) {
+	m.called("HistoryGetContext", []interface{}{ctx, date}, &r0, &r1)
+	return
+}
+
+func (m *Client) HistoryRemove(date string) (r0 error) {
+	m.called("HistoryRemove", []interface{}{date}, &r0)
+	return
+}
+
+func (m *Client) HistoryRemoveContext(ctx context.Context, date string) (r0 error) {
+	m.called("HistoryRemoveContext", []interface{}{ctx, date}, &r0)
+	return
+}
+
+func (m *Client) HistoryDownload(date string, options ...sdk.HistoryOption) (r0 *sdk.HistoryReader, r1 error) {
+	m.called("HistoryDownload", []interface{}{date, options}, &r0, &r1)
+	return
+}
+
+func (m *Client) HistoryDownloadContext(ctx context.Context, date string, options ...sdk.HistoryOption) (r0 *sdk.HistoryReader, r1 error) {
+	m.called("HistoryDownloadContext", []interface{}{ctx, date, options}, &r0, &r1)
+	return
+}
+
+func (m *Client) HistoryWalk(start time.Time, end time.Time, fn sdk.HistoryFunc, options ...sdk.HistoryOption) (r0 error) {
+	m.called("HistoryWalk", []interface{}{start, end, fn, options}, &r0)
+	return
+}
+
+func (m *Client) HistoryWalkContext(ctx context.Context, start time.Time, end time.Time, fn sdk.HistoryFunc, options ...sdk.HistoryOption) (r0 error) {
+	m.called("HistoryWalkContext", []interface{}{ctx, start, end, fn, options}, &r0)
+	return
+}
+
+// sdk.ConversationClient 的方法
+
+func (m *Client) ConversationMute(conversationType sdk.ConversationType, userID string, targetID string, options ...sdk.MsgOption) (r0 error) {
+	m.called("ConversationMute", []interface{}{conversationType, userID, targetID, options}, &r0)
+	return
+}
+
+func (m *Client) ConversationMuteContext(ctx context.Context, conversationType sdk.ConversationType, userID string, targetID string, options ...sdk.MsgOption) (r0 error) {
+	m.called("ConversationMuteContext", []interface{}{ctx, conversationType, userID, targetID, options}, &r0)
+	return
+}
+
+func (m *Client) ConversationUnmute(conversationType sdk.ConversationType, userID string, targetID string, options ...sdk.MsgOption) (r0 error) {
+	m.called("ConversationUnmute", []interface{}{conversationType, userID, targetID, options}, &r0)
+	return
+}
+
+func (m *Client) ConversationUnmuteContext(ctx context.Context, conversationType sdk.ConversationType, userID string, targetID string, options ...sdk.MsgOption) (r0 error) {
+	m.called("ConversationUnmuteContext", []interface{}{ctx, conversationType, userID, targetID, options}, &r0)
+	return
+}
+
+func (m *Client) ConversationGet(conversationType sdk.ConversationType, userID string, targetID string, options ...sdk.MsgOption) (r0 int, r1 error) {
+	m.called("ConversationGet", []interface{}{conversationType, userID, targetID, options}, &r0, &r1)
+	return
+}
+
+func (m *Client) ConversationGetContext(ctx context.Context, conversationType sdk.ConversationType, userID string, targetID string, options ...sdk.MsgOption) (r0 int, r1 error) {
+	m.called("ConversationGetContext", []interface{}{ctx, conversationType, userID, targetID, options}, &r0, &r1)
+	return
+}
+
+// sdk.SensitiveClient 的方法
+
+func (m *Client) SensitiveAdd(keyword string, replace string, sensitiveType int) (r0 error) {
+	m.called("SensitiveAdd", []interface{}{keyword, replace, sensitiveType}, &r0)
+	return
+}
+
+func (m *Client) SensitiveAddContext(ctx context.Context, keyword string, replace string, sensitiveType int) (r0 error) {
+	m.called("SensitiveAddContext", []interface{}{ctx, keyword, replace, sensitiveType}, &r0)
+	return
+}
+
+func (m *Client) SensitiveGetList() (r0 sdk.ListWordFilterResult, r1 error) {
+	m.called("SensitiveGetList", []interface{}{}, &r0, &r1)
+	return
+}
+
+func (m *Client) SensitiveGetListContext(ctx context.Context) (r0 sdk.ListWordFilterResult, r1 error) {
+	m.called("SensitiveGetListContext", []interface{}{ctx}, &r0, &r1)
+	return
+}
+
+func (m *Client) SensitiveRemove(keywords []string) (r0 error) {
+	m.called("SensitiveRemove", []interface{}{keywords}, &r0)
+	return
+}
+
+func (m *Client) SensitiveRemoveContext(ctx context.Context, keywords []string) (r0 error) {
+	m.called("SensitiveRemoveContext", []interface{}{ctx, keywords}, &r0)
+	return
+}
+
+// sdk.PushClient 的方法
+
+func (m *Client) PushSend(sender sdk.Sender) (r0 sdk.PushResult, r1 error) {
+	m.called("PushSend", []interface{}{sender}, &r0, &r1)
+	return
+}
+
+func (m *Client) PushSendContext(ctx context.Context, sender sdk.Sender) (r0 sdk.PushResult, r1 error) {
+	m.called("PushSendContext", []interface{}{ctx, sender}, &r0, &r1)
+	return
+}
@@ -0,0 +1,143 @@
+//go:build ignore
+// +build ignore
+
+// gen.go 根据 sdk/client.go 中的接口生成 client_gen.go，修改接口后执行 go generate ./sdk/sdkmock/
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"log"
+	"strings"
+)
+
+func main() {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "../client.go", nil, 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var b bytes.Buffer
+	b.WriteString(`// Code generated by gen.go; DO NOT EDIT.
+
+package sdkmock
+
+import (
+	"context"
+	"time"
+
+	"github.com/rongcloud/server-sdk-go/v3/sdk"
+)
+
+// Client sdk.Client 的 mock 实现，同时实现了 sdk.UserClient 等按领域拆分的接口
+type Client struct {
+	Mock
+}
+
+// NewClient 创建 Client
+func NewClient() *Client {
+	return &Client{}
+}
+
+var _ sdk.Client = (*Client)(nil)
+`)
+	ast.Inspect(f, func(n ast.Node) bool {
+		spec, ok := n.(*ast.TypeSpec)
+		if !ok {
+			return true
+		}
+		iface, ok := spec.Type.(*ast.InterfaceType)
+		if !ok {
+			return false
+		}
+		for i, method := range iface.Methods.List {
+			fn, ok := method.Type.(*ast.FuncType)
+			if !ok {
+				continue
+			}
+			if i == 0 {
+				fmt.Fprintf(&b, "\n// sdk.%s 的方法\n", spec.Name.Name)
+			}
+			writeMethod(&b, method.Names[0].Name, fn)
+		}
+		return false
+	})
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile("client_gen.go", src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// writeMethod 生成记录调用并返回预设结果的方法
+func writeMethod(b *bytes.Buffer, name string, fn *ast.FuncType) {
+	var params, args []string
+	for _, field := range fn.Params.List {
+		typ := typeString(field.Type)
+		names := field.Names
+		if len(names) == 0 {
+			names = []*ast.Ident{ast.NewIdent(fmt.Sprintf("arg%d", len(args)))}
+		}
+		for _, n := range names {
+			param := n.Name
+			if param == "m" {
+				param = "m_"
+			}
+			params = append(params, param+" "+typ)
+			args = append(args, param)
+		}
+	}
+
+	var results, outs []string
+	if fn.Results != nil {
+		for _, field := range fn.Results.List {
+			typ := typeString(field.Type)
+			for i := 0; i < len(field.Names) || i == 0 && len(field.Names) == 0; i++ {
+				r := fmt.Sprintf("r%d", len(results))
+				results = append(results, r+" "+typ)
+				outs = append(outs, "&"+r)
+			}
+		}
+	}
+
+	fmt.Fprintf(b, "\nfunc (m *Client) %s(%s) (%s) {\n", name, strings.Join(params, ", "), strings.Join(results, ", "))
+	fmt.Fprintf(b, "\tm.called(%q, []interface{}{%s}", name, strings.Join(args, ", "))
+	for _, out := range outs {
+		b.WriteString(", " + out)
+	}
+	b.WriteString(")\n\treturn\n}\n")
+}
+
+// typeString 输出类型表达式，sdk 包中的类型加上包名
+func typeString(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		if ast.IsExported(t.Name) {
+			return "sdk." + t.Name
+		}
+		return t.Name
+	case *ast.SelectorExpr:
+		return typeString(t.X) + "." + t.Sel.Name
+	case *ast.StarExpr:
+		return "*" + typeString(t.X)
+	case *ast.ArrayType:
+		return "[]" + typeString(t.Elt)
+	case *ast.Ellipsis:
+		return "..." + typeString(t.Elt)
+	case *ast.MapType:
+		return "map[" + typeString(t.Key) + "]" + typeString(t.Value)
+	case *ast.InterfaceType:
+		return "interface{}"
+	}
+	log.Fatalf("unsupported type %T", expr)
+	return ""
+}
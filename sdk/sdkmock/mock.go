@@ -0,0 +1,144 @@
+// Package sdkmock 提供 sdk.Client 的 mock 实现，记录调用参数并返回预设的结果，用于依赖融云接口的业务代码的单元测试
+/*
+	client := sdkmock.NewClient()
+	client.On("UserRegister", sdk.User{UserID: "u01", Token: "token"}, nil)
+	svc := NewService(client) // NewService 接收 sdk.UserClient
+	...
+	calls := client.Calls("UserRegister")
+*/
+package sdkmock
+
+//go:generate go run gen.go
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Call 一次方法调用
+type Call struct {
+	Method string
+	Args   []interface{} // 调用参数，Context 方法包括 ctx，可变参数为切片
+}
+
+// ResultFunc 根据调用参数返回结果
+type ResultFunc func(args []interface{}) []interface{}
+
+// Mock 记录调用并返回预设结果，零值可直接使用
+// 方法未设置结果时返回零值及 nil error；XxxContext 方法未设置结果时使用 Xxx 的设置
+type Mock struct {
+	mu      sync.Mutex
+	calls   []Call
+	once    map[string][][]interface{}
+	results map[string]ResultFunc
+}
+
+// On 设置方法每次调用返回的结果，按方法返回值的顺序传入，如 On("UserRegister", sdk.User{}, nil)
+func (m *Mock) On(method string, results ...interface{}) *Mock {
+	return m.OnFunc(method, func([]interface{}) []interface{} {
+		return results
+	})
+}
+
+// OnFunc 设置根据调用参数返回结果的函数
+func (m *Mock) OnFunc(method string, fn ResultFunc) *Mock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.results == nil {
+		m.results = map[string]ResultFunc{}
+	}
+	m.results[method] = fn
+	return m
+}
+
+// Once 设置方法下一次调用返回的结果，优先于 On 的设置，多次调用依次生效
+func (m *Mock) Once(method string, results ...interface{}) *Mock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.once == nil {
+		m.once = map[string][][]interface{}{}
+	}
+	m.once[method] = append(m.once[method], results)
+	return m
+}
+
+// Calls 获取所有调用，method 不为空时只返回这些方法的调用
+func (m *Mock) Calls(method ...string) []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var calls []Call
+	for _, call := range m.calls {
+		if len(method) == 0 || contains(method, call.Method) {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// CallCount 获取方法被调用的次数
+func (m *Mock) CallCount(method string) int {
+	return len(m.Calls(method))
+}
+
+// Reset 清空调用记录及预设结果
+func (m *Mock) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = nil
+	m.once = nil
+	m.results = nil
+}
+
+// called 记录调用，并将预设结果依次写入 outs 指向的返回值
+func (m *Mock) called(method string, args []interface{}, outs ...interface{}) {
+	results := m.record(method, args)
+	for i, out := range outs {
+		if i >= len(results) || results[i] == nil {
+			continue
+		}
+		v := reflect.ValueOf(out).Elem()
+		result := reflect.ValueOf(results[i])
+		if !result.Type().AssignableTo(v.Type()) {
+			panic(fmt.Sprintf("sdkmock: %s result %d: %T is not assignable to %s", method, i, results[i], v.Type()))
+		}
+		v.Set(result)
+	}
+}
+
+// record 记录调用并获取预设结果
+func (m *Mock) record(method string, args []interface{}) []interface{} {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: method, Args: args})
+	names := []string{method}
+	if base := strings.TrimSuffix(method, "Context"); base != method {
+		names = append(names, base)
+	}
+	var fn ResultFunc
+	for _, name := range names {
+		if queue := m.once[name]; len(queue) > 0 {
+			m.once[name] = queue[1:]
+			m.mu.Unlock()
+			return queue[0]
+		}
+		if fn = m.results[name]; fn != nil {
+			break
+		}
+	}
+	m.mu.Unlock()
+	// 在锁外调用，允许 fn 中再调用 Mock 的方法
+	if fn == nil {
+		return nil
+	}
+	return fn(args)
+}
+
+func contains(list []string, v string) bool {
+	for _, item := range list {
+		if item == v {
+			return true
+		}
+	}
+	return false
+}
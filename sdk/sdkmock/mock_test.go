@@ -0,0 +1,85 @@
+package sdkmock
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rongcloud/server-sdk-go/v3/sdk"
+)
+
+// register 依赖 sdk.UserClient 的业务代码
+func register(users sdk.UserClient, userID string) (string, error) {
+	user, err := users.UserRegisterContext(context.Background(), userID, userID, "")
+	if err != nil {
+		return "", err
+	}
+	return user.Token, nil
+}
+
+func TestClient_On(t *testing.T) {
+	client := NewClient()
+	client.On("UserRegister", sdk.User{UserID: "u01", Token: "token"}, nil)
+
+	token, err := register(client, "u01")
+	if err != nil || token != "token" {
+		t.Errorf("register = %q, %v", token, err)
+	}
+	calls := client.Calls("UserRegisterContext")
+	if len(calls) != 1 || calls[0].Args[1] != "u01" || calls[0].Args[2] != "u01" {
+		t.Errorf("calls = %+v", calls)
+	}
+
+	client.Once("UserRegisterContext", nil, errors.New("failed"))
+	if _, err := register(client, "u02"); err == nil || err.Error() != "failed" {
+		t.Errorf("Once: err = %v", err)
+	}
+	if token, err := register(client, "u03"); err != nil || token != "token" {
+		t.Errorf("after Once = %q, %v", token, err)
+	}
+	if n := client.CallCount("UserRegisterContext"); n != 3 {
+		t.Errorf("CallCount = %d, want 3", n)
+	}
+}
+
+func TestClient_OnFunc(t *testing.T) {
+	client := NewClient()
+	client.OnFunc("GroupGet", func(args []interface{}) []interface{} {
+		return []interface{}{sdk.Group{ID: args[0].(string)}}
+	})
+	group, err := client.GroupGet("g01")
+	if err != nil || group.ID != "g01" {
+		t.Errorf("GroupGet = %+v, %v", group, err)
+	}
+
+	// 未设置结果时返回零值
+	count, err := client.ConversationGet(sdk.PRIVATE, "u01", "u02")
+	if count != 0 || err != nil {
+		t.Errorf("ConversationGet = %d, %v", count, err)
+	}
+
+	msg := &sdk.TXTMsg{Content: "hello"}
+	if err := client.PrivateSend("u01", []string{"u02"}, "RC:TxtMsg", msg, "", "", 0, 0, 1, 0, 0, sdk.WithMsgDisablePush(true)); err != nil {
+		t.Error(err)
+	}
+	call := client.Calls("PrivateSend")[0]
+	if call.Args[3] != sdk.RCMsg(msg) || len(call.Args[11].([]sdk.MsgOption)) != 1 {
+		t.Errorf("call = %+v", call)
+	}
+
+	client.Reset()
+	if len(client.Calls()) != 0 {
+		t.Error("Reset did not clear calls")
+	}
+}
+
+func TestClient_WrongResult(t *testing.T) {
+	client := NewClient()
+	client.On("GroupGet", "g01")
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for wrong result type")
+		}
+	}()
+	client.GroupGet("g01")
+}
@@ -0,0 +1,354 @@
+package sdktest
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Mode 录制回放模式
+type Mode int
+
+const (
+	// MODE_REPLAY 回放，只从 cassette 文件读取响应，不访问网络
+	MODE_REPLAY Mode = iota
+	// MODE_RECORD 录制，请求发往真实服务，并将请求及响应保存到 cassette 文件
+	MODE_RECORD
+)
+
+// REDACTED 替换敏感信息的内容
+const REDACTED = "REDACTED"
+
+// ErrInteractionNotFound 回放时 cassette 中没有匹配的请求
+var ErrInteractionNotFound = errors.New("sdktest: no matching interaction in cassette")
+
+// redactHeaders 保存前替换的请求头，其中 Nonce、Timestamp 每次请求都不同，不参与匹配
+var redactHeaders = []string{"App-Key", "Nonce", "Timestamp", "Signature"}
+
+// Cassette 录制的请求及响应，以 json 格式保存
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction 一次请求及其响应
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest 录制的请求，不含域名
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	Path   string      `json:"path"`
+	Header http.Header `json:"header"`
+	Body   string      `json:"body"`
+}
+
+// RecordedResponse 录制的响应，body 已解压
+type RecordedResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header"`
+	Body       string      `json:"body"`
+}
+
+// executor 发送 http 请求，与 sdk.HTTPExecutor 一致
+type executor interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Recorder 录制回放 API 请求的 HTTPExecutor，通过 sdk.WithHTTPExecutor 或 SetHTTPExecutor 使用
+// 录制时替换 App-Key、签名等请求头及 WithRedact 指定的内容；回放时按请求方法、路径及参数匹配，忽略域名、Nonce、Timestamp 及签名，
+// 表单参数不区分顺序，json 请求体按内容比较
+type Recorder struct {
+	file         string
+	mode         Mode
+	executor     executor
+	redact       []string
+	ignoreParams []string
+
+	mu       sync.Mutex
+	cassette Cassette
+	used     []bool
+}
+
+// RecorderOption Recorder 配置项
+type RecorderOption func(*Recorder)
+
+// WithExecutor 设置录制时实际发送请求的方式，默认为 http.DefaultClient
+func WithExecutor(e interface {
+	Do(req *http.Request) (*http.Response, error)
+}) RecorderOption {
+	return func(r *Recorder) {
+		r.executor = e
+	}
+}
+
+// WithRedact 设置保存前需要替换的内容，如 appSecret、用户 token，请求体及响应体中出现的都会被替换
+func WithRedact(values ...string) RecorderOption {
+	return func(r *Recorder) {
+		for _, v := range values {
+			if v != "" {
+				r.redact = append(r.redact, v)
+			}
+		}
+	}
+}
+
+// WithIgnoreParams 设置回放时不参与匹配的参数，如每次请求都不同的随机数、时间，json 请求体只比较第一层字段
+func WithIgnoreParams(names ...string) RecorderOption {
+	return func(r *Recorder) {
+		r.ignoreParams = append(r.ignoreParams, names...)
+	}
+}
+
+// NewRecorder 创建 Recorder，file 为 cassette 文件路径
+// 回放模式下文件必须存在；录制模式下会覆盖已有文件，需调用 Close 保存
+func NewRecorder(file string, mode Mode, options ...RecorderOption) (*Recorder, error) {
+	r := &Recorder{
+		file:     file,
+		mode:     mode,
+		executor: http.DefaultClient,
+	}
+	for _, option := range options {
+		option(r)
+	}
+	if mode == MODE_REPLAY {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, &r.cassette); err != nil {
+			return nil, fmt.Errorf("sdktest: invalid cassette %s: %w", file, err)
+		}
+		r.used = make([]bool, len(r.cassette.Interactions))
+	}
+	return r, nil
+}
+
+// Do 实现 sdk.HTTPExecutor
+func (r *Recorder) Do(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+	r.mu.Lock()
+	// App-Key 在请求体及响应中出现时同样替换
+	if appKey := req.Header.Get("App-Key"); appKey != "" && !contains(r.redact, appKey) {
+		r.redact = append(r.redact, appKey)
+	}
+	redact := r.redact
+	r.mu.Unlock()
+	recorded := RecordedRequest{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Header: redactHeader(req.Header, redact),
+		Body:   redactString(string(body), redact),
+	}
+
+	if r.mode == MODE_RECORD {
+		return r.record(req, recorded, redact)
+	}
+	return r.replay(req, recorded)
+}
+
+// record 发送请求并保存请求及响应
+func (r *Recorder) record(req *http.Request, recorded RecordedRequest, redact []string) (*http.Response, error) {
+	resp, err := r.executor.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	header := resp.Header.Clone()
+	if header.Get("Content-Encoding") == "gzip" {
+		reader, err := gzip.NewReader(bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		if body, err = ioutil.ReadAll(reader); err != nil {
+			return nil, err
+		}
+		header.Del("Content-Encoding")
+		header.Del("Content-Length")
+	}
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request: recorded,
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     redactHeader(header, redact),
+			Body:       redactString(string(body), redact),
+		},
+	})
+	r.mu.Unlock()
+	return newResponse(req, resp.StatusCode, header, body), nil
+}
+
+// replay 返回第一条未使用的匹配记录，都已使用时返回最后一条匹配的记录
+func (r *Recorder) replay(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	found := -1
+	for i, interaction := range r.cassette.Interactions {
+		if !r.match(interaction.Request, recorded) {
+			continue
+		}
+		found = i
+		if !r.used[i] {
+			break
+		}
+	}
+	if found < 0 {
+		return nil, fmt.Errorf("%w: %s %s %s", ErrInteractionNotFound, recorded.Method, recorded.Path, recorded.Body)
+	}
+	r.used[found] = true
+	resp := r.cassette.Interactions[found].Response
+	return newResponse(req, resp.StatusCode, resp.Header.Clone(), []byte(resp.Body)), nil
+}
+
+// match 比较请求方法、路径、Content-Type 及参数
+func (r *Recorder) match(recorded, actual RecordedRequest) bool {
+	if recorded.Method != actual.Method || recorded.Path != actual.Path {
+		return false
+	}
+	mediaType, _, _ := mime.ParseMediaType(actual.Header.Get("Content-Type"))
+	recordedType, _, _ := mime.ParseMediaType(recorded.Header.Get("Content-Type"))
+	if mediaType != recordedType {
+		return false
+	}
+	switch mediaType {
+	case "application/x-www-form-urlencoded":
+		a, errA := url.ParseQuery(recorded.Body)
+		b, errB := url.ParseQuery(actual.Body)
+		if errA != nil || errB != nil {
+			return recorded.Body == actual.Body
+		}
+		for _, name := range r.ignoreParams {
+			a.Del(name)
+			b.Del(name)
+		}
+		return reflect.DeepEqual(a, b)
+	case "application/json":
+		var a, b interface{}
+		if json.Unmarshal([]byte(recorded.Body), &a) != nil || json.Unmarshal([]byte(actual.Body), &b) != nil {
+			return recorded.Body == actual.Body
+		}
+		for _, name := range r.ignoreParams {
+			if m, ok := a.(map[string]interface{}); ok {
+				delete(m, name)
+			}
+			if m, ok := b.(map[string]interface{}); ok {
+				delete(m, name)
+			}
+		}
+		return reflect.DeepEqual(a, b)
+	}
+	return recorded.Body == actual.Body
+}
+
+// Cassette 获取已录制或加载的内容
+func (r *Recorder) Cassette() Cassette {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return Cassette{Interactions: append([]Interaction(nil), r.cassette.Interactions...)}
+}
+
+// Unused 获取回放时未被使用的记录，可用于检查测试是否遗漏了请求
+func (r *Recorder) Unused() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var unused []Interaction
+	for i, used := range r.used {
+		if !used {
+			unused = append(unused, r.cassette.Interactions[i])
+		}
+	}
+	return unused
+}
+
+// Close 录制模式下保存 cassette 文件，先写入临时文件再重命名，回放模式下不做任何操作
+func (r *Recorder) Close() error {
+	if r.mode != MODE_RECORD {
+		return nil
+	}
+	r.mu.Lock()
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.file), 0755); err != nil {
+		return err
+	}
+	tmp := r.file + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, r.file)
+}
+
+// redactHeader 替换请求头中的敏感信息
+func redactHeader(header http.Header, redact []string) http.Header {
+	h := http.Header{}
+	for k, v := range header {
+		for _, value := range v {
+			h.Add(k, redactString(value, redact))
+		}
+	}
+	for _, k := range redactHeaders {
+		if h.Get(k) != "" {
+			h.Set(k, REDACTED)
+		}
+	}
+	return h
+}
+
+// redactString 将 s 中出现的 redact 替换为 REDACTED
+func redactString(s string, redact []string) string {
+	for _, v := range redact {
+		s = strings.Replace(s, v, REDACTED, -1)
+		// 表单参数中的内容经过 url 编码
+		if escaped := url.QueryEscape(v); escaped != v {
+			s = strings.Replace(s, escaped, REDACTED, -1)
+		}
+	}
+	return s
+}
+
+func newResponse(req *http.Request, statusCode int, header http.Header, body []byte) *http.Response {
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", statusCode, http.StatusText(statusCode)),
+		StatusCode:    statusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
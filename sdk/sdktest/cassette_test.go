@@ -0,0 +1,113 @@
+package sdktest
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rongcloud/server-sdk-go/v3/sdk"
+)
+
+// tempDir 创建临时目录，返回的函数用于删除该目录
+func tempDir(t *testing.T) (string, func()) {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "sdktest-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir, func() { _ = os.RemoveAll(dir) }
+}
+
+func TestRecorder(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	file := filepath.Join(dir, "cassettes", "user.json")
+	server := NewServer("appKey", "appSecret")
+
+	recorder, err := NewRecorder(file, MODE_RECORD, WithRedact("appSecret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rc := sdk.New("appKey", "appSecret", sdk.WithRongCloudURI(server.URL), sdk.WithHTTPExecutor(recorder))
+	user, err := rc.UserRegister("u01", "name", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := rc.BlacklistAdd("u01", []string{"u02", "u03"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := recorder.Close(); err != nil {
+		t.Fatal(err)
+	}
+	server.Close()
+
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"appKey", "appSecret"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("cassette contains %q:\n%s", secret, data)
+		}
+	}
+
+	// 回放时不访问网络，域名、App-Key 及签名不同也能匹配
+	recorder, err = NewRecorder(file, MODE_REPLAY)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rc = sdk.New("otherKey", "otherSecret", sdk.WithRongCloudURI("http://127.0.0.1:1"), sdk.WithHTTPExecutor(recorder))
+	if err := rc.BlacklistAdd("u01", []string{"u02", "u03"}); err != nil {
+		t.Error(err)
+	}
+	if len(recorder.Unused()) != 1 {
+		t.Errorf("unused = %+v", recorder.Unused())
+	}
+	replayed, err := rc.UserRegister("u01", "name", "")
+	if err != nil || replayed.Token != user.Token {
+		t.Errorf("UserRegister = %+v, %v, want %+v", replayed, err, user)
+	}
+	if len(recorder.Unused()) != 0 {
+		t.Errorf("unused = %+v", recorder.Unused())
+	}
+
+	_, err = rc.UserRegister("u02", "name", "")
+	if !errors.Is(err, ErrInteractionNotFound) {
+		t.Errorf("unmatched request: err = %v", err)
+	}
+}
+
+func TestRecorder_IgnoreParams(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	file := filepath.Join(dir, "cassette.json")
+	server := NewServer("appKey", "appSecret")
+	defer server.Close()
+
+	recorder, _ := NewRecorder(file, MODE_RECORD)
+	rc := sdk.New("appKey", "appSecret", sdk.WithRongCloudURI(server.URL), sdk.WithHTTPExecutor(recorder))
+	if _, err := rc.UserRegister("u01", "name", "a.png"); err != nil {
+		t.Fatal(err)
+	}
+	if err := recorder.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	recorder, _ = NewRecorder(file, MODE_REPLAY)
+	rc = sdk.New("appKey", "appSecret", sdk.WithHTTPExecutor(recorder))
+	if _, err := rc.UserRegister("u01", "name", "b.png"); !errors.Is(err, ErrInteractionNotFound) {
+		t.Errorf("err = %v", err)
+	}
+
+	recorder, _ = NewRecorder(file, MODE_REPLAY, WithIgnoreParams("portraitUri"))
+	rc = sdk.New("appKey", "appSecret", sdk.WithHTTPExecutor(recorder))
+	if _, err := rc.UserRegister("u01", "name", "b.png"); err != nil {
+		t.Error(err)
+	}
+	if len(server.Requests()) != 1 {
+		t.Errorf("requests = %d, want 1", len(server.Requests()))
+	}
+}
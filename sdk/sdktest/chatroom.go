@@ -0,0 +1,357 @@
+package sdktest
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// JoinChatRoom 模拟用户通过客户端加入聊天室，聊天室不存在时创建
+func (s *Server) JoinChatRoom(chatRoomID string, userIDs ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	c := s.getChatRoom(chatRoomID, now)
+	for _, id := range userIDs {
+		if _, ok := c.members[id]; !ok {
+			c.members[id] = now
+		}
+	}
+}
+
+// ChatRoomDistributionStopped 聊天室是否已停止消息分发
+func (s *Server) ChatRoomDistributionStopped(chatRoomID string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.chatrooms[chatRoomID]
+	return ok && c.stopped
+}
+
+func (s *Server) registerChatRoom() {
+	s.handle("/chatroom/create", func(r *Request) (result, error) {
+		created := false
+		now := s.now()
+		for key := range r.Form {
+			if !strings.HasPrefix(key, "chatroom[") || !strings.HasSuffix(key, "]") {
+				continue
+			}
+			s.getChatRoom(key[len("chatroom["):len(key)-1], now).name = r.Form.Get(key)
+			created = true
+		}
+		if !created {
+			return nil, errParam("chatroom")
+		}
+		return nil, nil
+	})
+	s.handle("/chatroom/destroy", func(r *Request) (result, error) {
+		if err := required(r, "chatroomId"); err != nil {
+			return nil, err
+		}
+		for _, id := range r.Form["chatroomId"] {
+			delete(s.chatrooms, id)
+		}
+		return nil, nil
+	})
+	s.handle("/chatroom/query", func(r *Request) (result, error) {
+		if err := required(r, "chatroomId"); err != nil {
+			return nil, err
+		}
+		rooms := []map[string]string{}
+		for _, id := range r.Form["chatroomId"] {
+			if c, ok := s.chatrooms[id]; ok {
+				rooms = append(rooms, map[string]string{"chrmId": id, "name": c.name, "time": formatTime(c.created)})
+			}
+		}
+		return result{"chatRooms": rooms}, nil
+	})
+	s.handle("/chatroom/user/query", func(r *Request) (result, error) {
+		if err := required(r, "chatroomId", "count", "order"); err != nil {
+			return nil, err
+		}
+		count, _ := strconv.Atoi(r.Form.Get("count"))
+		if count <= 0 || count > 500 {
+			return nil, errLimit("count")
+		}
+		users := []map[string]string{}
+		total := 0
+		if c, ok := s.chatrooms[r.Form.Get("chatroomId")]; ok {
+			ids := sortedByTime(c.members)
+			total = len(ids)
+			// order：1 加入时间正序，2 加入时间倒序
+			if r.Form.Get("order") == "2" {
+				for i, j := 0, len(ids)-1; i < j; i, j = i+1, j-1 {
+					ids[i], ids[j] = ids[j], ids[i]
+				}
+			}
+			if len(ids) > count {
+				ids = ids[:count]
+			}
+			for _, id := range ids {
+				users = append(users, map[string]string{"id": id, "userId": id, "time": formatTime(c.members[id])})
+			}
+		}
+		return result{"total": total, "users": users}, nil
+	})
+	s.handle("/chatroom/users/exist", func(r *Request) (result, error) {
+		if err := required(r, "chatroomId", "userId"); err != nil {
+			return nil, err
+		}
+		c := s.chatrooms[r.Form.Get("chatroomId")]
+		users := []map[string]interface{}{}
+		for _, id := range r.Form["userId"] {
+			in := 0
+			if c != nil {
+				if _, ok := c.members[id]; ok {
+					in = 1
+				}
+			}
+			users = append(users, map[string]interface{}{"userId": id, "isInChrm": in})
+		}
+		return result{"result": users}, nil
+	})
+
+	s.handleChatRoomUsers("/chatroom/user/block", func(c *chatroom) map[string]time.Time { return c.blocked }, "list")
+	s.handleChatRoomUsers("/chatroom/user/gag", func(c *chatroom) map[string]time.Time { return c.gagged }, "list")
+
+	s.handle("/chatroom/user/ban/add", func(r *Request) (result, error) {
+		if err := required(r, "userId"); err != nil {
+			return nil, err
+		}
+		d, err := minutes(r, "minute")
+		if err != nil {
+			return nil, err
+		}
+		until := s.now().Add(d)
+		for _, id := range r.Form["userId"] {
+			s.chatroomBan[id] = until
+		}
+		return nil, nil
+	})
+	s.handle("/chatroom/user/ban/remove", func(r *Request) (result, error) {
+		if err := required(r, "userId"); err != nil {
+			return nil, err
+		}
+		for _, id := range r.Form["userId"] {
+			delete(s.chatroomBan, id)
+		}
+		return nil, nil
+	})
+	s.handle("/chatroom/user/ban/query", func(r *Request) (result, error) {
+		users := []map[string]string{}
+		for _, id := range active(s.chatroomBan, s.now()) {
+			users = append(users, map[string]string{"userId": id, "time": formatTime(s.chatroomBan[id])})
+		}
+		return result{"users": users}, nil
+	})
+
+	s.handleSet("/chatroom/message/priority/add", "objectName", func() set { return s.chatroomPriority }, true)
+	s.handleSet("/chatroom/message/priority/remove", "objectName", func() set { return s.chatroomPriority }, false)
+	s.handle("/chatroom/message/priority/query", func(r *Request) (result, error) {
+		return result{"objectNames": s.chatroomPriority.list()}, nil
+	})
+	s.handleSet("/chatroom/whitelist/add", "objectnames", func() set { return s.chatroomMsgWhitelist }, true)
+	s.handleSet("/chatroom/whitelist/delete", "objectnames", func() set { return s.chatroomMsgWhitelist }, false)
+	s.handle("/chatroom/whitelist/query", func(r *Request) (result, error) {
+		return result{"whitlistMsgType": s.chatroomMsgWhitelist.list()}, nil
+	})
+
+	s.handle("/chatroom/message/stopDistribution", func(r *Request) (result, error) {
+		if err := required(r, "chatroomId"); err != nil {
+			return nil, err
+		}
+		s.getChatRoom(r.Form.Get("chatroomId"), s.now()).stopped = true
+		return nil, nil
+	})
+	s.handle("/chatroom/message/resumeDistribution", func(r *Request) (result, error) {
+		if err := required(r, "chatroomId"); err != nil {
+			return nil, err
+		}
+		s.getChatRoom(r.Form.Get("chatroomId"), s.now()).stopped = false
+		return nil, nil
+	})
+
+	s.handle("/chatroom/keepalive/add", func(r *Request) (result, error) {
+		if err := required(r, "chatroomId"); err != nil {
+			return nil, err
+		}
+		s.getChatRoom(r.Form.Get("chatroomId"), s.now()).keepalive = true
+		return nil, nil
+	})
+	s.handle("/chatroom/keepalive/remove", func(r *Request) (result, error) {
+		if err := required(r, "chatroomId"); err != nil {
+			return nil, err
+		}
+		if c, ok := s.chatrooms[r.Form.Get("chatroomId")]; ok {
+			c.keepalive = false
+		}
+		return nil, nil
+	})
+	s.handle("/chatroom/keepalive/query", func(r *Request) (result, error) {
+		ids := set{}
+		for id, c := range s.chatrooms {
+			if c.keepalive {
+				ids.add(id)
+			}
+		}
+		return result{"chatroomids": ids.list()}, nil
+	})
+
+	s.handle("/chatroom/user/whitelist/add", func(r *Request) (result, error) {
+		if err := required(r, "chatroomId", "userId"); err != nil {
+			return nil, err
+		}
+		c := s.getChatRoom(r.Form.Get("chatroomId"), s.now())
+		if len(c.whitelist)+len(r.Form["userId"]) > 5 {
+			return nil, errLimit("userId")
+		}
+		c.whitelist.add(r.Form["userId"]...)
+		return nil, nil
+	})
+	s.handle("/chatroom/user/whitelist/remove", func(r *Request) (result, error) {
+		if err := required(r, "chatroomId", "userId"); err != nil {
+			return nil, err
+		}
+		if c, ok := s.chatrooms[r.Form.Get("chatroomId")]; ok {
+			c.whitelist.remove(r.Form["userId"]...)
+		}
+		return nil, nil
+	})
+	s.handle("/chatroom/user/whitelist/query", func(r *Request) (result, error) {
+		if err := required(r, "chatroomId"); err != nil {
+			return nil, err
+		}
+		users := []string{}
+		if c, ok := s.chatrooms[r.Form.Get("chatroomId")]; ok {
+			users = c.whitelist.list()
+		}
+		return result{"users": users}, nil
+	})
+
+	s.handle("/chatroom/entry/set", func(r *Request) (result, error) {
+		if err := required(r, "chatroomId", "userId", "key", "value"); err != nil {
+			return nil, err
+		}
+		c := s.getChatRoom(r.Form.Get("chatroomId"), s.now())
+		key := r.Form.Get("key")
+		// 每个聊天室最多设置 100 个属性
+		if _, exists := c.entries[key]; !exists && len(c.entries) >= 100 {
+			return nil, errLimit("key")
+		}
+		c.entries[key] = chatroomEntry{
+			key:        key,
+			value:      r.Form.Get("value"),
+			userID:     r.Form.Get("userId"),
+			autoDelete: r.Form.Get("autoDelete"),
+			setTime:    s.now(),
+		}
+		return nil, nil
+	})
+	s.handle("/chatroom/entry/remove", func(r *Request) (result, error) {
+		if err := required(r, "chatroomId", "userId", "key"); err != nil {
+			return nil, err
+		}
+		if c, ok := s.chatrooms[r.Form.Get("chatroomId")]; ok {
+			delete(c.entries, r.Form.Get("key"))
+		}
+		return nil, nil
+	})
+	s.handle("/chatroom/entry/query", func(r *Request) (result, error) {
+		if err := required(r, "chatroomId"); err != nil {
+			return nil, err
+		}
+		entries := []map[string]string{}
+		c, ok := s.chatrooms[r.Form.Get("chatroomId")]
+		if !ok {
+			return result{"keys": entries}, nil
+		}
+		keys := set{}
+		for _, v := range r.Form["keys"] {
+			for _, key := range strings.Split(v, ",") {
+				if key != "" {
+					keys.add(key)
+				}
+			}
+		}
+		if len(keys) > 100 {
+			return nil, errLimit("keys")
+		}
+		if len(keys) == 0 {
+			for key := range c.entries {
+				keys.add(key)
+			}
+		}
+		for _, key := range keys.list() {
+			e, ok := c.entries[key]
+			if !ok {
+				continue
+			}
+			entries = append(entries, map[string]string{
+				"key":         e.key,
+				"value":       e.value,
+				"userID":      e.userID,
+				"autoDelete":  e.autoDelete,
+				"lastSetTime": formatTime(e.setTime),
+			})
+		}
+		return result{"keys": entries}, nil
+	})
+}
+
+// handleChatRoomUsers 注册聊天室成员封禁、禁言的 add、rollback 及查询接口
+func (s *Server) handleChatRoomUsers(prefix string, users func(c *chatroom) map[string]time.Time, query string) {
+	s.handle(prefix+"/add", func(r *Request) (result, error) {
+		if err := required(r, "chatroomId", "userId"); err != nil {
+			return nil, err
+		}
+		d, err := minutes(r, "minute")
+		if err != nil {
+			return nil, err
+		}
+		m := users(s.getChatRoom(r.Form.Get("chatroomId"), s.now()))
+		until := s.now().Add(d)
+		for _, id := range r.Form["userId"] {
+			m[id] = until
+		}
+		return nil, nil
+	})
+	s.handle(prefix+"/rollback", func(r *Request) (result, error) {
+		if err := required(r, "chatroomId", "userId"); err != nil {
+			return nil, err
+		}
+		if c, ok := s.chatrooms[r.Form.Get("chatroomId")]; ok {
+			m := users(c)
+			for _, id := range r.Form["userId"] {
+				delete(m, id)
+			}
+		}
+		return nil, nil
+	})
+	s.handle(prefix+"/"+query, func(r *Request) (result, error) {
+		if err := required(r, "chatroomId"); err != nil {
+			return nil, err
+		}
+		list := []map[string]string{}
+		if c, ok := s.chatrooms[r.Form.Get("chatroomId")]; ok {
+			m := users(c)
+			for _, id := range active(m, s.now()) {
+				list = append(list, map[string]string{"userId": id, "time": formatTime(m[id])})
+			}
+		}
+		return result{"users": list}, nil
+	})
+}
+
+// handleSet 注册向集合添加（add 为 true）或移除参数 name 的接口
+func (s *Server) handleSet(path, name string, target func() set, add bool) {
+	s.handle(path, func(r *Request) (result, error) {
+		if err := required(r, name); err != nil {
+			return nil, err
+		}
+		if add {
+			target().add(r.Form[name]...)
+		} else {
+			target().remove(r.Form[name]...)
+		}
+		return nil, nil
+	})
+}
@@ -0,0 +1,208 @@
+package sdktest
+
+import (
+	"strings"
+	"time"
+)
+
+func (s *Server) registerGroup() {
+	s.handle("/group/create", func(r *Request) (result, error) {
+		if err := required(r, "userId", "groupId", "groupName"); err != nil {
+			return nil, err
+		}
+		g := s.getGroup(r.Form.Get("groupId"))
+		g.name = r.Form.Get("groupName")
+		now := s.now()
+		for _, id := range r.Form["userId"] {
+			g.members[id] = now
+		}
+		return nil, nil
+	})
+	s.handle("/group/sync", func(r *Request) (result, error) {
+		if err := required(r, "userId"); err != nil {
+			return nil, err
+		}
+		userID := r.Form.Get("userId")
+		now := s.now()
+		for key := range r.Form {
+			if !strings.HasPrefix(key, "group[") || !strings.HasSuffix(key, "]") {
+				continue
+			}
+			g := s.getGroup(key[len("group[") : len(key)-1])
+			g.name = r.Form.Get(key)
+			if _, ok := g.members[userID]; !ok {
+				g.members[userID] = now
+			}
+		}
+		return nil, nil
+	})
+	s.handle("/group/refresh", func(r *Request) (result, error) {
+		if err := required(r, "groupId", "groupName"); err != nil {
+			return nil, err
+		}
+		s.getGroup(r.Form.Get("groupId")).name = r.Form.Get("groupName")
+		return nil, nil
+	})
+	s.handle("/group/join", func(r *Request) (result, error) {
+		if err := required(r, "userId", "groupId"); err != nil {
+			return nil, err
+		}
+		g := s.getGroup(r.Form.Get("groupId"))
+		if name := r.Form.Get("groupName"); name != "" {
+			g.name = name
+		}
+		now := s.now()
+		for _, id := range r.Form["userId"] {
+			if _, ok := g.members[id]; !ok {
+				g.members[id] = now
+			}
+		}
+		return nil, nil
+	})
+	s.handle("/group/quit", func(r *Request) (result, error) {
+		if err := required(r, "userId", "groupId"); err != nil {
+			return nil, err
+		}
+		if g, ok := s.groups[r.Form.Get("groupId")]; ok {
+			for _, id := range r.Form["userId"] {
+				delete(g.members, id)
+			}
+		}
+		return nil, nil
+	})
+	s.handle("/group/dismiss", func(r *Request) (result, error) {
+		if err := required(r, "userId", "groupId"); err != nil {
+			return nil, err
+		}
+		delete(s.groups, r.Form.Get("groupId"))
+		s.groupBan.remove(r.Form.Get("groupId"))
+		return nil, nil
+	})
+	s.handle("/group/user/query", func(r *Request) (result, error) {
+		if err := required(r, "groupId"); err != nil {
+			return nil, err
+		}
+		users := []map[string]string{}
+		if g, ok := s.groups[r.Form.Get("groupId")]; ok {
+			for _, id := range sortedByTime(g.members) {
+				users = append(users, map[string]string{"id": id})
+			}
+		}
+		return result{"users": users}, nil
+	})
+
+	s.handle("/group/user/gag/add", func(r *Request) (result, error) {
+		if err := required(r, "userId", "groupId"); err != nil {
+			return nil, err
+		}
+		d, err := minutes(r, "minute")
+		if err != nil {
+			return nil, err
+		}
+		g := s.getGroup(r.Form.Get("groupId"))
+		until := s.now().Add(d)
+		for _, id := range r.Form["userId"] {
+			g.gagged[id] = until
+		}
+		return nil, nil
+	})
+	s.handle("/group/user/gag/rollback", func(r *Request) (result, error) {
+		if err := required(r, "userId", "groupId"); err != nil {
+			return nil, err
+		}
+		if g, ok := s.groups[r.Form.Get("groupId")]; ok {
+			for _, id := range r.Form["userId"] {
+				delete(g.gagged, id)
+			}
+		}
+		return nil, nil
+	})
+	s.handle("/group/user/gag/list", func(r *Request) (result, error) {
+		if err := required(r, "groupId"); err != nil {
+			return nil, err
+		}
+		users := []map[string]string{}
+		if g, ok := s.groups[r.Form.Get("groupId")]; ok {
+			for _, id := range active(g.gagged, s.now()) {
+				users = append(users, map[string]string{"userId": id, "time": formatTime(g.gagged[id])})
+			}
+		}
+		return result{"users": users}, nil
+	})
+
+	s.handle("/group/ban/add", func(r *Request) (result, error) {
+		if err := required(r, "groupId"); err != nil {
+			return nil, err
+		}
+		s.groupBan.add(r.Form["groupId"]...)
+		return nil, nil
+	})
+	s.handle("/group/ban/rollback", func(r *Request) (result, error) {
+		if err := required(r, "groupId"); err != nil {
+			return nil, err
+		}
+		s.groupBan.remove(r.Form["groupId"]...)
+		return nil, nil
+	})
+	s.handle("/group/ban/query", func(r *Request) (result, error) {
+		ids := r.Form["groupId"]
+		if len(ids) == 0 {
+			ids = s.groupBan.list()
+		}
+		groups := []map[string]string{}
+		for _, id := range ids {
+			stat := "0"
+			if s.groupBan[id] {
+				stat = "1"
+			}
+			groups = append(groups, map[string]string{"id": id, "stat": stat})
+		}
+		return result{"groupinfo": groups}, nil
+	})
+
+	s.handle("/group/user/ban/whitelist/add", func(r *Request) (result, error) {
+		if err := required(r, "userId", "groupId"); err != nil {
+			return nil, err
+		}
+		s.getGroup(r.Form.Get("groupId")).banWhitelist.add(r.Form["userId"]...)
+		return nil, nil
+	})
+	s.handle("/group/user/ban/whitelist/rollback", func(r *Request) (result, error) {
+		if err := required(r, "userId", "groupId"); err != nil {
+			return nil, err
+		}
+		s.getGroup(r.Form.Get("groupId")).banWhitelist.remove(r.Form["userId"]...)
+		return nil, nil
+	})
+	s.handle("/group/user/ban/whitelist/query", func(r *Request) (result, error) {
+		if err := required(r, "groupId"); err != nil {
+			return nil, err
+		}
+		var ids []string
+		if g, ok := s.groups[r.Form.Get("groupId")]; ok {
+			ids = g.banWhitelist.list()
+		}
+		return result{"userids": ids}, nil
+	})
+}
+
+// GroupMembers 获取群组成员，按加入时间排序
+func (s *Server) GroupMembers(groupID string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	g, ok := s.groups[groupID]
+	if !ok {
+		return nil
+	}
+	return sortedByTime(g.members)
+}
+
+// sortedByTime 按时间先后返回 ID，时间相同时按字典序
+func sortedByTime(m map[string]time.Time) []string {
+	ids := make([]string, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	sortIDs(ids, m)
+	return ids
+}
@@ -0,0 +1,256 @@
+package sdktest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Messages 获取服务收到的消息，path 不为空时只返回该接口的消息
+func (s *Server) Messages(path ...string) []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var messages []Message
+	for _, m := range s.messages {
+		if len(path) == 0 || contains(path, m.Path) {
+			messages = append(messages, m)
+		}
+	}
+	return messages
+}
+
+// SensitiveWords 获取已设置的敏感词及替换词，屏蔽词的替换词为空
+func (s *Server) SensitiveWords() map[string]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	words := map[string]string{}
+	for word, w := range s.sensitiveWords {
+		words[word] = w.replaceWord
+	}
+	return words
+}
+
+// record 记录消息并生成消息 ID
+func (s *Server) record(r *Request, targets []string, content string) Message {
+	s.seq++
+	m := Message{
+		Path:       r.Path,
+		MsgUID:     fmt.Sprintf("SDKT-%04d-%04d", s.seq/10000, s.seq%10000),
+		FromUserID: r.Form.Get("fromUserId"),
+		TargetIDs:  targets,
+		ObjectName: r.Form.Get("objectName"),
+		Content:    content,
+		Form:       r.Form,
+		Time:       s.now(),
+	}
+	s.messages = append(s.messages, m)
+	return m
+}
+
+// handlePublish 注册发送消息接口，target 为接收方参数名，为空时表示广播，limit 为接收方数量上限
+func (s *Server) handlePublish(path, target string, limit int) {
+	s.handle(path, func(r *Request) (result, error) {
+		if err := required(r, "fromUserId", "objectName", "content"); err != nil {
+			return nil, err
+		}
+		var targets []string
+		if target != "" {
+			if err := required(r, target); err != nil {
+				return nil, err
+			}
+			targets = r.Form[target]
+			if len(targets) > limit {
+				return nil, errLimit(target)
+			}
+		}
+		// verifyBlacklist 为 1 时不发送给将发送人加入黑名单的用户
+		if target == "toUserId" && r.Form.Get("verifyBlacklist") == "1" {
+			targets = s.filterBlacklist(r.Form.Get("fromUserId"), targets)
+		}
+		m := s.record(r, targets, r.Form.Get("content"))
+		if target == "" {
+			return result{"id": m.MsgUID}, nil
+		}
+		return nil, nil
+	})
+}
+
+// handleTemplate 注册发送模板消息接口，请求体为 json
+func (s *Server) handleTemplate(path string) {
+	s.handle(path, func(r *Request) (result, error) {
+		var param struct {
+			FromUserID      string              `json:"fromUserId"`
+			ObjectName      string              `json:"objectName"`
+			Content         string              `json:"content"`
+			ToUserID        []string            `json:"toUserId"`
+			Values          []map[string]string `json:"values"`
+			PushContent     []string            `json:"pushContent"`
+			VerifyBlacklist int                 `json:"verifyBlacklist"`
+		}
+		if err := json.Unmarshal(r.Body, &param); err != nil {
+			return nil, errParam("content")
+		}
+		switch {
+		case param.FromUserID == "":
+			return nil, errParam("fromUserId")
+		case param.ObjectName == "":
+			return nil, errParam("objectName")
+		case param.Content == "":
+			return nil, errParam("content")
+		case len(param.ToUserID) == 0:
+			return nil, errParam("toUserId")
+		case len(param.Values) != len(param.ToUserID):
+			return nil, errParam("values")
+		}
+		form := url.Values{"fromUserId": {param.FromUserID}, "objectName": {param.ObjectName}}
+		for i, to := range param.ToUserID {
+			if param.VerifyBlacklist == 1 && len(s.filterBlacklist(param.FromUserID, []string{to})) == 0 {
+				continue
+			}
+			// 按接收人替换模板中的 {key}
+			content := param.Content
+			for k, v := range param.Values[i] {
+				content = strings.Replace(content, "{"+k+"}", v, -1)
+			}
+			s.record(&Request{Path: r.Path, Form: form}, []string{to}, content)
+		}
+		return nil, nil
+	})
+}
+
+// filterBlacklist 过滤将 from 加入黑名单的用户
+func (s *Server) filterBlacklist(from string, targets []string) []string {
+	filtered := make([]string, 0, len(targets))
+	for _, to := range targets {
+		if u, ok := s.users[to]; ok && u.blacklist[from] {
+			continue
+		}
+		filtered = append(filtered, to)
+	}
+	return filtered
+}
+
+// conversationKey 会话免打扰的 key
+func conversationKey(userID, conversationType, targetID string) string {
+	return userID + "|" + conversationType + "|" + targetID
+}
+
+func (s *Server) registerMessage() {
+	s.handlePublish("/message/private/publish", "toUserId", 1000)
+	s.handlePublish("/statusmessage/private/publish", "toUserId", 1000)
+	s.handlePublish("/message/system/publish", "toUserId", 100)
+	s.handlePublish("/message/group/publish", "toGroupId", 3)
+	s.handlePublish("/statusmessage/group/publish", "toGroupId", 3)
+	s.handlePublish("/message/chatroom/publish", "toChatroomId", 10)
+	s.handlePublish("/message/chatroom/broadcast", "", 0)
+	s.handlePublish("/message/online/broadcast", "", 0)
+	s.handlePublish("/message/broadcast", "", 0)
+	s.handleTemplate("/message/private/publish_template")
+	s.handleTemplate("/message/system/publish_template")
+
+	s.handle("/message/recall", func(r *Request) (result, error) {
+		if err := required(r, "fromUserId", "conversationType", "targetId", "messageUID", "sentTime"); err != nil {
+			return nil, err
+		}
+		s.record(r, []string{r.Form.Get("targetId")}, "")
+		return nil, nil
+	})
+	s.handle("/message/history", func(r *Request) (result, error) {
+		if err := required(r, "date"); err != nil {
+			return nil, err
+		}
+		return result{"url": "", "date": r.Form.Get("date")}, nil
+	})
+	s.handle("/message/history/delete", func(r *Request) (result, error) {
+		return nil, required(r, "date")
+	})
+
+	s.handle("/push", func(r *Request) (result, error) {
+		var push struct {
+			PlatForm []string        `json:"platform"`
+			Audience json.RawMessage `json:"audience"`
+			FromUser string          `json:"fromuserid"`
+			Message  struct {
+				ObjectName string `json:"objectName"`
+				Content    string `json:"content"`
+			} `json:"message"`
+		}
+		if err := json.Unmarshal(r.Body, &push); err != nil {
+			return nil, errParam("platform")
+		}
+		if len(push.PlatForm) == 0 {
+			return nil, errParam("platform")
+		}
+		if len(push.Audience) == 0 {
+			return nil, errParam("audience")
+		}
+		form := url.Values{"fromUserId": {push.FromUser}, "objectName": {push.Message.ObjectName}}
+		m := s.record(&Request{Path: r.Path, Form: form}, nil, string(r.Body))
+		return result{"id": m.MsgUID}, nil
+	})
+
+	s.handle("/conversation/notification/set", func(r *Request) (result, error) {
+		if err := required(r, "requestId", "conversationType", "targetId", "isMuted"); err != nil {
+			return nil, err
+		}
+		key := conversationKey(r.Form.Get("requestId"), r.Form.Get("conversationType"), r.Form.Get("targetId"))
+		if r.Form.Get("isMuted") == "1" {
+			s.mutedConversations.add(key)
+		} else {
+			s.mutedConversations.remove(key)
+		}
+		return nil, nil
+	})
+	s.handle("/conversation/notification/get", func(r *Request) (result, error) {
+		if err := required(r, "requestId", "conversationType", "targetId"); err != nil {
+			return nil, err
+		}
+		isMuted := 0
+		if s.mutedConversations[conversationKey(r.Form.Get("requestId"), r.Form.Get("conversationType"), r.Form.Get("targetId"))] {
+			isMuted = 1
+		}
+		return result{"isMuted": isMuted}, nil
+	})
+
+	s.handle("/sensitiveword/add", func(r *Request) (result, error) {
+		if err := required(r, "word"); err != nil {
+			return nil, err
+		}
+		if len(s.sensitiveWords) >= 50 {
+			return nil, errLimit("word")
+		}
+		word := r.Form.Get("word")
+		s.sensitiveWords[word] = sensitiveWord{word: word, replaceWord: r.Form.Get("replaceWord")}
+		return nil, nil
+	})
+	s.handle("/sensitiveword/batch/delete", func(r *Request) (result, error) {
+		if err := required(r, "words"); err != nil {
+			return nil, err
+		}
+		if len(r.Form["words"]) > 50 {
+			return nil, errLimit("words")
+		}
+		for _, word := range r.Form["words"] {
+			delete(s.sensitiveWords, word)
+		}
+		return nil, nil
+	})
+	s.handle("/sensitiveword/list", func(r *Request) (result, error) {
+		words := set{}
+		for word := range s.sensitiveWords {
+			words.add(word)
+		}
+		list := []map[string]string{}
+		for _, word := range words.list() {
+			w := s.sensitiveWords[word]
+			// type：0 替换敏感词，1 屏蔽敏感词
+			typ := "1"
+			if w.replaceWord != "" {
+				typ = "0"
+			}
+			list = append(list, map[string]string{"type": typ, "word": w.word, "replaceWord": w.replaceWord})
+		}
+		return result{"words": list}, nil
+	})
+}
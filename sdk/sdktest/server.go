@@ -0,0 +1,260 @@
+// Package sdktest 提供模拟融云 Server API 的内存服务，用于不依赖网络的测试
+/*
+	server := sdktest.NewServer("appKey", "appSecret")
+	defer server.Close()
+	rc := sdk.New("appKey", "appSecret", sdk.WithRongCloudURI(server.URL))
+*/
+package sdktest
+
+import (
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// timeFormat 融云接口返回的时间格式
+const timeFormat = "2006-01-02 15:04:05"
+
+// Request 服务收到的请求
+type Request struct {
+	Path       string      // 接口路径，不含 .json 后缀，如 /user/getToken
+	Header     http.Header // 请求头，包括签名
+	Form       url.Values  // 表单参数
+	Body       []byte      // 原始请求体
+	Time       time.Time
+	StatusCode int // 响应的 http 状态码
+	Code       int // 响应的融云返回码
+}
+
+// JSON 将 json 格式的请求体解码到 v
+func (r Request) JSON(v interface{}) error {
+	return json.Unmarshal(r.Body, v)
+}
+
+// Error 模拟的接口错误
+type Error struct {
+	StatusCode int
+	Code       int
+	Message    string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%d: %s", e.Code, e.Message)
+}
+
+// errParam 参数错误，返回码 1002
+func errParam(name string) *Error {
+	return &Error{StatusCode: http.StatusBadRequest, Code: 1002, Message: fmt.Sprintf("%s is required.", name)}
+}
+
+// errLimit 参数数量超出限制，返回码 1002
+func errLimit(name string) *Error {
+	return &Error{StatusCode: http.StatusBadRequest, Code: 1002, Message: fmt.Sprintf("%s size out of limit.", name)}
+}
+
+// result 接口返回内容，code 为 200 时可省略
+type result map[string]interface{}
+
+// handler 接口处理函数，调用时已持有 Server 的锁
+type handler func(r *Request) (result, error)
+
+// Server 模拟融云 Server API 的 httptest 服务
+// 实现用户、群组、聊天室、会话、消息、敏感词及推送接口，数据保存在内存中；校验请求签名，参数错误时返回与融云一致的返回码
+type Server struct {
+	*httptest.Server
+	AppKey    string
+	AppSecret string
+
+	mu       sync.Mutex
+	now      func() time.Time
+	handlers map[string]handler
+	requests []Request
+	failures map[string][]*Error
+	state
+}
+
+// NewServer 创建并启动模拟服务，使用完毕后需调用 Close
+func NewServer(appKey, appSecret string) *Server {
+	s := &Server{
+		AppKey:    appKey,
+		AppSecret: appSecret,
+		now:       time.Now,
+		failures:  map[string][]*Error{},
+	}
+	s.state = newState()
+	s.handlers = map[string]handler{}
+	s.registerUser()
+	s.registerGroup()
+	s.registerChatRoom()
+	s.registerMessage()
+	s.Server = httptest.NewServer(s)
+	return s
+}
+
+// ServeHTTP 实现 http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	req := &Request{
+		Path:   strings.TrimSuffix(r.URL.Path, ".json"),
+		Header: r.Header.Clone(),
+		Time:   time.Now(),
+	}
+	req.Body, _ = ioutil.ReadAll(r.Body)
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != "application/json" {
+		req.Form, _ = url.ParseQuery(string(req.Body))
+	}
+	if req.Form == nil {
+		req.Form = url.Values{}
+	}
+
+	s.mu.Lock()
+	res, err := s.serve(req)
+	status, code := http.StatusOK, http.StatusOK
+	if err != nil {
+		e, ok := err.(*Error)
+		if !ok {
+			e = &Error{StatusCode: http.StatusInternalServerError, Code: 1000, Message: err.Error()}
+		}
+		status, code = e.StatusCode, e.Code
+		res = result{"code": e.Code, "errorMessage": e.Message}
+	} else if _, ok := res["code"]; !ok {
+		res["code"] = http.StatusOK
+	}
+	req.StatusCode, req.Code = status, code
+	s.requests = append(s.requests, *req)
+	requestID := fmt.Sprintf("sdktest-%d", len(s.requests))
+	s.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Request-ID", requestID)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(res)
+}
+
+// serve 校验签名，执行注入的错误或接口处理函数，调用方需持有锁
+func (s *Server) serve(r *Request) (result, error) {
+	if err := s.verify(r.Header); err != nil {
+		return nil, err
+	}
+	if failures := s.failures[r.Path]; len(failures) > 0 {
+		s.failures[r.Path] = failures[1:]
+		return nil, failures[0]
+	}
+	h, ok := s.handlers[r.Path]
+	if !ok {
+		return nil, &Error{StatusCode: http.StatusNotFound, Code: 1000, Message: "unknown api " + r.Path}
+	}
+	res, err := h(r)
+	if res == nil && err == nil {
+		res = result{}
+	}
+	return res, err
+}
+
+// verify 校验 App-Key 及 SHA1(appSecret + nonce + timestamp) 签名
+func (s *Server) verify(header http.Header) error {
+	if header.Get("App-Key") != s.AppKey {
+		return &Error{StatusCode: http.StatusUnauthorized, Code: 1001, Message: "App Key is invalid."}
+	}
+	nonce, timestamp := header.Get("Nonce"), header.Get("Timestamp")
+	if nonce == "" || timestamp == "" {
+		return &Error{StatusCode: http.StatusUnauthorized, Code: 1004, Message: "Signature error."}
+	}
+	expected := fmt.Sprintf("%x", sha1.Sum([]byte(s.AppSecret+nonce+timestamp)))
+	if subtle.ConstantTimeCompare([]byte(expected), []byte(header.Get("Signature"))) != 1 {
+		return &Error{StatusCode: http.StatusUnauthorized, Code: 1004, Message: "Signature error."}
+	}
+	return nil
+}
+
+// FailNext 使接口 path（如 /user/getToken）的下一次调用返回指定的 http 状态码及返回码，多次调用依次生效
+func (s *Server) FailNext(path string, statusCode, code int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures[path] = append(s.failures[path], &Error{StatusCode: statusCode, Code: code, Message: "injected error"})
+}
+
+// Requests 获取服务收到的所有请求，path 不为空时只返回该接口的请求
+func (s *Server) Requests(path ...string) []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var requests []Request
+	for _, r := range s.requests {
+		if len(path) == 0 || contains(path, r.Path) {
+			requests = append(requests, r)
+		}
+	}
+	return requests
+}
+
+// LastRequest 获取接口 path 最后一次收到的请求，没有时 ok 为 false
+func (s *Server) LastRequest(path string) (r Request, ok bool) {
+	requests := s.Requests(path)
+	if len(requests) == 0 {
+		return Request{}, false
+	}
+	return requests[len(requests)-1], true
+}
+
+// Reset 清空所有数据、请求记录及注入的错误
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state = newState()
+	s.requests = nil
+	s.failures = map[string][]*Error{}
+}
+
+// handle 注册接口处理函数
+func (s *Server) handle(path string, h handler) {
+	s.handlers[path] = h
+}
+
+// formatTime 按融云接口返回的格式格式化时间
+func formatTime(t time.Time) string {
+	return t.Format(timeFormat)
+}
+
+func contains(list []string, v string) bool {
+	for _, item := range list {
+		if item == v {
+			return true
+		}
+	}
+	return false
+}
+
+// set 字符串集合
+type set map[string]bool
+
+func (s set) add(values ...string) {
+	for _, v := range values {
+		s[v] = true
+	}
+}
+
+func (s set) remove(values ...string) {
+	for _, v := range values {
+		delete(s, v)
+	}
+}
+
+// list 按字典序返回集合内容
+func (s set) list() []string {
+	list := make([]string, 0, len(s))
+	for v := range s {
+		list = append(list, v)
+	}
+	sort.Strings(list)
+	return list
+}
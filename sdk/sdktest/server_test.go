@@ -0,0 +1,157 @@
+package sdktest
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/rongcloud/server-sdk-go/v3/sdk"
+)
+
+func newClient(s *Server, appSecret string) *sdk.RongCloud {
+	return sdk.New(s.AppKey, appSecret, sdk.WithRongCloudURI(s.URL))
+}
+
+func TestServer_User(t *testing.T) {
+	server := NewServer("appKey", "appSecret")
+	defer server.Close()
+	rc := newClient(server, "appSecret")
+
+	user, err := rc.UserRegister("u01", "name", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token, ok := server.Token("u01"); !ok || token != user.Token {
+		t.Errorf("token = %q, %v, want %q", token, ok, user.Token)
+	}
+
+	server.SetOnline("u01", true)
+	status, err := rc.OnlineStatusCheck("u01")
+	if err != nil || status != 1 {
+		t.Errorf("OnlineStatusCheck = %d, %v", status, err)
+	}
+
+	r, ok := server.LastRequest("/user/getToken")
+	if !ok || r.Form.Get("userId") != "u01" || r.Header.Get("Signature") == "" {
+		t.Errorf("LastRequest = %+v, %v", r, ok)
+	}
+}
+
+func TestServer_Errors(t *testing.T) {
+	server := NewServer("appKey", "appSecret")
+	defer server.Close()
+
+	_, err := newClient(server, "wrong").UserRegister("u01", "name", "")
+	if !errors.Is(err, sdk.ErrAuthentication) {
+		t.Errorf("wrong secret: err = %v", err)
+	}
+
+	rc := newClient(server, "appSecret")
+	_, err = rc.UserRegister("u01", "", "")
+	if err == nil {
+		t.Fatal("missing name: err = nil")
+	}
+
+	server.FailNext("/user/getToken", http.StatusInternalServerError, 1000)
+	_, err = rc.UserRegister("u01", "name", "")
+	var e *sdk.Error
+	if !errors.As(err, &e) || e.Code != 1000 || !errors.Is(err, sdk.ErrServer) || e.RequestID == "" {
+		t.Errorf("FailNext: err = %v", err)
+	}
+	if _, err = rc.UserRegister("u01", "name", ""); err != nil {
+		t.Errorf("after FailNext: err = %v", err)
+	}
+	if n := len(server.Requests("/user/getToken")); n != 3 {
+		t.Errorf("requests = %d, want 3", n)
+	}
+}
+
+func TestServer_Group(t *testing.T) {
+	server := NewServer("appKey", "appSecret")
+	defer server.Close()
+	rc := newClient(server, "appSecret")
+
+	if err := rc.GroupCreate("g01", "group", []string{"u01", "u02"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := rc.GroupJoin("g01", "group", "u03"); err != nil {
+		t.Fatal(err)
+	}
+	if err := rc.GroupQuit("u01", "g01"); err != nil {
+		t.Fatal(err)
+	}
+	group, err := rc.GroupGet("g01")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(group.Users) != 2 {
+		t.Errorf("users = %+v", group.Users)
+	}
+	if members := server.GroupMembers("g01"); len(members) != 2 || members[0] != "u02" {
+		t.Errorf("GroupMembers = %v", members)
+	}
+}
+
+func TestServer_ChatRoomEntry(t *testing.T) {
+	server := NewServer("appKey", "appSecret")
+	defer server.Close()
+	rc := newClient(server, "appSecret")
+
+	if err := rc.ChatRoomCreate("c01", "room"); err != nil {
+		t.Fatal(err)
+	}
+	if err := rc.ChatRoomEntrySet("c01", "u01", "k1", "v1", true); err != nil {
+		t.Fatal(err)
+	}
+	if err := rc.ChatRoomEntrySet("c01", "u01", "k2", "v2", false); err != nil {
+		t.Fatal(err)
+	}
+	if err := rc.ChatRoomEntryRemove("c01", "u01", "k2"); err != nil {
+		t.Fatal(err)
+	}
+	attrs, err := rc.ChatRoomEntryQuery("c01", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(attrs) != 1 || attrs[0].Key != "k1" || attrs[0].Value != "v1" || attrs[0].AutoDelete != "true" {
+		t.Errorf("attrs = %+v", attrs)
+	}
+}
+
+func TestServer_Message(t *testing.T) {
+	server := NewServer("appKey", "appSecret")
+	defer server.Close()
+	rc := newClient(server, "appSecret")
+
+	if err := rc.BlacklistAdd("u03", []string{"u01"}); err != nil {
+		t.Fatal(err)
+	}
+	msg := sdk.TXTMsg{Content: "hello"}
+	err := rc.PrivateSend("u01", []string{"u02", "u03"}, "RC:TxtMsg", &msg, "", "", 0, 1, 1, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	messages := server.Messages("/message/private/publish")
+	if len(messages) != 1 {
+		t.Fatalf("messages = %+v", messages)
+	}
+	m := messages[0]
+	if m.FromUserID != "u01" || m.ObjectName != "RC:TxtMsg" || len(m.TargetIDs) != 1 || m.TargetIDs[0] != "u02" {
+		t.Errorf("message = %+v", m)
+	}
+	if m.MsgUID == "" || m.Content == "" {
+		t.Errorf("message = %+v", m)
+	}
+
+	if err := rc.SensitiveAdd("bad", "***", 0); err != nil {
+		t.Fatal(err)
+	}
+	if words := server.SensitiveWords(); words["bad"] != "***" {
+		t.Errorf("SensitiveWords = %v", words)
+	}
+
+	server.Reset()
+	if len(server.Messages()) != 0 || len(server.Requests()) != 0 {
+		t.Error("Reset did not clear messages and requests")
+	}
+}
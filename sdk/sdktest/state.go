@@ -0,0 +1,179 @@
+package sdktest
+
+import (
+	"net/url"
+	"sort"
+	"time"
+)
+
+// state 模拟服务的内存数据
+type state struct {
+	users     map[string]*user
+	groups    map[string]*group
+	chatrooms map[string]*chatroom
+	// groupBan 全体禁言的群组
+	groupBan set
+	// chatroomBan 聊天室全局禁言的用户及截止时间
+	chatroomBan map[string]time.Time
+	// chatroomPriority 聊天室低优先级消息类型
+	chatroomPriority set
+	// chatroomMsgWhitelist 聊天室消息白名单
+	chatroomMsgWhitelist set
+	// mutedConversations 设置了免打扰的会话，见 conversationKey
+	mutedConversations set
+	sensitiveWords     map[string]sensitiveWord
+	messages           []Message
+	seq                int
+}
+
+func newState() state {
+	return state{
+		users:                map[string]*user{},
+		groups:               map[string]*group{},
+		chatrooms:            map[string]*chatroom{},
+		groupBan:             set{},
+		chatroomBan:          map[string]time.Time{},
+		chatroomPriority:     set{},
+		chatroomMsgWhitelist: set{},
+		mutedConversations:   set{},
+		sensitiveWords:       map[string]sensitiveWord{},
+	}
+}
+
+type user struct {
+	id           string
+	name         string
+	portraitURI  string
+	token        string
+	blockedUntil time.Time
+	online       bool
+	blacklist    set
+	whitelist    set
+	tags         []string
+}
+
+type group struct {
+	id      string
+	name    string
+	members map[string]time.Time // 成员及加入时间
+	gagged  map[string]time.Time // 禁言成员及截止时间
+	// banWhitelist 全体禁言时可以发言的成员
+	banWhitelist set
+}
+
+type chatroom struct {
+	id        string
+	name      string
+	created   time.Time
+	members   map[string]time.Time // 成员及加入时间
+	blocked   map[string]time.Time // 封禁成员及截止时间
+	gagged    map[string]time.Time // 禁言成员及截止时间
+	whitelist set
+	entries   map[string]chatroomEntry
+	keepalive bool
+	// stopped 停止消息分发
+	stopped bool
+}
+
+type chatroomEntry struct {
+	key        string
+	value      string
+	userID     string
+	autoDelete string
+	setTime    time.Time
+}
+
+type sensitiveWord struct {
+	word        string
+	replaceWord string
+}
+
+// Message 服务收到的消息，包括单聊、群聊、聊天室、系统消息、广播、推送及撤回
+type Message struct {
+	Path       string   // 接口路径，如 /message/private/publish
+	MsgUID     string   // 模拟服务生成的消息 ID
+	FromUserID string   // 发送人
+	TargetIDs  []string // 接收人、群组或聊天室
+	ObjectName string
+	Content    string     // 消息内容，推送及模板消息为 json 格式的请求体
+	Form       url.Values // 全部表单参数
+	Time       time.Time
+}
+
+// getUser 获取用户，不存在时创建
+func (st *state) getUser(id string) *user {
+	u, ok := st.users[id]
+	if !ok {
+		u = &user{id: id, blacklist: set{}, whitelist: set{}}
+		st.users[id] = u
+	}
+	return u
+}
+
+// getGroup 获取群组，不存在时创建
+func (st *state) getGroup(id string) *group {
+	g, ok := st.groups[id]
+	if !ok {
+		g = &group{
+			id:           id,
+			members:      map[string]time.Time{},
+			gagged:       map[string]time.Time{},
+			banWhitelist: set{},
+		}
+		st.groups[id] = g
+	}
+	return g
+}
+
+// getChatRoom 获取聊天室，不存在时创建
+func (st *state) getChatRoom(id string, now time.Time) *chatroom {
+	c, ok := st.chatrooms[id]
+	if !ok {
+		c = &chatroom{
+			id:        id,
+			created:   now,
+			members:   map[string]time.Time{},
+			blocked:   map[string]time.Time{},
+			gagged:    map[string]time.Time{},
+			whitelist: set{},
+			entries:   map[string]chatroomEntry{},
+		}
+		st.chatrooms[id] = c
+	}
+	return c
+}
+
+// userIDs 按字典序返回所有用户 ID
+func (st *state) userIDs() []string {
+	ids := make([]string, 0, len(st.users))
+	for id := range st.users {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// active 按字典序返回截止时间晚于 now 的 ID，同时清理已过期的记录
+func active(m map[string]time.Time, now time.Time) []string {
+	ids := make([]string, 0, len(m))
+	for id, until := range m {
+		if until.After(now) {
+			ids = append(ids, id)
+		} else {
+			delete(m, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// sortIDs 按 m 中的时间先后排序，时间相同时按字典序
+func sortIDs(ids []string, m map[string]time.Time) {
+	sort.Slice(ids, func(i, j int) bool {
+		ti, tj := m[ids[i]], m[ids[j]]
+		if ti.Equal(tj) {
+			return ids[i] < ids[j]
+		}
+		return ti.Before(tj)
+	})
+}
@@ -0,0 +1,203 @@
+package sdktest
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// required 校验必传参数，缺少时返回 1002
+func required(r *Request, names ...string) error {
+	for _, name := range names {
+		if r.Form.Get(name) == "" {
+			return errParam(name)
+		}
+	}
+	return nil
+}
+
+// minutes 读取以分钟为单位的时长参数
+func minutes(r *Request, name string) (time.Duration, error) {
+	minute, err := strconv.Atoi(r.Form.Get(name))
+	if err != nil || minute <= 0 {
+		return 0, errParam(name)
+	}
+	return time.Duration(minute) * time.Minute, nil
+}
+
+// SetOnline 设置用户在线状态，供 /user/checkOnline 查询
+func (s *Server) SetOnline(userID string, online bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.getUser(userID).online = online
+}
+
+// Token 获取用户的 token，用户未注册时 ok 为 false
+func (s *Server) Token(userID string) (token string, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u, ok := s.users[userID]
+	if !ok || u.token == "" {
+		return "", false
+	}
+	return u.token, true
+}
+
+func (s *Server) registerUser() {
+	s.handle("/user/getToken", func(r *Request) (result, error) {
+		if err := required(r, "userId", "name"); err != nil {
+			return nil, err
+		}
+		u := s.getUser(r.Form.Get("userId"))
+		u.name = r.Form.Get("name")
+		u.portraitURI = r.Form.Get("portraitUri")
+		s.seq++
+		u.token = fmt.Sprintf("%s@sdktest.%d", u.id, s.seq)
+		return result{"userId": u.id, "token": u.token}, nil
+	})
+	s.handle("/user/refresh", func(r *Request) (result, error) {
+		if err := required(r, "userId"); err != nil {
+			return nil, err
+		}
+		u := s.getUser(r.Form.Get("userId"))
+		if name := r.Form.Get("name"); name != "" {
+			u.name = name
+		}
+		if portraitURI := r.Form.Get("portraitUri"); portraitURI != "" {
+			u.portraitURI = portraitURI
+		}
+		return nil, nil
+	})
+	s.handle("/user/checkOnline", func(r *Request) (result, error) {
+		if err := required(r, "userId"); err != nil {
+			return nil, err
+		}
+		status := "0"
+		if u, ok := s.users[r.Form.Get("userId")]; ok && u.online {
+			status = "1"
+		}
+		return result{"status": status}, nil
+	})
+
+	s.handle("/user/block", func(r *Request) (result, error) {
+		if err := required(r, "userId"); err != nil {
+			return nil, err
+		}
+		d, err := minutes(r, "minute")
+		if err != nil {
+			return nil, err
+		}
+		s.getUser(r.Form.Get("userId")).blockedUntil = s.now().Add(d)
+		return nil, nil
+	})
+	s.handle("/user/unblock", func(r *Request) (result, error) {
+		if err := required(r, "userId"); err != nil {
+			return nil, err
+		}
+		s.getUser(r.Form.Get("userId")).blockedUntil = time.Time{}
+		return nil, nil
+	})
+	s.handle("/user/block/query", func(r *Request) (result, error) {
+		now := s.now()
+		users := []map[string]string{}
+		for _, id := range s.userIDs() {
+			if u := s.users[id]; u.blockedUntil.After(now) {
+				users = append(users, map[string]string{"userId": id, "blockEndTime": formatTime(u.blockedUntil)})
+			}
+		}
+		return result{"users": users}, nil
+	})
+
+	s.handle("/user/blacklist/add", func(r *Request) (result, error) {
+		if err := required(r, "userId", "blackUserId"); err != nil {
+			return nil, err
+		}
+		s.getUser(r.Form.Get("userId")).blacklist.add(r.Form["blackUserId"]...)
+		return nil, nil
+	})
+	s.handle("/user/blacklist/remove", func(r *Request) (result, error) {
+		if err := required(r, "userId", "blackUserId"); err != nil {
+			return nil, err
+		}
+		s.getUser(r.Form.Get("userId")).blacklist.remove(r.Form["blackUserId"]...)
+		return nil, nil
+	})
+	s.handle("/user/blacklist/query", func(r *Request) (result, error) {
+		if err := required(r, "userId"); err != nil {
+			return nil, err
+		}
+		return result{"users": s.getUser(r.Form.Get("userId")).blacklist.list()}, nil
+	})
+
+	s.handle("/user/whitelist/add", func(r *Request) (result, error) {
+		if err := required(r, "userId", "whiteUserId"); err != nil {
+			return nil, err
+		}
+		s.getUser(r.Form.Get("userId")).whitelist.add(r.Form["whiteUserId"]...)
+		return nil, nil
+	})
+	s.handle("/user/whitelist/remove", func(r *Request) (result, error) {
+		if err := required(r, "userId", "whiteUserId"); err != nil {
+			return nil, err
+		}
+		s.getUser(r.Form.Get("userId")).whitelist.remove(r.Form["whiteUserId"]...)
+		return nil, nil
+	})
+	s.handle("/user/whitelist/query", func(r *Request) (result, error) {
+		if err := required(r, "userId"); err != nil {
+			return nil, err
+		}
+		return result{"users": s.getUser(r.Form.Get("userId")).whitelist.list()}, nil
+	})
+
+	s.handle("/user/tag/set", func(r *Request) (result, error) {
+		var tag struct {
+			UserID string   `json:"userId"`
+			Tags   []string `json:"tags"`
+		}
+		if err := json.Unmarshal(r.Body, &tag); err != nil || tag.UserID == "" {
+			return nil, errParam("userId")
+		}
+		if len(tag.Tags) > 20 {
+			return nil, errLimit("tags")
+		}
+		s.getUser(tag.UserID).tags = tag.Tags
+		return nil, nil
+	})
+	s.handle("/user/tag/batch/set", func(r *Request) (result, error) {
+		var tag struct {
+			UserIDs []string `json:"userIds"`
+			Tags    []string `json:"tags"`
+		}
+		if err := json.Unmarshal(r.Body, &tag); err != nil || len(tag.UserIDs) == 0 {
+			return nil, errParam("userIds")
+		}
+		if len(tag.UserIDs) > 1000 {
+			return nil, errLimit("userIds")
+		}
+		if len(tag.Tags) > 20 {
+			return nil, errLimit("tags")
+		}
+		for _, id := range tag.UserIDs {
+			s.getUser(id).tags = tag.Tags
+		}
+		return nil, nil
+	})
+	s.handle("/user/tags/get", func(r *Request) (result, error) {
+		if err := required(r, "userIds"); err != nil {
+			return nil, err
+		}
+		if len(r.Form["userIds"]) > 50 {
+			return nil, errLimit("userIds")
+		}
+		tags := map[string][]string{}
+		for _, id := range r.Form["userIds"] {
+			tags[id] = []string{}
+			if u, ok := s.users[id]; ok && u.tags != nil {
+				tags[id] = u.tags
+			}
+		}
+		return result{"result": tags}, nil
+	})
+}
@@ -1,9 +1,8 @@
 package sdk
 
 import (
+	"context"
 	"encoding/json"
-	"github.com/astaxie/beego/httplib"
-	"time"
 )
 
 // ListWordFilterResult listWordFilter返回结果
@@ -27,15 +26,18 @@ type SensitiveWord struct {
 *@return error
  */
 func (rc *RongCloud) SensitiveAdd(keyword, replace string, sensitiveType int) error {
+	return rc.SensitiveAddContext(context.Background(), keyword, replace, sensitiveType)
+}
+
+// SensitiveAddContext 同 SensitiveAdd，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) SensitiveAddContext(ctx context.Context, keyword, replace string, sensitiveType int) error {
 	if keyword == "" {
 		return RCErrorNew(1002, "Paramer 'keyword' is required")
 	}
 	if replace == "" {
 		return RCErrorNew(1002, "Paramer 'replace' is required")
 	}
-	req := httplib.Post(rc.rongCloudURI + "/sensitiveword/add." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/sensitiveword/add")
 	req.Param("word", keyword)
 	switch sensitiveType {
 	case 0:
@@ -46,7 +48,7 @@ func (rc *RongCloud) SensitiveAdd(keyword, replace string, sensitiveType int) er
 		return RCErrorNew(1002, "Paramer 'replace' is required")
 	}
 
-	_, err := rc.do(req)
+	_, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 	}
@@ -58,12 +60,15 @@ func (rc *RongCloud) SensitiveAdd(keyword, replace string, sensitiveType int) er
 *@return ListWordFilterResult error
  */
 func (rc *RongCloud) SensitiveGetList() (ListWordFilterResult, error) {
+	return rc.SensitiveGetListContext(context.Background())
+}
 
-	req := httplib.Post(rc.rongCloudURI + "/sensitiveword/list." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+// SensitiveGetListContext 同 SensitiveGetList，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) SensitiveGetListContext(ctx context.Context) (ListWordFilterResult, error) {
 
-	resp, err := rc.do(req)
+	req := newRequest("/sensitiveword/list")
+
+	resp, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 		return ListWordFilterResult{}, err
@@ -84,18 +89,21 @@ func (rc *RongCloud) SensitiveGetList() (ListWordFilterResult, error) {
 *@return error
  */
 func (rc *RongCloud) SensitiveRemove(keywords []string) error {
+	return rc.SensitiveRemoveContext(context.Background(), keywords)
+}
+
+// SensitiveRemoveContext 同 SensitiveRemove，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) SensitiveRemoveContext(ctx context.Context, keywords []string) error {
 	if len(keywords) == 0 {
 		return RCErrorNew(1002, "Paramer 'keywords' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/sensitiveword/batch/delete." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/sensitiveword/batch/delete")
 	for _, v := range keywords {
 		req.Param("words", v)
 	}
 
-	_, err := rc.do(req)
+	_, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 	}
@@ -0,0 +1,100 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// span 属性名
+const (
+	TraceAttrEndpoint    = "rongcloud.endpoint"     // 接口路径
+	TraceAttrAppKey      = "rongcloud.app_key"      // appKey，不包含 appSecret
+	TraceAttrTargetCount = "rongcloud.target_count" // 目标 ID（接收用户、群组、聊天室等）数量
+	TraceAttrCode        = "rongcloud.code"         // 融云返回码，无法获取时为 0
+	TraceAttrHost        = "rongcloud.host"         // 实际请求的 API 地址，重试时为最后一次请求的地址
+	TraceAttrStatusCode  = "http.status_code"       // http 状态码，未收到响应时为 0
+)
+
+// targetParams 计入目标 ID 数量的表单参数或 json 请求体字段
+var targetParams = []string{"toUserId", "toGroupId", "toChatroomId", "targetId"}
+
+// Tracer 为每次 API 调用创建追踪 span，可适配 OpenTelemetry 等实现，默认不追踪
+type Tracer interface {
+	// Start 创建 span，ctx 中携带父 span，返回携带新 span 的 ctx
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+// Span 一次 API 调用的追踪 span
+type Span interface {
+	SetAttribute(key string, value interface{})
+	RecordError(err error)
+	End()
+}
+
+type noopTracer struct{}
+
+func (noopTracer) Start(ctx context.Context, name string) (context.Context, Span) {
+	return ctx, noopSpan{}
+}
+
+type noopSpan struct{}
+
+func (noopSpan) SetAttribute(key string, value interface{}) {}
+func (noopSpan) RecordError(err error)                      {}
+func (noopSpan) End()                                       {}
+
+type spanKey struct{}
+
+// spanFromContext 获取当前 API 调用的 span
+func spanFromContext(ctx context.Context) Span {
+	if span, ok := ctx.Value(spanKey{}).(Span); ok {
+		return span
+	}
+	return noopSpan{}
+}
+
+// startSpan 为 API 调用创建 span 并记录请求相关的属性，未设置 Tracer 时不创建
+func (rc *RongCloud) startSpan(ctx context.Context, r *request) (context.Context, Span) {
+	if _, ok := rc.tracer.(noopTracer); ok {
+		return ctx, noopSpan{}
+	}
+	ctx, span := rc.tracer.Start(ctx, "rongcloud "+r.path)
+	span.SetAttribute(TraceAttrEndpoint, r.path)
+	span.SetAttribute(TraceAttrAppKey, rc.AppKey())
+	span.SetAttribute(TraceAttrTargetCount, r.targetCount())
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+// targetCount 请求中目标 ID 的数量，json 请求体中的字段为数组或字符串
+func (r *request) targetCount() int {
+	targets := 0
+	for _, key := range targetParams {
+		targets += len(r.params[key])
+	}
+	if len(r.body) == 0 {
+		return targets
+	}
+	var body map[string]json.RawMessage
+	if err := json.Unmarshal(r.body, &body); err != nil {
+		return targets
+	}
+	for _, key := range targetParams {
+		var ids []string
+		var id string
+		if json.Unmarshal(body[key], &ids) == nil {
+			targets += len(ids)
+		} else if json.Unmarshal(body[key], &id) == nil && id != "" {
+			targets++
+		}
+	}
+	return targets
+}
+
+// endSpan 记录调用结果并结束 span
+func endSpan(span Span, err error) {
+	span.SetAttribute(TraceAttrCode, responseCode(err))
+	if err != nil {
+		span.RecordError(err)
+	}
+	span.End()
+}
@@ -0,0 +1,112 @@
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+type testSpan struct {
+	name   string
+	parent *testSpan
+	attrs  map[string]interface{}
+	err    error
+	ended  bool
+}
+
+func (s *testSpan) SetAttribute(key string, value interface{}) { s.attrs[key] = value }
+func (s *testSpan) RecordError(err error)                      { s.err = err }
+func (s *testSpan) End()                                       { s.ended = true }
+
+type testSpanKey struct{}
+
+type testTracer struct {
+	lock  sync.Mutex
+	spans []*testSpan
+}
+
+func (t *testTracer) Start(ctx context.Context, name string) (context.Context, Span) {
+	parent, _ := ctx.Value(testSpanKey{}).(*testSpan)
+	span := &testSpan{name: name, parent: parent, attrs: map[string]interface{}{}}
+	t.lock.Lock()
+	t.spans = append(t.spans, span)
+	t.lock.Unlock()
+	return context.WithValue(ctx, testSpanKey{}, span), span
+}
+
+func TestWithTracer(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"code":1002,"errorMessage":"invalid"}`))
+	}))
+	defer server.Close()
+
+	tracer := &testTracer{}
+	rc := New("appKey", "appSecret", WithRongCloudURI(server.URL), WithTracer(tracer))
+
+	parent := &testSpan{name: "handler"}
+	ctx := context.WithValue(context.Background(), testSpanKey{}, parent)
+	err := rc.GroupSendContext(ctx, "u01", []string{"g01", "g02"}, nil, "RC:TxtMsg", &TXTMsg{Content: "hello"},
+		"", "", 1, 0)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	if len(tracer.spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(tracer.spans))
+	}
+	span := tracer.spans[0]
+	if span.name != "rongcloud /message/group/publish" || span.parent != parent || !span.ended || span.err != err {
+		t.Errorf("invalid span: %+v", span)
+	}
+	expected := map[string]interface{}{
+		TraceAttrEndpoint:    "/message/group/publish",
+		TraceAttrAppKey:      "appKey",
+		TraceAttrTargetCount: 2,
+		TraceAttrCode:        1002,
+		TraceAttrHost:        server.URL,
+		TraceAttrStatusCode:  200,
+	}
+	for k, v := range expected {
+		if span.attrs[k] != v {
+			t.Errorf("attribute %s: expected %v, got %v", k, v, span.attrs[k])
+		}
+	}
+	for _, v := range span.attrs {
+		if v == "appSecret" {
+			t.Error("app secret should not be recorded")
+		}
+	}
+}
+
+func TestRequest_targetCount(t *testing.T) {
+	r := newRequest("/message/private/publish").Param("toUserId", "u01").Param("toUserId", "u02")
+	if n := r.targetCount(); n != 2 {
+		t.Errorf("form: %d", n)
+	}
+	// 模板消息等使用 json 请求体的接口
+	r, _ = newRequest("/message/private/publish_template").JSONBody(map[string]interface{}{
+		"fromUserId": "u00",
+		"toUserId":   []string{"u01", "u02", "u03"},
+	})
+	if n := r.targetCount(); n != 3 {
+		t.Errorf("json array: %d", n)
+	}
+	r, _ = newRequest("/chatroom/query").JSONBody(map[string]interface{}{"targetId": "c01"})
+	if n := r.targetCount(); n != 1 {
+		t.Errorf("json string: %d", n)
+	}
+}
+
+func TestRongCloud_startSpanNoop(t *testing.T) {
+	rc := New("appKey", "appSecret")
+	ctx := context.Background()
+	spanCtx, span := rc.startSpan(ctx, newRequest("/user/getToken"))
+	if spanCtx != ctx {
+		t.Error("noop tracer should not wrap ctx")
+	}
+	if _, ok := span.(noopSpan); !ok {
+		t.Errorf("span = %T", span)
+	}
+}
@@ -9,11 +9,9 @@
 package sdk
 
 import (
+	"context"
 	"encoding/json"
 	"strconv"
-	"time"
-
-	"github.com/astaxie/beego/httplib"
 )
 
 // User 用户信息 返回信息
@@ -66,6 +64,11 @@ type WhiteList struct {
  * @return: error
  */
 func (rc *RongCloud) AddWhiteList(userId string, whiteList []string) error {
+	return rc.AddWhiteListContext(context.Background(), userId, whiteList)
+}
+
+// AddWhiteListContext 同 AddWhiteList，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) AddWhiteListContext(ctx context.Context, userId string, whiteList []string) error {
 	if userId == "" {
 		return RCErrorNew(1002, "Paramer 'userId' is required")
 	}
@@ -78,15 +81,13 @@ func (rc *RongCloud) AddWhiteList(userId string, whiteList []string) error {
 		return RCErrorNew(1002, "Length of paramer 'whiteList' must less than 20")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/user/whitelist/add." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/user/whitelist/add")
 	req.Param("userId", userId)
 	for _, v := range whiteList {
 		req.Param("whiteUserId", v)
 	}
 
-	_, err := rc.do(req)
+	_, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 	}
@@ -102,6 +103,11 @@ func (rc *RongCloud) AddWhiteList(userId string, whiteList []string) error {
  * @return: error
  */
 func (rc *RongCloud) RemoveWhiteList(userId string, whiteList []string) error {
+	return rc.RemoveWhiteListContext(context.Background(), userId, whiteList)
+}
+
+// RemoveWhiteListContext 同 RemoveWhiteList，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) RemoveWhiteListContext(ctx context.Context, userId string, whiteList []string) error {
 	if userId == "" {
 		return RCErrorNew(1002, "Paramer 'userId' is required")
 	}
@@ -114,15 +120,13 @@ func (rc *RongCloud) RemoveWhiteList(userId string, whiteList []string) error {
 		return RCErrorNew(1002, "Length of paramer 'whiteList' must less than 20")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/user/whitelist/remove." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/user/whitelist/remove")
 	req.Param("userId", userId)
 	for _, v := range whiteList {
 		req.Param("whiteUserId", v)
 	}
 
-	_, err := rc.do(req)
+	_, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 	}
@@ -137,16 +141,19 @@ func (rc *RongCloud) RemoveWhiteList(userId string, whiteList []string) error {
  * @return: WhiteList error
  */
 func (rc *RongCloud) QueryWhiteList(userId string) (WhiteList, error) {
+	return rc.QueryWhiteListContext(context.Background(), userId)
+}
+
+// QueryWhiteListContext 同 QueryWhiteList，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) QueryWhiteListContext(ctx context.Context, userId string) (WhiteList, error) {
 	if userId == "" {
 		return WhiteList{}, RCErrorNew(1002, "Paramer 'userId' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/user/whitelist/query." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/user/whitelist/query")
 	req.Param("userId", userId)
 
-	resp, err := rc.do(req)
+	resp, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 		return WhiteList{}, err
@@ -168,6 +175,11 @@ func (rc *RongCloud) QueryWhiteList(userId string) (WhiteList, error) {
 *@return User, error
  */
 func (rc *RongCloud) UserRegister(userID, name, portraitURI string) (User, error) {
+	return rc.UserRegisterContext(context.Background(), userID, name, portraitURI)
+}
+
+// UserRegisterContext 同 UserRegister，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) UserRegisterContext(ctx context.Context, userID, name, portraitURI string) (User, error) {
 	if userID == "" {
 		return User{}, RCErrorNew(1002, "Paramer 'userID' is required")
 	}
@@ -175,16 +187,14 @@ func (rc *RongCloud) UserRegister(userID, name, portraitURI string) (User, error
 		return User{}, RCErrorNew(1002, "Paramer 'name' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/user/getToken." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/user/getToken")
 	req.Param("userId", userID)
 	req.Param("name", name)
 	if portraitURI != "" {
 		req.Param("portraitUri", portraitURI)
 	}
 
-	resp, err := rc.do(req)
+	resp, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 		return User{}, err
@@ -206,18 +216,21 @@ func (rc *RongCloud) UserRegister(userID, name, portraitURI string) (User, error
 *@return error
  */
 func (rc *RongCloud) UserUpdate(userID, name, portraitURI string) error {
+	return rc.UserUpdateContext(context.Background(), userID, name, portraitURI)
+}
+
+// UserUpdateContext 同 UserUpdate，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) UserUpdateContext(ctx context.Context, userID, name, portraitURI string) error {
 	if userID == "" {
 		return RCErrorNew(1002, "Paramer 'userID' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/user/refresh." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/user/refresh")
 	req.Param("userId", userID)
 	req.Param("name", name)
 	req.Param("portraitUri", portraitURI)
 
-	_, err := rc.do(req)
+	_, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 	}
@@ -232,6 +245,11 @@ func (rc *RongCloud) UserUpdate(userID, name, portraitURI string) error {
 *@return error
  */
 func (rc *RongCloud) BlockAdd(id string, minute uint64) error {
+	return rc.BlockAddContext(context.Background(), id, minute)
+}
+
+// BlockAddContext 同 BlockAdd，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) BlockAddContext(ctx context.Context, id string, minute uint64) error {
 	if id == "" {
 		return RCErrorNew(1002, "Paramer 'id' is required")
 	}
@@ -239,13 +257,11 @@ func (rc *RongCloud) BlockAdd(id string, minute uint64) error {
 		return RCErrorNew(20004, "封禁时间不正确, 当前传入为 , 正确范围 1 - 1 * 30 * 24 * 60 分钟")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/user/block." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/user/block")
 	req.Param("userId", id)
 	req.Param("minute", strconv.FormatUint(minute, 10))
 
-	_, err := rc.do(req)
+	_, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 	}
@@ -259,15 +275,18 @@ func (rc *RongCloud) BlockAdd(id string, minute uint64) error {
 *@return error
  */
 func (rc *RongCloud) BlockRemove(id string) error {
+	return rc.BlockRemoveContext(context.Background(), id)
+}
+
+// BlockRemoveContext 同 BlockRemove，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) BlockRemoveContext(ctx context.Context, id string) error {
 	if id == "" {
 		return RCErrorNew(1002, "Paramer 'id' is required")
 	}
-	req := httplib.Post(rc.rongCloudURI + "/user/unblock." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/user/unblock")
 	req.Param("userId", id)
 
-	_, err := rc.do(req)
+	_, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 	}
@@ -279,11 +298,14 @@ func (rc *RongCloud) BlockRemove(id string) error {
 *@return QueryBlockUserResult error
  */
 func (rc *RongCloud) BlockGetList() (BlockListResult, error) {
-	req := httplib.Post(rc.rongCloudURI + "/user/block/query." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	return rc.BlockGetListContext(context.Background())
+}
 
-	resp, err := rc.do(req)
+// BlockGetListContext 同 BlockGetList，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) BlockGetListContext(ctx context.Context) (BlockListResult, error) {
+	req := newRequest("/user/block/query")
+
+	resp, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 		return BlockListResult{}, err
@@ -305,6 +327,11 @@ func (rc *RongCloud) BlockGetList() (BlockListResult, error) {
 *@return error
  */
 func (rc *RongCloud) BlacklistAdd(id string, blacklist []string) error {
+	return rc.BlacklistAddContext(context.Background(), id, blacklist)
+}
+
+// BlacklistAddContext 同 BlacklistAdd，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) BlacklistAddContext(ctx context.Context, id string, blacklist []string) error {
 	if id == "" {
 		return RCErrorNew(1002, "Paramer 'id' is required")
 	}
@@ -312,15 +339,13 @@ func (rc *RongCloud) BlacklistAdd(id string, blacklist []string) error {
 		return RCErrorNew(1002, "Paramer 'blacklist' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/user/blacklist/add." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/user/blacklist/add")
 	req.Param("userId", id)
 	for _, v := range blacklist {
 		req.Param("blackUserId", v)
 	}
 
-	_, err := rc.do(req)
+	_, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 	}
@@ -335,6 +360,11 @@ func (rc *RongCloud) BlacklistAdd(id string, blacklist []string) error {
 *@return error
  */
 func (rc *RongCloud) BlacklistRemove(id string, blacklist []string) error {
+	return rc.BlacklistRemoveContext(context.Background(), id, blacklist)
+}
+
+// BlacklistRemoveContext 同 BlacklistRemove，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) BlacklistRemoveContext(ctx context.Context, id string, blacklist []string) error {
 	if id == "" {
 		return RCErrorNew(1002, "Paramer 'id' is required")
 	}
@@ -342,15 +372,13 @@ func (rc *RongCloud) BlacklistRemove(id string, blacklist []string) error {
 		return RCErrorNew(1002, "Paramer 'blacklist' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/user/blacklist/remove." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/user/blacklist/remove")
 	req.Param("userId", id)
 	for _, v := range blacklist {
 		req.Param("blackUserId", v)
 	}
 
-	_, err := rc.do(req)
+	_, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 	}
@@ -364,16 +392,19 @@ func (rc *RongCloud) BlacklistRemove(id string, blacklist []string) error {
 *@return BlacklistResult error
  */
 func (rc *RongCloud) BlacklistGet(id string) (BlacklistResult, error) {
+	return rc.BlacklistGetContext(context.Background(), id)
+}
+
+// BlacklistGetContext 同 BlacklistGet，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) BlacklistGetContext(ctx context.Context, id string) (BlacklistResult, error) {
 	if id == "" {
 		return BlacklistResult{}, RCErrorNew(1002, "Paramer 'id' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/user/blacklist/query." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	req := newRequest("/user/blacklist/query")
 	req.Param("userId", id)
 
-	resp, err := rc.do(req)
+	resp, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 		return BlacklistResult{}, err
@@ -386,23 +417,38 @@ func (rc *RongCloud) BlacklistGet(id string) (BlacklistResult, error) {
 	return listResult, nil
 }
 
-// OnlineStatusCheck 检查用户在线状态
+// PresenceCache 用户在线状态缓存，可使用 callback.Presence 根据融云在线状态回调维护
+type PresenceCache interface {
+	// OnlineStatus 返回用户在线状态（1 在线，0 不在线），ok 为 false 表示缓存未命中
+	OnlineStatus(userID string) (status int, ok bool)
+}
+
+// OnlineStatusCheck 检查用户在线状态，返回 1 在线，0 不在线。设置了 WithPresenceCache 时优先查询缓存
 /*
 *@param  userID:用户 ID，最大长度 64 字节.是用户在 App 中的唯一标识码，必须保证在同一个 App 内不重复，重复的用户 Id 将被当作是同一用户。
 *
 *@return int, error
  */
 func (rc *RongCloud) OnlineStatusCheck(userID string) (int, error) {
+	return rc.OnlineStatusCheckContext(context.Background(), userID)
+}
+
+// OnlineStatusCheckContext 同 OnlineStatusCheck，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) OnlineStatusCheckContext(ctx context.Context, userID string) (int, error) {
 	if userID == "" {
 		return -1, RCErrorNew(1002, "Paramer 'userID' is required")
 	}
 
-	req := httplib.Post(rc.rongCloudURI + "/user/checkOnline." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	if rc.presence != nil {
+		if status, ok := rc.presence.OnlineStatus(userID); ok {
+			return status, nil
+		}
+	}
+
+	req := newRequest("/user/checkOnline")
 	req.Param("userId", userID)
 
-	resp, err := rc.do(req)
+	resp, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 		return -1, err
@@ -422,16 +468,19 @@ func (rc *RongCloud) OnlineStatusCheck(userID string) (int, error) {
 *@return error
  */
 func (rc *RongCloud) TagSet(tag Tag) error {
-	req := httplib.Post(rc.rongCloudURI + "/user/tag/set." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	return rc.TagSetContext(context.Background(), tag)
+}
+
+// TagSetContext 同 TagSet，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) TagSetContext(ctx context.Context, tag Tag) error {
+	req := newRequest("/user/tag/set")
 	req, err := req.JSONBody(tag)
 	if err != nil {
 		rc.urlError(err)
 		return err
 	}
 
-	_, err = rc.do(req)
+	_, err = rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 	}
@@ -445,16 +494,19 @@ func (rc *RongCloud) TagSet(tag Tag) error {
 *@return error
  */
 func (rc *RongCloud) TagBatchSet(tagBatch TagBatch) error {
-	req := httplib.Post(rc.rongCloudURI + "/user/tag/batch/set." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	return rc.TagBatchSetContext(context.Background(), tagBatch)
+}
+
+// TagBatchSetContext 同 TagBatchSet，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) TagBatchSetContext(ctx context.Context, tagBatch TagBatch) error {
+	req := newRequest("/user/tag/batch/set")
 	req, err := req.JSONBody(tagBatch)
 	if err != nil {
 		rc.urlError(err)
 		return err
 	}
 
-	_, err = rc.do(req)
+	_, err = rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 	}
@@ -468,14 +520,17 @@ func (rc *RongCloud) TagBatchSet(tagBatch TagBatch) error {
 *@return error
  */
 func (rc *RongCloud) TagGet(userIds []string) (TagResult, error) {
-	req := httplib.Post(rc.rongCloudURI + "/user/tags/get." + ReqType)
-	req.SetTimeout(time.Second*rc.timeout, time.Second*rc.timeout)
-	rc.fillHeader(req)
+	return rc.TagGetContext(context.Background(), userIds)
+}
+
+// TagGetContext 同 TagGet，可通过 ctx 取消请求或设置截止时间
+func (rc *RongCloud) TagGetContext(ctx context.Context, userIds []string) (TagResult, error) {
+	req := newRequest("/user/tags/get")
 	for _, v := range userIds {
 		req.Param("userIds", v)
 	}
 
-	resp, err := rc.do(req)
+	resp, err := rc.do(ctx, req)
 	if err != nil {
 		rc.urlError(err)
 		return TagResult{}, err